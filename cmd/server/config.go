@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// The settings come from, in order of precedence:
+//  1. the command line flags
+//  2. environment variables, named like the flags with the envPrefix, in upper case and with underscores (SSB_ROOM_HTTPS_DOMAIN)
+//  3. the config file (config.toml in the repo, or the one passed with -config), with the names of the flags as keys
+//  4. the defaults of the flags
+const (
+	envPrefix      = "SSB_ROOM_"
+	configFileName = "config.toml"
+)
+
+// repeatableFlags can be passed more than once. A list in the config file passes every entry on its own.
+// Lists for the other flags are joined with commas.
+var repeatableFlags = map[string]bool{
+	"public-addr":     true,
+	"federation-peer": true,
+}
+
+// commandLineOnly can't be set from the config file, since they are needed to find it
+var commandLineOnly = map[string]bool{
+	"config": true,
+	"repo":   true,
+}
+
+// envName returns the name of the environment variable for a flag
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.Replace(flagName, "-", "_", -1))
+}
+
+// applyEnvironment sets the flags that were not passed on the command line from the environment.
+// It returns the names of all the flags that are now set.
+func applyEnvironment(fs *flag.FlagSet, lookup func(string) (string, bool)) (map[string]bool, error) {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || set[f.Name] {
+			return
+		}
+		val, has := lookup(envName(f.Name))
+		if !has {
+			return
+		}
+		if setErr := fs.Set(f.Name, val); setErr != nil {
+			err = fmt.Errorf("invalid value for %s: %w", envName(f.Name), setErr)
+			return
+		}
+		set[f.Name] = true
+	})
+	return set, err
+}
+
+// applyConfigFile sets the flags that are not in alreadySet from the TOML file at path.
+// If mustExist is false, a missing file is not an error and false is returned.
+func applyConfigFile(fs *flag.FlagSet, path string, mustExist bool, alreadySet map[string]bool) (bool, error) {
+	var values map[string]interface{}
+	_, err := toml.DecodeFile(path, &values)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !mustExist {
+			return false, nil
+		}
+		return false, fmt.Errorf("config file: %w", err)
+	}
+
+	for name, val := range values {
+		f := fs.Lookup(name)
+		if f == nil {
+			return false, fmt.Errorf("config file: unknown setting %q", name)
+		}
+		if commandLineOnly[name] {
+			return false, fmt.Errorf("config file: %s can only be set on the command line or with %s", name, envName(name))
+		}
+		if alreadySet[name] {
+			continue
+		}
+
+		strs, err := configValueStrings(val)
+		if err != nil {
+			return false, fmt.Errorf("config file: %s: %w", name, err)
+		}
+		if !repeatableFlags[name] {
+			strs = []string{strings.Join(strs, ",")}
+		}
+		for _, s := range strs {
+			if err := fs.Set(name, s); err != nil {
+				return false, fmt.Errorf("config file: invalid value for %s: %w", name, err)
+			}
+		}
+	}
+	return true, nil
+}
+
+// configValueStrings turns a TOML value into the strings that are passed to flag.Set
+func configValueStrings(val interface{}) ([]string, error) {
+	switch v := val.(type) {
+	case string:
+		return []string{v}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case int64:
+		return []string{strconv.FormatInt(v, 10)}, nil
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case []interface{}:
+		var strs []string
+		for _, elem := range v {
+			s, err := configValueStrings(elem)
+			if err != nil {
+				return nil, err
+			}
+			if len(s) != 1 {
+				return nil, fmt.Errorf("nested lists are not supported")
+			}
+			strs = append(strs, s[0])
+		}
+		return strs, nil
+	}
+	return nil, fmt.Errorf("unsupported value of type %T", val)
+}
+
+// loadReloadableSettings reads the command line, the environment and the config file again, with the same precedence as on startup.
+// Only the reloadable settings are returned, the others are ignored.
+func loadReloadableSettings() (reloadableSettings, error) {
+	var s reloadableSettings
+
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	s.register(fs)
+	ignoreOtherFlags(fs, flag.CommandLine)
+
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		return s, err
+	}
+	set, err := applyEnvironment(fs, os.LookupEnv)
+	if err != nil {
+		return s, err
+	}
+	if _, err := applyConfigFile(fs, configPath, configRequired, set); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// ignoredFlag accepts a flag without storing it. It is used to parse the command line again for the reloadable settings.
+type ignoredFlag struct{ isBool bool }
+
+func (ignoredFlag) String() string     { return "" }
+func (ignoredFlag) Set(string) error   { return nil }
+func (f ignoredFlag) IsBoolFlag() bool { return f.isBool }
+
+// ignoreOtherFlags defines the flags of from, that fs doesn't have, as ignored flags on fs
+func ignoreOtherFlags(fs, from *flag.FlagSet) {
+	from.VisitAll(func(f *flag.Flag) {
+		if fs.Lookup(f.Name) != nil {
+			return
+		}
+		bf, isBool := f.Value.(interface{ IsBoolFlag() bool })
+		fs.Var(ignoredFlag{isBool: isBool && bf.IsBoolFlag()}, f.Name, f.Usage)
+	})
+}
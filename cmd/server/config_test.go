@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigPrecedence(t *testing.T) {
+	r := require.New(t)
+
+	var (
+		domain, listen, repo string
+		peers                []string
+		ping                 time.Duration
+		s                    reloadableSettings
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&domain, "https-domain", "", "")
+	fs.StringVar(&listen, "lismux", ":8008", "")
+	fs.StringVar(&repo, "repo", "", "")
+	fs.DurationVar(&ping, "websocket-ping", time.Second, "")
+	fs.Func("public-addr", "", func(val string) error {
+		peers = append(peers, val)
+		return nil
+	})
+	s.register(fs)
+
+	cfg := filepath.Join(t.TempDir(), configFileName)
+	r.NoError(os.WriteFile(cfg, []byte(`
+https-domain = "file.example"
+lismux = [":8008", "unix:/tmp/room.sock"]
+public-addr = ["net:192.0.2.1:8008", "net:[2001:db8::1]:8008"]
+websocket-ping = "45s"
+loglevel = "debug"
+max-conns = 10
+hsts-preload = true
+`), 0600))
+
+	r.NoError(fs.Parse([]string{"-loglevel", "warn"}))
+
+	env := map[string]string{
+		"SSB_ROOM_HTTPS_DOMAIN": "env.example",
+		"SSB_ROOM_LOGLEVEL":     "error",
+	}
+	set, err := applyEnvironment(fs, func(k string) (string, bool) {
+		v, has := env[k]
+		return v, has
+	})
+	r.NoError(err)
+
+	loaded, err := applyConfigFile(fs, cfg, true, set)
+	r.NoError(err)
+	r.True(loaded)
+
+	r.Equal("warn", s.logLevel, "the flag wins")
+	r.Equal("env.example", domain, "the environment wins over the file")
+	r.Equal(":8008,unix:/tmp/room.sock", listen, "lists are joined")
+	r.Equal([]string{"net:192.0.2.1:8008", "net:[2001:db8::1]:8008"}, peers, "repeatable flags are set for every entry")
+	r.Equal(45*time.Second, ping)
+	r.Equal(10, s.connLimits.MaxConns)
+	r.Equal(32, s.connLimits.MaxConnsPerIP, "the default")
+	r.True(s.hstsPreload)
+
+	// a missing default file is fine, a missing explicit one is not
+	loaded, err = applyConfigFile(fs, cfg+".missing", false, set)
+	r.NoError(err)
+	r.False(loaded)
+	_, err = applyConfigFile(fs, cfg+".missing", true, set)
+	r.Error(err)
+
+	for _, bad := range []string{
+		`unknown-setting = 1`,
+		`repo = "/somewhere"`,
+		`max-conns = "many"`,
+		`[table]` + "\n" + `key = 1`,
+	} {
+		r.NoError(os.WriteFile(cfg, []byte(bad), 0600))
+		_, err = applyConfigFile(fs, cfg, true, set)
+		r.Error(err, bad)
+	}
+}
+
+func TestIgnoreOtherFlags(t *testing.T) {
+	r := require.New(t)
+
+	var domain string
+	var noUnixSock bool
+	all := flag.NewFlagSet("all", flag.ContinueOnError)
+	all.StringVar(&domain, "https-domain", "", "")
+	all.BoolVar(&noUnixSock, "nounixsock", false, "")
+	var s reloadableSettings
+	s.register(all)
+
+	var reloaded reloadableSettings
+	fs := flag.NewFlagSet("reload", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	reloaded.register(fs)
+	ignoreOtherFlags(fs, all)
+
+	// the bool flag doesn't swallow the next argument
+	r.NoError(fs.Parse(strings.Fields("-https-domain room.example -nounixsock -http-rate 9")))
+	r.Equal(9, reloaded.httpRate)
+	r.Equal("", domain)
+}
@@ -9,6 +9,8 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"flag"
 	"fmt"
@@ -26,9 +28,6 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/ssbc/go-muxrpc/v2/debug"
-	"github.com/throttled/throttled/v2"
-	"github.com/throttled/throttled/v2/store/memstore"
-	"github.com/unrolled/secure"
 	kitlog "go.mindeco.de/log"
 	"go.mindeco.de/log/level"
 	_ "modernc.org/sqlite"
@@ -36,10 +35,12 @@ import (
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/internal/repo"
 	"github.com/ssbc/go-ssb-room/v2/internal/signinwithssb"
+	"github.com/ssbc/go-ssb-room/v2/internal/tlscert"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
 	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite"
 	"github.com/ssbc/go-ssb-room/v2/roomsrv"
 	mksrv "github.com/ssbc/go-ssb-room/v2/roomsrv"
+	"github.com/ssbc/go-ssb-room/v2/roomstate"
 	"github.com/ssbc/go-ssb-room/v2/web/handlers"
 )
 
@@ -57,19 +58,50 @@ var (
 
 	listenAddrShsMux string
 	listenAddrHTTP   string
+	listenAddrHTTPS  string
+	listenAddrOnion  string
+
+	onionHostname string
+	onionPort     uint
 
 	httpsDomain string
 
+	tlsOpts     tlscert.Options
+	acmeCARoots string
+
 	aliasesAsSubdomains bool
 
+	publicAddresses []string
+
+	federationPeers []string
+
+	keepAliveInterval  time.Duration
+	keepAliveMaxMissed int
+
+	presenceRetention time.Duration
+
+	// the settings that are applied again on SIGHUP
+	reloadable reloadableSettings
+
+	trustedProxies network.TrustedProxies
+	proxyProtocol  bool
+
+	websocketOpts network.WebsocketOptions
+	websocketPath string
+
 	listenAddrDebug string
 	logToFile       string
 	repoDir         string
 
+	configFile     string
+	configPath     string
+	configRequired bool
+
 	privacyMode = roomdb.ModeUnknown
 
 	// helper
-	log kitlog.Logger
+	log      kitlog.Logger
+	levelLog *levelLogger
 
 	// juicy bits
 	appKey string
@@ -88,14 +120,18 @@ func checkAndLog(err error) {
 	}
 }
 
-func initFlags() {
+func initFlags() error {
 	u, err := user.Current()
 	checkFatal(err)
 
 	flag.StringVar(&appKey, "shscap", "1KHLiKZvAvjbY1ziZEHMXawbCEIM6qwjCDm3VYRan/s=", "secret-handshake app-key or capability; should likely not be changed as this makes you part of a different network")
 
-	flag.StringVar(&listenAddrShsMux, "lismux", ":8008", "address to listen on for secret-handshake+muxrpc")
+	flag.StringVar(&listenAddrShsMux, "lismux", ":8008", "comma separated addresses to listen on for secret-handshake+muxrpc. The first one is the main one, the others can be host:port or unix:/path")
 	flag.StringVar(&listenAddrHTTP, "lishttp", ":3000", "address to listen on for HTTP requests")
+	flag.StringVar(&listenAddrOnion, "lisonion", "", "address (host:port or unix:/path) to listen on for secret-handshake+muxrpc forwarded from a tor onion service")
+
+	flag.StringVar(&onionHostname, "onion-hostname", "", "the .onion name of the room's onion service, or the path to the hostname file in tor's HiddenServiceDir")
+	flag.UintVar(&onionPort, "onion-port", network.DefaultPort, "the virtual port of the onion service (HiddenServicePort)")
 
 	flag.BoolVar(&flagDisableUNIXSock, "nounixsock", false, "disable the UNIX socket RPC interface")
 
@@ -106,6 +142,15 @@ func initFlags() {
 
 	flag.StringVar(&httpsDomain, "https-domain", "", "which domain to use for TLS and AllowedHosts checks")
 
+	flag.StringVar(&listenAddrHTTPS, "lishttps", ":443", "address to listen on for HTTPS requests, if -tls-cert or -acme is used")
+	flag.StringVar(&tlsOpts.CertFile, "tls-cert", "", "serve HTTPS with this PEM certificate chain, like the fullchain.pem of certbot (needs -tls-key)")
+	flag.StringVar(&tlsOpts.KeyFile, "tls-key", "", "the PEM private key of -tls-cert")
+	flag.BoolVar(&tlsOpts.ACME, "acme", false, "serve HTTPS with certificates from an ACME CA, like Let's Encrypt. The -lishttp listener needs to be reachable on port 80 of the https-domain")
+	flag.StringVar(&tlsOpts.Email, "acme-email", "", "contact address for the ACME account, for expiry notices")
+	flag.StringVar(&tlsOpts.DirectoryURL, "acme-directory", tlscert.LetsEncryptURL, "directory URL of the ACME CA. Use the staging directory of Let's Encrypt or a local pebble for testing")
+	flag.StringVar(&acmeCARoots, "acme-ca-roots", "", "PEM file with additional root certificates to trust for the ACME CA, like the one of pebble")
+	flag.StringVar(&tlsOpts.DNSHook, "acme-dns-hook", "", "program that creates and removes the TXT records for the DNS-01 challenge of the wildcard certificate for the alias subdomains. It is called with present or cleanup, the name of the record and its value")
+
 	flag.BoolVar(&flagPrintVersion, "version", false, "print version number and build date")
 
 	flag.Func("mode", "the privacy mode (values: open, community, restricted) determining room access controls", func(val string) error {
@@ -120,8 +165,69 @@ func initFlags() {
 
 	flag.BoolVar(&aliasesAsSubdomains, "aliases-as-subdomains", true, "needs to be disabled if a wildcard certificate for the room is not available. (stub until we have the admin/settings page)")
 
+	flag.Func("public-addr", "additional multiserver transport address the room is reachable under, like net:[2001:db8::1]:8008 or wss://room.example:443 (can be passed multiple times)", func(val string) error {
+		if err := network.ValidateTransportAddress(val); err != nil {
+			return err
+		}
+		publicAddresses = append(publicAddresses, val)
+		return nil
+	})
+
+	flag.Func("federation-peer", "multiserver address (net:host:port~shs:key) of a trusted sibling room to share attendants with (can be passed multiple times)", func(val string) error {
+		if _, _, err := network.ParseMultiserverAddress(val); err != nil {
+			return err
+		}
+		federationPeers = append(federationPeers, val)
+		return nil
+	})
+
+	flag.DurationVar(&keepAliveInterval, "keepalive", roomstate.DefaultKeepAliveInterval, "how often connected peers are pinged (0 disables the pings)")
+	flag.IntVar(&keepAliveMaxMissed, "keepalive-missed", roomstate.DefaultKeepAliveMaxMissed, "how many pings in a row a peer can miss before it is disconnected")
+
+	reloadable.register(flag.CommandLine)
+
+	trustedProxies, _ = network.ParseTrustedProxies(network.DefaultTrustedProxies)
+	flag.Func("trusted-proxies", "comma separated list of CIDR ranges of reverse proxies. Only their X-Forwarded-For and Forwarded headers and PROXY protocol headers are used (default "+strings.Join(network.DefaultTrustedProxies, ",")+")", func(val string) error {
+		var err error
+		trustedProxies, err = network.ParseTrustedProxies(strings.Split(val, ","))
+		return err
+	})
+	flag.BoolVar(&proxyProtocol, "proxy-protocol", false, "read the PROXY protocol header on muxrpc connections from trusted proxies and unix sockets")
+
+	flag.Func("websocket-origins", "comma separated list of hosts browsers can open websocket connections from, like app.example or *.app.example. * allows all (default: the https-domain and its subdomains)", func(val string) error {
+		for _, o := range strings.Split(val, ",") {
+			if o = strings.TrimSpace(o); o != "" {
+				websocketOpts.AllowedOrigins = append(websocketOpts.AllowedOrigins, o)
+			}
+		}
+		return nil
+	})
+	flag.Int64Var(&websocketOpts.MaxMessageSize, "websocket-max-message", network.DefaultWebsocketMaxMessageSize, "largest websocket message in bytes a peer can send (-1 for no limit)")
+	flag.DurationVar(&websocketOpts.PingInterval, "websocket-ping", network.DefaultWebsocketPingInterval, "how often websocket peers are pinged (-1s disables the pings)")
+	flag.DurationVar(&websocketOpts.IdleTimeout, "websocket-idle-timeout", network.DefaultWebsocketIdleTimeout, "close websocket connections that didn't send anything for this long (-1s disables it)")
+	flag.StringVar(&websocketPath, "websocket-path", "", "serve websocket connections only on this path, like /ws. By default websocket upgrades are accepted on every path")
+
+	flag.DurationVar(&presenceRetention, "presence-retention", sqlite.DefaultSessionRetention, "how long the connections of members are kept in the presence history (0 keeps them forever)")
+
+	flag.StringVar(&configFile, "config", "", "TOML file with settings, using the flag names as keys (default: config.toml in the repo, if it exists)")
+
 	flag.Parse()
 
+	// flags passed on the command line take precedence over the environment, which takes precedence over the config file
+	setFlags, err := applyEnvironment(flag.CommandLine, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	configPath, configRequired = configFile, true
+	if configPath == "" {
+		configPath, configRequired = filepath.Join(repoDir, configFileName), false
+	}
+	configLoaded, err := applyConfigFile(flag.CommandLine, configPath, configRequired, setFlags)
+	if err != nil {
+		return err
+	}
+
 	if logToFile != "" {
 		logDir := filepath.Join(repoDir, logToFile)
 		os.MkdirAll(logDir, 0700) // nearly everything is a log here so..
@@ -136,10 +242,23 @@ func initFlags() {
 	} else {
 		log = kitlog.NewLogfmtLogger(os.Stderr)
 	}
+
+	levelLog, err = newLevelLogger(log, reloadable.logLevel)
+	if err != nil {
+		return err
+	}
+	log = levelLog
+
+	if configLoaded {
+		level.Info(log).Log("event", "loaded config file", "path", configPath)
+	}
+	return nil
 }
 
 func runroomsrv() error {
-	initFlags()
+	if err := initFlags(); err != nil {
+		return err
+	}
 
 	if flagPrintVersion {
 		level.Info(log).Log("version", version, "commit", commit)
@@ -154,7 +273,19 @@ func runroomsrv() error {
 	}
 
 	// validate listen addresses to bail out on invalid flag input before doing anything else
-	_, muxrpcPortStr, err := net.SplitHostPort(listenAddrShsMux)
+	muxrpcListenAddrs := strings.Split(listenAddrShsMux, ",")
+	mainMuxrpcListenAddr := strings.TrimSpace(muxrpcListenAddrs[0])
+
+	var additionalMuxrpcListenAddrs []string
+	for _, la := range muxrpcListenAddrs[1:] {
+		la = strings.TrimSpace(la)
+		if _, err := network.ParseListenAddr(la); err != nil {
+			return fmt.Errorf("invalid additional muxrpc listener: %w", err)
+		}
+		additionalMuxrpcListenAddrs = append(additionalMuxrpcListenAddrs, la)
+	}
+
+	_, muxrpcPortStr, err := net.SplitHostPort(mainMuxrpcListenAddr)
 	if err != nil {
 		return fmt.Errorf("invalid muxrpc listener: %w", err)
 	}
@@ -174,6 +305,21 @@ func runroomsrv() error {
 		return fmt.Errorf("invalid tcp port for muxrpc listener: %w", err)
 	}
 
+	if listenAddrOnion != "" {
+		if _, err := network.ParseListenAddr(listenAddrOnion); err != nil {
+			return fmt.Errorf("invalid onion listener: %w", err)
+		}
+	}
+
+	var onionAddress string
+	if onionHostname != "" {
+		onionHost, err := readOnionHostname(onionHostname)
+		if err != nil {
+			return err
+		}
+		onionAddress = fmt.Sprintf("%s:%d", onionHost, onionPort)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -187,6 +333,14 @@ func runroomsrv() error {
 		roomsrv.WithAppKey(ak),
 		roomsrv.WithRepoPath(repoDir),
 		roomsrv.WithUNIXSocket(!flagDisableUNIXSock),
+		roomsrv.WithFederationPeers(federationPeers...),
+		roomsrv.WithKeepAlive(keepAliveInterval, keepAliveMaxMissed),
+		roomsrv.WithConnLimits(reloadable.connLimits),
+		roomsrv.WithWebsocketOptions(websocketOpts),
+	}
+
+	if proxyProtocol {
+		opts = append(opts, roomsrv.WithProxyProtocol(trustedProxies))
 	}
 
 	if logToFile != "" {
@@ -223,6 +377,38 @@ func runroomsrv() error {
 	checkAndLog(err)
 	opts = append(opts, roomsrv.WithKeyPair(keyPair))
 
+	// serve HTTPS ourselves instead of behind a reverse proxy
+	var tlsManager *tlscert.Manager
+	if tlsOpts.CertFile != "" || tlsOpts.KeyFile != "" || tlsOpts.ACME {
+		_, portHTTPSStr, err := net.SplitHostPort(listenAddrHTTPS)
+		if err != nil {
+			return fmt.Errorf("invalid https listener: %w", err)
+		}
+		portHTTP, err = net.LookupPort("tcp", portHTTPSStr)
+		if err != nil {
+			return fmt.Errorf("invalid tcp port for https listener: %w", err)
+		}
+
+		if tlsOpts.ACME && aliasesAsSubdomains && tlsOpts.DNSHook == "" {
+			return fmt.Errorf("the alias subdomains need a wildcard certificate: set -acme-dns-hook or disable -aliases-as-subdomains")
+		}
+
+		tlsOpts.Domain = httpsDomain
+		tlsOpts.Wildcard = aliasesAsSubdomains
+		tlsOpts.CacheDir = r.GetPath("acme")
+		if acmeCARoots != "" {
+			tlsOpts.HTTPClient, err = acmeHTTPClient(acmeCARoots)
+			if err != nil {
+				return err
+			}
+		}
+
+		tlsManager, err = tlscert.New(kitlog.With(log, "unit", "tlscert"), tlsOpts)
+		if err != nil {
+			return fmt.Errorf("failed to set up TLS: %w", err)
+		}
+	}
+
 	networkInfo := network.ServerEndpointDetails{
 		Development: development,
 
@@ -231,13 +417,17 @@ func runroomsrv() error {
 
 		RoomID: keyPair.Feed,
 
-		ListenAddressMUXRPC: listenAddrShsMux,
+		ListenAddressMUXRPC:             mainMuxrpcListenAddr,
+		AdditionalListenAddressesMUXRPC: additionalMuxrpcListenAddrs,
+		PublicAddresses:                 publicAddresses,
+		ListenAddressOnion:              listenAddrOnion,
+		OnionAddress:                    onionAddress,
 
 		UseSubdomainForAliases: aliasesAsSubdomains,
 	}
 
 	// open the sqlite version of the roomdb
-	db, err := sqlite.Open(r)
+	db, err := sqlite.Open(r, sqlite.WithSessionRetention(presenceRetention))
 	if err != nil {
 		return fmt.Errorf("failed to initiate database: %w", err)
 	}
@@ -248,6 +438,15 @@ func runroomsrv() error {
 		db.Config.SetPrivacyMode(ctx, privacyMode)
 	}
 
+	// keep connected to the peers from the admin settings
+	opts = append(opts, roomsrv.WithOutboundPeers(db.Peers))
+
+	// record when members are connected
+	opts = append(opts, roomsrv.WithPresence(db.Presence))
+
+	// stream the news posts over room.notices
+	opts = append(opts, roomsrv.WithNews(db.News))
+
 	// create the shs+muxrpc server
 	roomsrv, err := mksrv.New(
 		db.Members,
@@ -268,6 +467,14 @@ func runroomsrv() error {
 		return fmt.Errorf("failed to open listener for HTTPdashboard: %w", err)
 	}
 
+	var httpsLis net.Listener
+	if tlsManager != nil {
+		httpsLis, err = net.Listen("tcp", listenAddrHTTPS)
+		if err != nil {
+			return fmt.Errorf("failed to open HTTPS listener for HTTPdashboard: %w", err)
+		}
+	}
+
 	c := make(chan os.Signal)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
@@ -277,6 +484,9 @@ func runroomsrv() error {
 		roomsrv.Shutdown()
 
 		httpLis.Close()
+		if httpsLis != nil {
+			httpsLis.Close()
+		}
 		time.Sleep(2 * time.Second)
 
 		err := roomsrv.Close()
@@ -293,84 +503,76 @@ func runroomsrv() error {
 		networkInfo,
 		roomsrv.StateManager,
 		roomsrv.Network,
+		roomsrv.Peers,
+		roomsrv.ConnLimiter,
 		bridge,
 		handlers.Databases{
 			Aliases:       db.Aliases,
 			AuthFallback:  db.AuthFallback,
 			AuthWithSSB:   db.AuthWithSSB,
+			CodeOfConduct: db.CodeOfConduct,
 			Config:        db.Config,
 			DeniedKeys:    db.DeniedKeys,
 			Invites:       db.Invites,
+			JoinRequests:  db.JoinRequests,
 			Notices:       db.Notices,
 			Members:       db.Members,
+			News:          db.News,
+			Pages:         db.Pages,
+			Peers:         db.Peers,
 			PinnedNotices: db.PinnedNotices,
+			Presence:      db.Presence,
 		},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create HTTPdashboard handler: %w", err)
 	}
 
-	// setup CSP and HTTPS redirects
-	secureMiddleware := secure.New(secure.Options{
-		IsDevelopment: development,
-
-		AllowedHosts: []string{
-			// the normal domain
-			httpsDomain,
-			// the domain but as a wildcard match with *. infront
-			`*\.` + strings.Replace(httpsDomain, ".", `\.`, -1),
-		},
-
-		// for the wildcard matching
-		AllowedHostsAreRegex: true,
-
-		// TLS stuff
-		SSLRedirect: true,
-		SSLHost:     httpsDomain,
-
-		// Important for reverse-proxy setups (when nginx or similar does the TLS termination)
-		SSLProxyHeaders:   map[string]string{"X-Forwarded-Proto": "https"},
-		HostsProxyHeaders: []string{"X-Forwarded-Host"},
-
-		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security
-		STSSeconds: 2592000, // 30 days in seconds (TODO configure?)
-		STSPreload: false,   // don't submit to googles list service (TODO configure?)
-		// TODO configure (could be needed in special setups where the room is a subdomain of a site)
-		STSIncludeSubdomains: false,
-
-		// See for more https://developer.mozilla.org/en-US/docs/Web/HTTP/CSP
-		// helpful: https://report-uri.com/home/generate
-		ContentSecurityPolicy: "default-src 'self'; img-src 'self' data:", // enforce no external content
-
-		BrowserXssFilter: true,
-		FrameDeny:        true,
-		//ContentTypeNosniff: true, // TODO: fix Content-Type headers served from assets
-	})
-
-	// HTTP rate limiter
-	throttleStore, err := memstore.New(65536) // 64k different combinations of limitByPathAndAddr
+	// the rate limiter and the security middleware are replaced when the settings are reloaded
+	webMiddlewareHandler := new(swappableHandler)
+	h, err := webMiddleware(reloadable, webHandler)
 	if err != nil {
-		return fmt.Errorf("failed to init HTTP rate limiter store: %w", err)
+		return err
 	}
-	quota := throttled.RateQuota{
-		MaxRate:  throttled.PerSec(5), // different requests per second per VaryBy
-		MaxBurst: 25,
-	}
-	limiter, err := throttled.NewGCRARateLimiter(throttleStore, quota)
-	if err != nil {
-		return fmt.Errorf("failed to init HTTP rate limiter: %w", err)
+	webMiddlewareHandler.Set(h)
+
+	var httpHandler http.Handler = webMiddlewareHandler
+	if websocketPath != "" {
+		mux := http.NewServeMux()
+		mux.Handle(websocketPath, roomsrv.Network.WebsocketEndpoint())
+		mux.Handle("/", httpHandler)
+		httpHandler = mux
+	} else {
+		httpHandler = roomsrv.Network.WebsockHandler(httpHandler)
 	}
+	// everything above sees the address of the client, not the one of the reverse proxy
+	httpHandler = trustedProxies.ResolveRemoteAddr(httpHandler)
 
-	httpRateLimiter := throttled.HTTPRateLimiter{
-		RateLimiter: limiter,
-		VaryBy:      limitByPathAndAddr{},
-	}
+	// reload the settings that can be changed without a restart on SIGHUP
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			s, err := loadReloadableSettings()
+			if err != nil {
+				level.Error(log).Log("event", "reloading settings failed", "err", err)
+				continue
+			}
+			if err := levelLog.SetLevel(s.logLevel); err != nil {
+				level.Error(log).Log("event", "reloading settings failed", "err", err)
+				continue
+			}
+			h, err := webMiddleware(s, webHandler)
+			if err != nil {
+				level.Error(log).Log("event", "reloading settings failed", "err", err)
+				continue
+			}
+			webMiddlewareHandler.Set(h)
+			roomsrv.ConnLimiter.SetLimits(s.connLimits)
 
-	// wrap dashboard/alias/invite handler in ratlimiter and security middleware
-	var httpHandler http.Handler
-	httpHandler = httpRateLimiter.RateLimit(webHandler)
-	httpHandler = secureMiddleware.Handler(httpHandler)
-	httpHandler = roomsrv.Network.WebsockHandler(httpHandler)
+			level.Info(log).Log("event", "reloaded settings", "applied", strings.Join(reloadableNames(), ","), "msg", "the other settings need a restart")
+		}
+	}()
 
 	// all init was successfull
 	level.Info(log).Log(
@@ -378,13 +580,15 @@ func runroomsrv() error {
 		"ID", roomsrv.Whoami().String(),
 		"shsmuxaddr", listenAddrShsMux,
 		"httpaddr", listenAddrHTTP,
+		"tls", tlsManager != nil,
+		"onionaddr", onionAddress,
 		"version", version, "commit", commit,
 	)
 
 	// start serving http connections
-	go func() {
+	serveHTTP := func(lis net.Listener, handler http.Handler, tlsConfig *tls.Config) {
 		srv := http.Server{
-			Addr: httpLis.Addr().String(),
+			Addr: lis.Addr().String(),
 
 			// Good practice to set timeouts to avoid Slowloris attacks.
 			// Keep in mind that the SSE stuff for "sign-in with ssb" can take a moment, thou
@@ -392,14 +596,35 @@ func runroomsrv() error {
 			WriteTimeout:      time.Minute * 3,
 			IdleTimeout:       time.Minute * 3,
 
-			Handler: httpHandler,
+			Handler:   handler,
+			TLSConfig: tlsConfig,
 		}
 
-		err = srv.Serve(httpLis)
+		var err error
+		if tlsConfig != nil {
+			err = srv.ServeTLS(lis, "", "")
+		} else {
+			err = srv.Serve(lis)
+		}
 		if err != nil {
-			level.Error(log).Log("event", "http serve failed", "err", err)
+			level.Error(log).Log("event", "http serve failed", "addr", srv.Addr, "err", err)
 		}
-	}()
+	}
+
+	if tlsManager != nil {
+		go serveHTTP(httpsLis, httpHandler, tlsManager.TLSConfig())
+		// the plain listener answers the ACME challenges, the secure middleware redirects everything else to HTTPS
+		go serveHTTP(httpLis, tlsManager.HTTPHandler(httpHandler), nil)
+
+		go func() {
+			err := tlsManager.Run(ctx)
+			if err != nil && err != context.Canceled {
+				level.Error(log).Log("event", "tls certificate renewal stopped", "err", err)
+			}
+		}()
+	} else {
+		go serveHTTP(httpLis, httpHandler, nil)
+	}
 
 	// start serving shs+muxrpc connections
 	for {
@@ -426,6 +651,49 @@ func main() {
 	}
 }
 
+// readOnionHostname returns the passed name if it already is an .onion name.
+// Otherwise it is treated as the path to the hostname file tor writes into the HiddenServiceDir.
+func readOnionHostname(nameOrPath string) (string, error) {
+	if strings.HasSuffix(nameOrPath, ".onion") {
+		return nameOrPath, nil
+	}
+
+	data, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read onion hostname file: %w", err)
+	}
+
+	name := strings.TrimSpace(string(data))
+	if !strings.HasSuffix(name, ".onion") {
+		return "", fmt.Errorf("onion hostname file %s does not contain an .onion name", nameOrPath)
+	}
+	return name, nil
+}
+
+// acmeHTTPClient returns a client that trusts the root certificates in the file in addition to the ones of the system.
+// This is needed for test CAs, like pebble.
+func acmeHTTPClient(rootsFile string) (*http.Client, error) {
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+
+	data, err := os.ReadFile(rootsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ACME CA roots: %w", err)
+	}
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in ACME CA roots file %s", rootsFile)
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: roots},
+		},
+	}, nil
+}
+
 type limitByPathAndAddr struct{}
 
 func (limitByPathAndAddr) Key(r *http.Request) string {
@@ -434,8 +702,9 @@ func (limitByPathAndAddr) Key(r *http.Request) string {
 	k.WriteString(r.URL.Path)
 	k.WriteString("\n")
 
-	remoteIP := r.Header.Get("X-Forwarded-For")
-	if remoteIP == "" {
+	// the RemoteAddr was already resolved by TrustedProxies.ResolveRemoteAddr. the port changes with every connection.
+	remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
 		remoteIP = r.RemoteAddr
 	}
 	k.WriteString(remoteIP)
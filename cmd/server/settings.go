@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/throttled/throttled/v2"
+	"github.com/throttled/throttled/v2/store/memstore"
+	"github.com/unrolled/secure"
+	kitlog "go.mindeco.de/log"
+	"go.mindeco.de/log/level"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+)
+
+// reloadableSettings can be changed by editing the config file and sending SIGHUP to the server, without a restart.
+type reloadableSettings struct {
+	logLevel string
+
+	httpRate  int
+	httpBurst int
+
+	connLimits network.ConnLimits
+
+	allowedHosts []string
+
+	hstsMaxAge            time.Duration
+	hstsIncludeSubdomains bool
+	hstsPreload           bool
+}
+
+func (s *reloadableSettings) register(fs *flag.FlagSet) {
+	fs.StringVar(&s.logLevel, "loglevel", "debug", "only log messages of this level and above (values: debug, info, warn, error)")
+
+	fs.IntVar(&s.httpRate, "http-rate", 5, "how many requests per second one address can make to the same path of the web interface")
+	fs.IntVar(&s.httpBurst, "http-burst", 25, "how many requests over the -http-rate are allowed in a short burst")
+
+	fs.IntVar(&s.connLimits.MaxConns, "max-conns", 4096, "how many incoming muxrpc connections can be open at the same time (0 for no limit)")
+	fs.IntVar(&s.connLimits.MaxConnsPerIP, "max-conns-per-ip", 32, "how many incoming muxrpc connections one IP address can have open (0 for no limit). Connections from loopback addresses are not limited per address")
+	fs.IntVar(&s.connLimits.AcceptsPerMinutePerIP, "accept-rate-per-ip", 120, "how many new muxrpc connections one IP address can open per minute (0 for no limit)")
+
+	fs.Func("allowed-hosts", "comma separated list of additional host names the web interface answers to, besides the https-domain and its subdomains", func(val string) error {
+		s.allowedHosts = nil
+		for _, h := range strings.Split(val, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				s.allowedHosts = append(s.allowedHosts, h)
+			}
+		}
+		return nil
+	})
+
+	fs.DurationVar(&s.hstsMaxAge, "hsts-max-age", 30*24*time.Hour, "how long browsers should only use HTTPS for the room (Strict-Transport-Security max-age, 0 disables the header)")
+	fs.BoolVar(&s.hstsIncludeSubdomains, "hsts-include-subdomains", false, "apply the Strict-Transport-Security header to all subdomains. Only enable it if every subdomain of the https-domain uses HTTPS")
+	fs.BoolVar(&s.hstsPreload, "hsts-preload", false, "allow the domain to be submitted to the HSTS preload list of the browsers")
+}
+
+// reloadableNames returns the flag names of the reloadable settings
+func reloadableNames() []string {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	new(reloadableSettings).register(fs)
+
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) { names = append(names, f.Name) })
+	return names
+}
+
+// levelOption returns the filter for the name of a log level
+func levelOption(name string) (level.Option, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return level.AllowDebug(), nil
+	case "info", "":
+		return level.AllowInfo(), nil
+	case "warn", "warning":
+		return level.AllowWarn(), nil
+	case "error":
+		return level.AllowError(), nil
+	}
+	return nil, fmt.Errorf("unknown log level %q", name)
+}
+
+// levelLogger filters the log messages by a level that can be changed at runtime
+type levelLogger struct {
+	next kitlog.Logger
+
+	mu       sync.RWMutex
+	filtered kitlog.Logger
+}
+
+func newLevelLogger(next kitlog.Logger, name string) (*levelLogger, error) {
+	l := &levelLogger{next: next}
+	return l, l.SetLevel(name)
+}
+
+func (l *levelLogger) SetLevel(name string) error {
+	opt, err := levelOption(name)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.filtered = level.NewFilter(l.next, opt)
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *levelLogger) Log(keyvals ...interface{}) error {
+	l.mu.RLock()
+	filtered := l.filtered
+	l.mu.RUnlock()
+	return filtered.Log(keyvals...)
+}
+
+// swappableHandler passes the requests to a handler that can be replaced at runtime.
+// Requests that are already running, like the long-polling of sign-in with ssb or websockets, keep the handler they started with.
+type swappableHandler struct {
+	mu      sync.RWMutex
+	current http.Handler
+}
+
+func (sh *swappableHandler) Set(h http.Handler) {
+	sh.mu.Lock()
+	sh.current = h
+	sh.mu.Unlock()
+}
+
+func (sh *swappableHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	sh.mu.RLock()
+	h := sh.current
+	sh.mu.RUnlock()
+	h.ServeHTTP(w, req)
+}
+
+// webMiddleware wraps the web handler in the rate limiter and the security middleware for the passed settings.
+// The counters of the rate limiter start from zero every time.
+func webMiddleware(s reloadableSettings, webHandler http.Handler) (http.Handler, error) {
+	// HTTP rate limiter
+	throttleStore, err := memstore.New(65536) // 64k different combinations of limitByPathAndAddr
+	if err != nil {
+		return nil, fmt.Errorf("failed to init HTTP rate limiter store: %w", err)
+	}
+	quota := throttled.RateQuota{
+		MaxRate:  throttled.PerSec(s.httpRate), // different requests per second per VaryBy
+		MaxBurst: s.httpBurst,
+	}
+	limiter, err := throttled.NewGCRARateLimiter(throttleStore, quota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init HTTP rate limiter: %w", err)
+	}
+
+	httpRateLimiter := throttled.HTTPRateLimiter{
+		RateLimiter: limiter,
+		VaryBy:      limitByPathAndAddr{},
+	}
+
+	allowedHosts := []string{
+		// the normal domain
+		httpsDomain,
+		// the domain but as a wildcard match with *. infront
+		`*\.` + strings.Replace(httpsDomain, ".", `\.`, -1),
+	}
+	for _, h := range s.allowedHosts {
+		allowedHosts = append(allowedHosts, strings.Replace(h, ".", `\.`, -1))
+	}
+
+	// setup CSP and HTTPS redirects
+	secureMiddleware := secure.New(secure.Options{
+		IsDevelopment: development,
+
+		AllowedHosts: allowedHosts,
+
+		// for the wildcard matching
+		AllowedHostsAreRegex: true,
+
+		// TLS stuff
+		SSLRedirect: true,
+		SSLHost:     httpsDomain,
+
+		// Important for reverse-proxy setups (when nginx or similar does the TLS termination)
+		SSLProxyHeaders:   map[string]string{"X-Forwarded-Proto": "https"},
+		HostsProxyHeaders: []string{"X-Forwarded-Host"},
+
+		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security
+		STSSeconds:           int64(s.hstsMaxAge / time.Second),
+		STSPreload:           s.hstsPreload,
+		STSIncludeSubdomains: s.hstsIncludeSubdomains,
+
+		// See for more https://developer.mozilla.org/en-US/docs/Web/HTTP/CSP
+		// helpful: https://report-uri.com/home/generate
+		ContentSecurityPolicy: "default-src 'self'; img-src 'self' data:", // enforce no external content
+
+		BrowserXssFilter: true,
+		FrameDeny:        true,
+		//ContentTypeNosniff: true, // TODO: fix Content-Type headers served from assets
+	})
+
+	// wrap dashboard/alias/invite handler in ratlimiter and security middleware
+	return secureMiddleware.Handler(httpRateLimiter.RateLimit(webHandler)), nil
+}
@@ -9,7 +9,6 @@ import (
 	"sync"
 
 	refs "github.com/ssbc/go-ssb-refs"
-	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/multierror"
 )
 
 type AttendantsEmitter interface {
@@ -23,8 +22,9 @@ type AttendantsEmitter interface {
 // broadcast instance.
 func NewAttendantsEmitter() (AttendantsEmitter, *AttendantsBroadcast) {
 	bcst := AttendantsBroadcast{
-		mu:    &sync.Mutex{},
-		sinks: make(map[*AttendantsEmitter]struct{}),
+		mu:         &sync.Mutex{},
+		sinks:      make(map[*attendantsSubscriber]struct{}),
+		maxPending: DefaultMaxPending,
 	}
 
 	return (*attendantsSink)(&bcst), &bcst
@@ -32,96 +32,219 @@ func NewAttendantsEmitter() (AttendantsEmitter, *AttendantsBroadcast) {
 
 // AttendantsBroadcast is an interface for registering one or more Sinks to recieve
 // updates.
+//
+// Every registered sink is fed by its own goroutine, so Joined and Left never wait for a subscriber.
+// While a subscriber is busy, its queue keeps only the latest event for each member.
+// If more than maxPending members are waiting in the queue the subscriber is evicted and its sink closed.
 type AttendantsBroadcast struct {
 	mu    *sync.Mutex
-	sinks map[*AttendantsEmitter]struct{}
+	sinks map[*attendantsSubscriber]struct{}
+
+	maxPending int
 }
 
-// Register a Sink for updates to be sent. also returns
+// Register a Sink for updates to be sent. also returns a function to unregister it again.
+// Calling that function waits until the queued updates are delivered and closes the sink.
 func (bcst *AttendantsBroadcast) Register(sink AttendantsEmitter) func() {
+	s := newAttendantsSubscriber(sink)
+
 	bcst.mu.Lock()
-	defer bcst.mu.Unlock()
-	bcst.sinks[&sink] = struct{}{}
+	bcst.sinks[s] = struct{}{}
+	bcst.mu.Unlock()
+
+	// subscribers that return an error are dropped, like before
+	go s.run(func() { bcst.remove(s) })
 
 	return func() {
-		bcst.mu.Lock()
-		defer bcst.mu.Unlock()
-		delete(bcst.sinks, &sink)
-		sink.Close()
+		bcst.remove(s)
+		s.stop(true)
+		<-s.done
+		s.closeSink()
 	}
 }
 
-type attendantsSink AttendantsBroadcast
-
-func (bcst *attendantsSink) Joined(member refs.FeedRef) error {
+func (bcst *AttendantsBroadcast) remove(s *attendantsSubscriber) {
 	bcst.mu.Lock()
-	for s := range bcst.sinks {
-		err := (*s).Joined(member)
-		if err != nil {
-			delete(bcst.sinks, s)
-		}
-	}
+	delete(bcst.sinks, s)
 	bcst.mu.Unlock()
+}
+
+type attendantsSink AttendantsBroadcast
 
+func (bcst *attendantsSink) Joined(member refs.FeedRef) error {
+	bcst.push(attendantsEvent{member: member, joined: true})
 	return nil
 }
 
 func (bcst *attendantsSink) Left(member refs.FeedRef) error {
+	bcst.push(attendantsEvent{member: member, joined: false})
+	return nil
+}
+
+func (bcst *attendantsSink) push(evt attendantsEvent) {
+	key := evt.member.String()
+
 	bcst.mu.Lock()
+	defer bcst.mu.Unlock()
+
 	for s := range bcst.sinks {
-		err := (*s).Left(member)
-		if err != nil {
+		if !s.push(key, evt, bcst.maxPending) {
 			delete(bcst.sinks, s)
+			s.stop(false)
+			go s.closeSink()
 		}
 	}
-	bcst.mu.Unlock()
-
-	return nil
 }
 
 // Close implements the Sink interface.
+// Pending updates are dropped. It closes all the sinks and waits for their goroutines to finish.
 func (bcst *attendantsSink) Close() error {
 	bcst.mu.Lock()
-	defer bcst.mu.Unlock()
-	sinks := make([]AttendantsEmitter, 0, len(bcst.sinks))
+	subs := make([]*attendantsSubscriber, 0, len(bcst.sinks))
+	for s := range bcst.sinks {
+		subs = append(subs, s)
+	}
+	bcst.sinks = make(map[*attendantsSubscriber]struct{})
+	bcst.mu.Unlock()
 
-	for sink := range bcst.sinks {
-		sinks = append(sinks, *sink)
+	errs := make([]error, len(subs))
+	var wg sync.WaitGroup
+	wg.Add(len(subs))
+	for i, s := range subs {
+		go func(i int, s *attendantsSubscriber) {
+			defer wg.Done()
+			s.stop(false)
+			// closing the sink also unblocks a pending write
+			errs[i] = s.closeSink()
+			<-s.done
+		}(i, s)
 	}
+	wg.Wait()
 
-	bcst.mu.Lock()
-	defer bcst.mu.Unlock()
+	return collectErrors(errs)
+}
+
+type attendantsEvent struct {
+	member refs.FeedRef
+	joined bool
+}
+
+// attendantsSubscriber holds the events that weren't delivered to the sink yet
+type attendantsSubscriber struct {
+	sink AttendantsEmitter
+
+	mu      sync.Mutex
+	queue   []attendantsEvent
+	spare   []attendantsEvent // the previous batch, to avoid allocating a new queue every time
+	index   map[string]int    // position of a member in the queue
+	closed  bool
+	dropped bool // the queued events are thrown away
+
+	wake      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
 
-	sinks = make([]AttendantsEmitter, 0, len(bcst.sinks))
+func newAttendantsSubscriber(sink AttendantsEmitter) *attendantsSubscriber {
+	return &attendantsSubscriber{
+		sink:  sink,
+		index: make(map[string]int),
+		wake:  make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+}
+
+// push queues the event or replaces the pending one of the same member.
+// It returns false if the subscriber fell too far behind.
+func (s *attendantsSubscriber) push(key string, evt attendantsEvent, maxPending int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return true
+	}
 
-	for sink := range bcst.sinks {
-		sinks = append(sinks, *sink)
+	if i, has := s.index[key]; has {
+		s.queue[i] = evt
+		return true
 	}
 
-	var (
-		wg sync.WaitGroup
-		me multierror.List
-	)
+	if len(s.queue) >= maxPending {
+		return false
+	}
 
-	// might be fine without the waitgroup and concurrency
+	s.index[key] = len(s.queue)
+	s.queue = append(s.queue, evt)
+	notify(s.wake)
+	return true
+}
 
-	wg.Add(len(sinks))
-	for _, sink_ := range sinks {
-		go func(sink AttendantsEmitter) {
-			defer wg.Done()
+// stop makes run return, after delivering the queued events if drain is true.
+func (s *attendantsSubscriber) stop(drain bool) {
+	s.mu.Lock()
+	s.closed = true
+	if !drain {
+		s.queue = nil
+		s.index = make(map[string]int)
+		s.dropped = true
+	}
+	s.mu.Unlock()
+	notify(s.wake)
+}
+
+func (s *attendantsSubscriber) closeSink() error {
+	var err error
+	s.closeOnce.Do(func() {
+		err = s.sink.Close()
+	})
+	return err
+}
 
-			err := sink.Close()
+func (s *attendantsSubscriber) run(onErr func()) {
+	defer close(s.done)
+
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.mu.Unlock()
+			<-s.wake
+			s.mu.Lock()
+		}
+		if len(s.queue) == 0 { // stopped and nothing left to deliver
+			s.mu.Unlock()
+			return
+		}
+		batch := s.queue
+		s.queue = s.spare[:0]
+		for k := range s.index {
+			delete(s.index, k)
+		}
+		s.mu.Unlock()
+
+		for _, evt := range batch {
+			var err error
+			if evt.joined {
+				err = s.sink.Joined(evt.member)
+			} else {
+				err = s.sink.Left(evt.member)
+			}
 			if err != nil {
-				me.Errs = append(me.Errs, err)
+				onErr()
 				return
 			}
-		}(sink_)
-	}
-	wg.Wait()
 
-	if len(me.Errs) == 0 {
-		return nil
-	}
+			// don't keep writing the rest of the batch to a sink that was evicted or closed
+			s.mu.Lock()
+			dropped := s.dropped
+			s.mu.Unlock()
+			if dropped {
+				return
+			}
+		}
 
-	return me
+		// reuse the backing array for the next batch
+		s.mu.Lock()
+		s.spare = batch[:0]
+		s.mu.Unlock()
+	}
 }
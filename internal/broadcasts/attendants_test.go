@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package broadcasts
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	refs "github.com/ssbc/go-ssb-refs"
+)
+
+// attendantsRecorder keeps the events it got as strings like "joined:1" and can block until unblocked or closed
+type attendantsRecorder struct {
+	mu     sync.Mutex
+	events []string
+
+	block   bool
+	unblock chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newAttendantsRecorder(block bool) *attendantsRecorder {
+	return &attendantsRecorder{
+		block:   block,
+		unblock: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (ar *attendantsRecorder) record(what string, member refs.FeedRef) error {
+	ar.mu.Lock()
+	ar.events = append(ar.events, fmt.Sprintf("%s:%d", what, member.PubKey()[0]))
+	ar.mu.Unlock()
+
+	if !ar.block {
+		return nil
+	}
+
+	select {
+	case <-ar.unblock:
+		return nil
+	case <-ar.closed:
+		return errors.New("closed")
+	}
+}
+
+func (ar *attendantsRecorder) Joined(member refs.FeedRef) error { return ar.record("joined", member) }
+func (ar *attendantsRecorder) Left(member refs.FeedRef) error   { return ar.record("left", member) }
+
+func (ar *attendantsRecorder) Close() error {
+	ar.once.Do(func() { close(ar.closed) })
+	return nil
+}
+
+func (ar *attendantsRecorder) received() []string {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	return append([]string(nil), ar.events...)
+}
+
+type nopAttendants struct{}
+
+func (nopAttendants) Joined(refs.FeedRef) error { return nil }
+func (nopAttendants) Left(refs.FeedRef) error   { return nil }
+func (nopAttendants) Close() error              { return nil }
+
+func testFeed(t testing.TB, b byte) refs.FeedRef {
+	ref, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{b}, 32), refs.RefAlgoFeedSSB1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ref
+}
+
+func TestAttendantsOrder(t *testing.T) {
+	sink, bcast := NewAttendantsEmitter()
+	defer sink.Close()
+
+	rec := newAttendantsRecorder(false)
+	closeSink := bcast.Register(rec)
+
+	sink.Joined(testFeed(t, 1))
+	sink.Joined(testFeed(t, 2))
+	sink.Left(testFeed(t, 1))
+
+	closeSink()
+
+	got := fmt.Sprint(rec.received())
+	// the first and the last one are coalesced if the worker didn't pick up the first one in time
+	if got != "[joined:1 joined:2 left:1]" && got != "[left:1 joined:2]" {
+		t.Errorf("unexpected events: %s", got)
+	}
+
+	select {
+	case <-rec.closed:
+	default:
+		t.Error("sink was not closed")
+	}
+}
+
+func TestAttendantsCoalesce(t *testing.T) {
+	sink, bcast := NewAttendantsEmitter()
+	defer sink.Close()
+
+	rec := newAttendantsRecorder(true)
+	bcast.Register(rec)
+
+	sink.Joined(testFeed(t, 1))
+	waitFor(t, func() bool { return len(rec.received()) == 1 })
+
+	// while the first one is stuck, these collapse to one event per member
+	for i := 0; i < 10; i++ {
+		sink.Joined(testFeed(t, 2))
+		sink.Left(testFeed(t, 2))
+		sink.Joined(testFeed(t, 3))
+	}
+	sink.Left(testFeed(t, 3))
+
+	close(rec.unblock)
+	waitFor(t, func() bool { return len(rec.received()) == 3 })
+
+	time.Sleep(20 * time.Millisecond)
+	if got := fmt.Sprint(rec.received()); got != "[joined:1 left:2 left:3]" {
+		t.Errorf("unexpected events: %s", got)
+	}
+}
+
+func TestAttendantsEvictsSubscriber(t *testing.T) {
+	sink, bcast := NewAttendantsEmitter()
+	defer sink.Close()
+	bcast.maxPending = 3
+
+	stuck := newAttendantsRecorder(true)
+	bcast.Register(stuck)
+
+	other := newAttendantsRecorder(false)
+	closeOther := bcast.Register(other)
+
+	sink.Joined(testFeed(t, 1))
+	waitFor(t, func() bool { return len(stuck.received()) == 1 })
+
+	// three members fill the queue, the fourth one evicts
+	for i := byte(2); i < 6; i++ {
+		sink.Joined(testFeed(t, i))
+		waitFor(t, func() bool { return len(other.received()) == int(i) })
+	}
+
+	select {
+	case <-stuck.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the sink to be closed")
+	}
+
+	// the others are not affected
+	sink.Left(testFeed(t, 1))
+	closeOther()
+	if n := len(other.received()); n != 6 {
+		t.Errorf("expected 6 events, got %d", n)
+	}
+
+	// the evicted one doesn't get anything else
+	if n := len(stuck.received()); n != 1 {
+		t.Errorf("expected 1 event, got %d", n)
+	}
+}
+
+func BenchmarkAttendantsBroadcast(b *testing.B) {
+	members := make([]refs.FeedRef, 64)
+	for i := range members {
+		members[i] = testFeed(b, byte(i))
+	}
+
+	for _, n := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("subscribers-%d", n), func(b *testing.B) {
+			sink, bcast := NewAttendantsEmitter()
+
+			// every tenth subscriber doesn't read anymore
+			for i := 0; i < n; i++ {
+				if i%10 == 0 {
+					bcast.Register(newAttendantsRecorder(true))
+				} else {
+					bcast.Register(nopAttendants{})
+				}
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m := members[i%len(members)]
+				if i%2 == 0 {
+					sink.Joined(m)
+				} else {
+					sink.Left(m)
+				}
+			}
+			b.StopTimer()
+
+			sink.Close()
+		})
+	}
+}
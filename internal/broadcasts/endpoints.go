@@ -7,8 +7,6 @@ package broadcasts
 import (
 	"io"
 	"sync"
-
-	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/multierror"
 )
 
 type EndpointsEmitter interface {
@@ -20,8 +18,9 @@ type EndpointsEmitter interface {
 // broadcast instance.
 func NewEndpointsEmitter() (EndpointsEmitter, *EndpointsBroadcast) {
 	bcst := EndpointsBroadcast{
-		mu:    &sync.Mutex{},
-		sinks: make(map[*EndpointsEmitter]struct{}),
+		mu:         &sync.Mutex{},
+		sinks:      make(map[*endpointsSubscriber]struct{}),
+		maxPending: DefaultMaxPending,
 	}
 
 	return (*endpointsSink)(&bcst), &bcst
@@ -29,79 +28,174 @@ func NewEndpointsEmitter() (EndpointsEmitter, *EndpointsBroadcast) {
 
 // EndpointsBroadcast is an interface for registering one or more Sinks to recieve
 // updates.
+//
+// Every registered sink is fed by its own goroutine, so Update never waits for a subscriber.
+// Only the newest list is kept for a subscriber that is still busy with the previous one.
+// If more than maxPending updates are replaced that way the subscriber is evicted and its sink closed.
 type EndpointsBroadcast struct {
 	mu    *sync.Mutex
-	sinks map[*EndpointsEmitter]struct{}
+	sinks map[*endpointsSubscriber]struct{}
+
+	maxPending int
 }
 
-// Register a Sink for updates to be sent. also returns
+// Register a Sink for updates to be sent. also returns a function to unregister it again.
+// Calling that function waits until the queued update is delivered and closes the sink.
 func (bcst *EndpointsBroadcast) Register(sink EndpointsEmitter) func() {
+	s := newEndpointsSubscriber(sink)
+
 	bcst.mu.Lock()
-	defer bcst.mu.Unlock()
-	bcst.sinks[&sink] = struct{}{}
+	bcst.sinks[s] = struct{}{}
+	bcst.mu.Unlock()
+
+	// subscribers that return an error are dropped, like before
+	go s.run(func() { bcst.remove(s) })
 
 	return func() {
-		bcst.mu.Lock()
-		defer bcst.mu.Unlock()
-		delete(bcst.sinks, &sink)
-		sink.Close()
+		bcst.remove(s)
+		s.stop(true)
+		<-s.done
+		s.closeSink()
 	}
 }
 
+func (bcst *EndpointsBroadcast) remove(s *endpointsSubscriber) {
+	bcst.mu.Lock()
+	delete(bcst.sinks, s)
+	bcst.mu.Unlock()
+}
+
 type endpointsSink EndpointsBroadcast
 
-// Pour implements the Sink interface.
+// Update queues the list for all subscribers and returns without waiting for them.
 func (bcst *endpointsSink) Update(members []string) error {
-
 	bcst.mu.Lock()
+	defer bcst.mu.Unlock()
+
 	for s := range bcst.sinks {
-		err := (*s).Update(members)
-		if err != nil {
+		if !s.push(members, bcst.maxPending) {
 			delete(bcst.sinks, s)
+			s.stop(false)
+			go s.closeSink()
 		}
 	}
-	bcst.mu.Unlock()
 
 	return nil
 }
 
 // Close implements the Sink interface.
+// Pending updates are dropped. It closes all the sinks and waits for their goroutines to finish.
 func (bcst *endpointsSink) Close() error {
-	var sinks []EndpointsEmitter
-
 	bcst.mu.Lock()
-	defer bcst.mu.Unlock()
-
-	sinks = make([]EndpointsEmitter, 0, len(bcst.sinks))
+	subs := make([]*endpointsSubscriber, 0, len(bcst.sinks))
+	for s := range bcst.sinks {
+		subs = append(subs, s)
+	}
+	bcst.sinks = make(map[*endpointsSubscriber]struct{})
+	bcst.mu.Unlock()
 
-	for sink := range bcst.sinks {
-		sinks = append(sinks, *sink)
+	errs := make([]error, len(subs))
+	var wg sync.WaitGroup
+	wg.Add(len(subs))
+	for i, s := range subs {
+		go func(i int, s *endpointsSubscriber) {
+			defer wg.Done()
+			s.stop(false)
+			// closing the sink also unblocks a pending write
+			errs[i] = s.closeSink()
+			<-s.done
+		}(i, s)
 	}
+	wg.Wait()
 
-	var (
-		wg sync.WaitGroup
-		me multierror.List
-	)
+	return collectErrors(errs)
+}
 
-	// might be fine without the waitgroup and concurrency
+// endpointsSubscriber holds the latest update that wasn't delivered to the sink yet
+type endpointsSubscriber struct {
+	sink EndpointsEmitter
 
-	wg.Add(len(sinks))
-	for _, sink_ := range sinks {
-		go func(sink EndpointsEmitter) {
-			defer wg.Done()
+	mu      sync.Mutex
+	latest  []string
+	pending bool
+	skipped int // updates that were replaced before the sink could take them
+	closed  bool
+
+	wake      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
 
-			err := sink.Close()
-			if err != nil {
-				me.Errs = append(me.Errs, err)
-				return
-			}
-		}(sink_)
+func newEndpointsSubscriber(sink EndpointsEmitter) *endpointsSubscriber {
+	return &endpointsSubscriber{
+		sink: sink,
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
 	}
-	wg.Wait()
+}
 
-	if len(me.Errs) == 0 {
-		return nil
+// push replaces the pending list. It returns false if the subscriber fell too far behind.
+func (s *endpointsSubscriber) push(members []string, maxPending int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return true
+	}
+
+	if s.pending {
+		s.skipped++
+		if s.skipped > maxPending {
+			return false
+		}
 	}
 
-	return me
+	s.latest = members
+	s.pending = true
+	notify(s.wake)
+	return true
+}
+
+// stop makes run return, after delivering the pending update if drain is true.
+func (s *endpointsSubscriber) stop(drain bool) {
+	s.mu.Lock()
+	s.closed = true
+	if !drain {
+		s.latest, s.pending = nil, false
+	}
+	s.mu.Unlock()
+	notify(s.wake)
+}
+
+func (s *endpointsSubscriber) closeSink() error {
+	var err error
+	s.closeOnce.Do(func() {
+		err = s.sink.Close()
+	})
+	return err
+}
+
+func (s *endpointsSubscriber) run(onErr func()) {
+	defer close(s.done)
+
+	for {
+		s.mu.Lock()
+		for !s.pending && !s.closed {
+			s.mu.Unlock()
+			<-s.wake
+			s.mu.Lock()
+		}
+		if !s.pending { // stopped and nothing left to deliver
+			s.mu.Unlock()
+			return
+		}
+		members := s.latest
+		s.latest, s.pending, s.skipped = nil, false, 0
+		s.mu.Unlock()
+
+		if err := s.sink.Update(members); err != nil {
+			onErr()
+			return
+		}
+	}
 }
@@ -11,7 +11,9 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 type testPrinter struct {
@@ -78,7 +80,7 @@ func (tp erroringPrinter) Update(m []string) error {
 func (tp erroringPrinter) Close() error { return nil }
 
 func TestBroadcastOneErrs(t *testing.T) {
-	var buf = &bytes.Buffer{}
+	var buf = &lockedBuffer{}
 
 	sink, bcast := NewEndpointsEmitter()
 	defer sink.Close()
@@ -89,15 +91,20 @@ func TestBroadcastOneErrs(t *testing.T) {
 	var p2 erroringPrinter
 	p2.w = buf
 
-	closeSink := bcast.Register(p1)
-	defer closeSink()
-	closeSink = bcast.Register(p2)
-	defer closeSink()
+	closeSink1 := bcast.Register(p1)
+	closeSink2 := bcast.Register(p2)
 
 	sink.Update([]string{"run1"})
 
+	// the updates are delivered in the background, wait for the first one to fail
+	waitFor(t, func() bool { return buf.Contains("failed: 1\n") })
+
 	sink.Update([]string{"run", "2"})
 
+	// unregistering waits for the queued updates
+	closeSink1()
+	closeSink2()
+
 	output := buf.String()
 
 	expectedContains := []string{
@@ -120,6 +127,184 @@ func TestBroadcastOneErrs(t *testing.T) {
 	}
 }
 
+// blockingPrinter blocks in Update until it is closed or unblocked
+type blockingPrinter struct {
+	mu      sync.Mutex
+	updates [][]string
+
+	unblock chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newBlockingPrinter() *blockingPrinter {
+	return &blockingPrinter{
+		unblock: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (bp *blockingPrinter) Update(members []string) error {
+	bp.mu.Lock()
+	bp.updates = append(bp.updates, members)
+	bp.mu.Unlock()
+
+	select {
+	case <-bp.unblock:
+		return nil
+	case <-bp.closed:
+		return errors.New("closed")
+	}
+}
+
+func (bp *blockingPrinter) Close() error {
+	bp.once.Do(func() { close(bp.closed) })
+	return nil
+}
+
+func (bp *blockingPrinter) received() [][]string {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	return append([][]string(nil), bp.updates...)
+}
+
+func TestBroadcastSlowSubscriber(t *testing.T) {
+	sink, bcast := NewEndpointsEmitter()
+	defer sink.Close()
+
+	stuck := newBlockingPrinter()
+	bcast.Register(stuck)
+
+	var buf = &lockedBuffer{}
+	closeSink := bcast.Register(testPrinter{w: buf})
+
+	sink.Update([]string{})
+	waitFor(t, func() bool { return len(stuck.received()) == 1 })
+
+	done := make(chan struct{})
+	go func() {
+		for i := 1; i < 10; i++ {
+			sink.Update(make([]string, i))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("updates blocked on the stuck subscriber")
+	}
+
+	closeSink()
+	if !buf.Contains("test: 9\n") {
+		t.Errorf("expected the last update to be delivered")
+		t.Log(buf.String())
+	}
+
+	// the stuck one only got the first update so far
+	if n := len(stuck.received()); n != 1 {
+		t.Errorf("expected one update, got %d", n)
+	}
+
+	// afterwards only the latest list is delivered
+	close(stuck.unblock)
+	waitFor(t, func() bool { return len(stuck.received()) == 2 })
+	if n := len(stuck.received()[1]); n != 9 {
+		t.Errorf("expected the newest list with 9 entries, got %d", n)
+	}
+}
+
+func TestBroadcastEvictsSubscriber(t *testing.T) {
+	sink, bcast := NewEndpointsEmitter()
+	defer sink.Close()
+	bcast.maxPending = 3
+
+	stuck := newBlockingPrinter()
+	bcast.Register(stuck)
+
+	sink.Update([]string{"x"})
+	waitFor(t, func() bool { return len(stuck.received()) == 1 })
+
+	// one is pending, three replace it and the next one evicts
+	for i := 0; i < 5; i++ {
+		sink.Update([]string{"x"})
+	}
+
+	select {
+	case <-stuck.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the sink to be closed")
+	}
+
+	waitFor(t, func() bool {
+		bcast.mu.Lock()
+		defer bcast.mu.Unlock()
+		return len(bcast.sinks) == 0
+	})
+}
+
+func BenchmarkEndpointsBroadcast(b *testing.B) {
+	for _, n := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("subscribers-%d", n), func(b *testing.B) {
+			sink, bcast := NewEndpointsEmitter()
+
+			// every tenth subscriber doesn't read anymore
+			for i := 0; i < n; i++ {
+				if i%10 == 0 {
+					bcast.Register(newBlockingPrinter())
+				} else {
+					bcast.Register(testPrinter{w: io.Discard})
+				}
+			}
+			bcast.maxPending = b.N + 1 // measure the updates, not the evictions
+
+			members := []string{"a", "b", "c"}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sink.Update(members)
+			}
+			b.StopTimer()
+
+			sink.Close()
+		})
+	}
+}
+
+// lockedBuffer can be written to from the goroutines of multiple subscribers
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (lb *lockedBuffer) Write(p []byte) (int, error) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return lb.buf.Write(p)
+}
+
+func (lb *lockedBuffer) String() string {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return lb.buf.String()
+}
+
+func (lb *lockedBuffer) Contains(s string) bool {
+	return strings.Contains(lb.String(), s)
+}
+
+func waitFor(t testing.TB, cond func() bool) {
+	t.Helper()
+	for i := 0; i < 500; i++ {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("condition not met in time")
+}
+
 /*
 type expectedEOSErr struct{ v interface{} }
 
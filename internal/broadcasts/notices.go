@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package broadcasts
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Notice is a news post of the room, as the room.notices subscribers get it
+type Notice struct {
+	ID       int64 // of the post
+	NoticeID int64
+
+	Title    string
+	Content  string
+	Language string
+
+	Published time.Time
+}
+
+type NoticesEmitter interface {
+	Published(post Notice) error
+
+	io.Closer
+}
+
+// NewNoticesEmitter returns the Sink, to write to the broadcaster, and the new
+// broadcast instance.
+func NewNoticesEmitter() (NoticesEmitter, *NoticesBroadcast) {
+	bcst := NoticesBroadcast{
+		mu:         &sync.Mutex{},
+		sinks:      make(map[*noticesSubscriber]struct{}),
+		maxPending: DefaultMaxPending,
+	}
+
+	return (*noticesSink)(&bcst), &bcst
+}
+
+// NoticesBroadcast is an interface for registering one or more Sinks to recieve
+// newly published news posts.
+//
+// Every registered sink is fed by its own goroutine, so Published never waits for a subscriber.
+// Unlike the attendants, every post is delivered, in the order they were published.
+// If more than maxPending posts are waiting in the queue the subscriber is evicted and its sink closed.
+type NoticesBroadcast struct {
+	mu    *sync.Mutex
+	sinks map[*noticesSubscriber]struct{}
+
+	maxPending int
+}
+
+// Register a Sink for updates to be sent. also returns a function to unregister it again.
+// Calling that function waits until the queued updates are delivered and closes the sink.
+func (bcst *NoticesBroadcast) Register(sink NoticesEmitter) func() {
+	s := newNoticesSubscriber(sink)
+
+	bcst.mu.Lock()
+	bcst.sinks[s] = struct{}{}
+	bcst.mu.Unlock()
+
+	go s.run(func() { bcst.remove(s) })
+
+	return func() {
+		bcst.remove(s)
+		s.stop(true)
+		<-s.done
+		s.closeSink()
+	}
+}
+
+func (bcst *NoticesBroadcast) remove(s *noticesSubscriber) {
+	bcst.mu.Lock()
+	delete(bcst.sinks, s)
+	bcst.mu.Unlock()
+}
+
+type noticesSink NoticesBroadcast
+
+func (bcst *noticesSink) Published(post Notice) error {
+	bcst.mu.Lock()
+	defer bcst.mu.Unlock()
+
+	for s := range bcst.sinks {
+		if !s.push(post, bcst.maxPending) {
+			delete(bcst.sinks, s)
+			s.stop(false)
+			go s.closeSink()
+		}
+	}
+	return nil
+}
+
+// Close implements the Sink interface.
+// Pending updates are dropped. It closes all the sinks and waits for their goroutines to finish.
+func (bcst *noticesSink) Close() error {
+	bcst.mu.Lock()
+	subs := make([]*noticesSubscriber, 0, len(bcst.sinks))
+	for s := range bcst.sinks {
+		subs = append(subs, s)
+	}
+	bcst.sinks = make(map[*noticesSubscriber]struct{})
+	bcst.mu.Unlock()
+
+	errs := make([]error, len(subs))
+	var wg sync.WaitGroup
+	wg.Add(len(subs))
+	for i, s := range subs {
+		go func(i int, s *noticesSubscriber) {
+			defer wg.Done()
+			s.stop(false)
+			// closing the sink also unblocks a pending write
+			errs[i] = s.closeSink()
+			<-s.done
+		}(i, s)
+	}
+	wg.Wait()
+
+	return collectErrors(errs)
+}
+
+// noticesSubscriber holds the posts that weren't delivered to the sink yet
+type noticesSubscriber struct {
+	sink NoticesEmitter
+
+	mu      sync.Mutex
+	queue   []Notice
+	closed  bool
+	dropped bool // the queued posts are thrown away
+
+	wake      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newNoticesSubscriber(sink NoticesEmitter) *noticesSubscriber {
+	return &noticesSubscriber{
+		sink: sink,
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+}
+
+// push queues the post. It returns false if the subscriber fell too far behind.
+func (s *noticesSubscriber) push(post Notice, maxPending int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return true
+	}
+
+	if len(s.queue) >= maxPending {
+		return false
+	}
+
+	s.queue = append(s.queue, post)
+	notify(s.wake)
+	return true
+}
+
+// stop makes run return, after delivering the queued posts if drain is true.
+func (s *noticesSubscriber) stop(drain bool) {
+	s.mu.Lock()
+	s.closed = true
+	if !drain {
+		s.queue = nil
+		s.dropped = true
+	}
+	s.mu.Unlock()
+	notify(s.wake)
+}
+
+func (s *noticesSubscriber) closeSink() error {
+	var err error
+	s.closeOnce.Do(func() {
+		err = s.sink.Close()
+	})
+	return err
+}
+
+func (s *noticesSubscriber) run(onErr func()) {
+	defer close(s.done)
+
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.mu.Unlock()
+			<-s.wake
+			s.mu.Lock()
+		}
+		if len(s.queue) == 0 { // stopped and nothing left to deliver
+			s.mu.Unlock()
+			return
+		}
+		batch := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+
+		for _, post := range batch {
+			if err := s.sink.Published(post); err != nil {
+				onErr()
+				return
+			}
+
+			// don't keep writing the rest of the batch to a sink that was evicted or closed
+			s.mu.Lock()
+			dropped := s.dropped
+			s.mu.Unlock()
+			if dropped {
+				return
+			}
+		}
+	}
+}
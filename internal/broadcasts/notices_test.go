@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package broadcasts
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// noticesRecorder keeps the IDs of the posts it got and can block until unblocked or closed
+type noticesRecorder struct {
+	mu    sync.Mutex
+	posts []int64
+
+	block   bool
+	unblock chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newNoticesRecorder(block bool) *noticesRecorder {
+	return &noticesRecorder{
+		block:   block,
+		unblock: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+}
+
+func (nr *noticesRecorder) Published(post Notice) error {
+	nr.mu.Lock()
+	nr.posts = append(nr.posts, post.ID)
+	nr.mu.Unlock()
+
+	if !nr.block {
+		return nil
+	}
+
+	select {
+	case <-nr.unblock:
+		return nil
+	case <-nr.closed:
+		return errors.New("closed")
+	}
+}
+
+func (nr *noticesRecorder) Close() error {
+	nr.once.Do(func() { close(nr.closed) })
+	return nil
+}
+
+func (nr *noticesRecorder) received() []int64 {
+	nr.mu.Lock()
+	defer nr.mu.Unlock()
+	return append([]int64(nil), nr.posts...)
+}
+
+func TestNoticesOrder(t *testing.T) {
+	sink, bcast := NewNoticesEmitter()
+	defer sink.Close()
+
+	rec := newNoticesRecorder(true)
+	closeSink := bcast.Register(rec)
+
+	sink.Published(Notice{ID: 1})
+	waitFor(t, func() bool { return len(rec.received()) == 1 })
+
+	// while the first one is stuck, the others are queued and none of them is left out
+	for i := int64(2); i <= 5; i++ {
+		sink.Published(Notice{ID: i})
+	}
+
+	close(rec.unblock)
+	closeSink()
+
+	if got := fmt.Sprint(rec.received()); got != "[1 2 3 4 5]" {
+		t.Errorf("unexpected posts: %s", got)
+	}
+
+	select {
+	case <-rec.closed:
+	default:
+		t.Error("sink was not closed")
+	}
+}
+
+func TestNoticesEvictsSubscriber(t *testing.T) {
+	sink, bcast := NewNoticesEmitter()
+	defer sink.Close()
+	bcast.maxPending = 3
+
+	stuck := newNoticesRecorder(true)
+	bcast.Register(stuck)
+
+	other := newNoticesRecorder(false)
+	closeOther := bcast.Register(other)
+
+	sink.Published(Notice{ID: 1})
+	waitFor(t, func() bool { return len(stuck.received()) == 1 })
+
+	// three posts fill the queue, the fourth one evicts
+	for i := int64(2); i < 6; i++ {
+		sink.Published(Notice{ID: i})
+		waitFor(t, func() bool { return len(other.received()) == int(i) })
+	}
+
+	select {
+	case <-stuck.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the sink to be closed")
+	}
+
+	closeOther()
+	if n := len(other.received()); n != 5 {
+		t.Errorf("expected 5 posts, got %d", n)
+	}
+
+	if n := len(stuck.received()); n != 1 {
+		t.Errorf("expected 1 post, got %d", n)
+	}
+}
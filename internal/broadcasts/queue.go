@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package broadcasts
+
+import (
+	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/multierror"
+)
+
+// DefaultMaxPending is how many updates a subscriber can fall behind before it is evicted.
+const DefaultMaxPending = 256
+
+// notify wakes up the goroutine of a subscriber without blocking if it is already awake
+func notify(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+func collectErrors(errs []error) error {
+	var me multierror.List
+	for _, err := range errs {
+		if err != nil {
+			me.Errs = append(me.Errs, err)
+		}
+	}
+
+	if len(me.Errs) == 0 {
+		return nil
+	}
+
+	return me
+}
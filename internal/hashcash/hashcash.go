@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+// Package hashcash implements a proof-of-work in the style of hashcash.
+//
+// The room hands out a challenge and the client has to find a solution,
+// so that the SHA-256 hash of "challenge:solution" starts with as many zero bits as the challenge asks for.
+// Checking a solution takes one hash while finding one takes 2^difficulty hashes on average.
+//
+// Challenges are signed by the room, so that it doesn't need to remember the ones it handed out,
+// only the ones that were already redeemed until they expire.
+package hashcash
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ValidFor is how long a client has to solve a challenge
+const ValidFor = 10 * time.Minute
+
+var (
+	ErrInvalidChallenge = errors.New("hashcash: invalid challenge")
+	ErrExpired          = errors.New("hashcash: challenge expired")
+	ErrAlreadyRedeemed  = errors.New("hashcash: challenge was already redeemed")
+	ErrWrongSolution    = errors.New("hashcash: solution doesn't have enough leading zero bits")
+)
+
+// Challenge is what a client needs to find a solution
+type Challenge struct {
+	Challenge  string
+	Difficulty uint
+	Expires    time.Time
+}
+
+// Issuer hands out challenges and redeems their solutions, each only once.
+type Issuer struct {
+	key []byte
+
+	// now is replaced in the tests
+	now func() time.Time
+
+	mu       sync.Mutex
+	redeemed map[string]time.Time
+}
+
+// NewIssuer creates an issuer with a random key. Challenges of other issuers, or from before a restart, are invalid.
+func NewIssuer() *Issuer {
+	key := make([]byte, 32)
+	rand.Read(key)
+
+	return &Issuer{
+		key: key,
+		now: time.Now,
+
+		redeemed: make(map[string]time.Time),
+	}
+}
+
+// Issue creates a new challenge with the passed difficulty
+func (i *Issuer) Issue(difficulty uint) Challenge {
+	expires := i.now().Add(ValidFor)
+
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+
+	payload := fmt.Sprintf("%d:%d:%s", difficulty, expires.Unix(), base64.RawURLEncoding.EncodeToString(nonce))
+
+	return Challenge{
+		Challenge:  payload + ":" + i.sign(payload),
+		Difficulty: difficulty,
+		Expires:    time.Unix(expires.Unix(), 0),
+	}
+}
+
+// Redeem checks that the challenge was issued by i and didn't expire, and that the solution solves it.
+// A challenge can only be redeemed once.
+func (i *Issuer) Redeem(challenge, solution string) error {
+	parts := strings.Split(challenge, ":")
+	if len(parts) != 4 {
+		return ErrInvalidChallenge
+	}
+
+	payload := strings.Join(parts[:3], ":")
+	if !hmac.Equal([]byte(i.sign(payload)), []byte(parts[3])) {
+		return ErrInvalidChallenge
+	}
+
+	difficulty, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil {
+		return ErrInvalidChallenge
+	}
+
+	expiresUnix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return ErrInvalidChallenge
+	}
+	expires := time.Unix(expiresUnix, 0)
+
+	now := i.now()
+	if now.After(expires) {
+		return ErrExpired
+	}
+
+	if !Check(challenge, solution, uint(difficulty)) {
+		return ErrWrongSolution
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	// expired challenges are rejected above, no need to remember them any longer
+	for c, exp := range i.redeemed {
+		if now.After(exp) {
+			delete(i.redeemed, c)
+		}
+	}
+
+	if _, used := i.redeemed[challenge]; used {
+		return ErrAlreadyRedeemed
+	}
+	i.redeemed[challenge] = expires
+
+	return nil
+}
+
+func (i *Issuer) sign(payload string) string {
+	mac := hmac.New(sha256.New, i.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Check returns true if the SHA-256 hash of "challenge:solution" starts with at least difficulty zero bits
+func Check(challenge, solution string, difficulty uint) bool {
+	sum := sha256.Sum256([]byte(challenge + ":" + solution))
+	return leadingZeros(sum[:]) >= difficulty
+}
+
+// Solve counts up from zero until it finds a solution.
+// The room never calls it, it's for Go clients and the tests.
+func Solve(challenge string, difficulty uint) string {
+	for n := uint64(0); ; n++ {
+		solution := strconv.FormatUint(n, 10)
+		if Check(challenge, solution, difficulty) {
+			return solution
+		}
+	}
+}
+
+func leadingZeros(b []byte) uint {
+	var n uint
+	for _, x := range b {
+		if x != 0 {
+			return n + uint(bits.LeadingZeros8(x))
+		}
+		n += 8
+	}
+	return n
+}
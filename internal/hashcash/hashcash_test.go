@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package hashcash
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeadingZeros(t *testing.T) {
+	r := require.New(t)
+
+	r.EqualValues(0, leadingZeros([]byte{0xff, 0x00}))
+	r.EqualValues(7, leadingZeros([]byte{0x01, 0xff}))
+	r.EqualValues(8, leadingZeros([]byte{0x00, 0x80}))
+	r.EqualValues(13, leadingZeros([]byte{0x00, 0x04}))
+	r.EqualValues(16, leadingZeros([]byte{0x00, 0x00}))
+}
+
+func TestRedeem(t *testing.T) {
+	r := require.New(t)
+
+	now := time.Now()
+	i := NewIssuer()
+	i.now = func() time.Time { return now }
+
+	c := i.Issue(12)
+	r.EqualValues(12, c.Difficulty)
+	r.True(c.Expires.After(now))
+
+	solution := Solve(c.Challenge, c.Difficulty)
+	r.True(Check(c.Challenge, solution, 12))
+
+	// a solution to an easier challenge isn't enough
+	var tooEasy string
+	for n := 0; ; n++ {
+		s := strings.Repeat("x", n)
+		if Check(c.Challenge, s, 1) && !Check(c.Challenge, s, 12) {
+			tooEasy = s
+			break
+		}
+	}
+	r.ErrorIs(i.Redeem(c.Challenge, tooEasy), ErrWrongSolution)
+
+	r.NoError(i.Redeem(c.Challenge, solution))
+	r.ErrorIs(i.Redeem(c.Challenge, solution), ErrAlreadyRedeemed, "can only be used once")
+
+	// the difficulty can't be lowered by the client
+	easier := strings.Replace(c.Challenge, "12:", "1:", 1)
+	r.ErrorIs(i.Redeem(easier, Solve(easier, 1)), ErrInvalidChallenge)
+
+	// nor can other issuers hand out challenges
+	other := NewIssuer().Issue(1)
+	r.ErrorIs(i.Redeem(other.Challenge, Solve(other.Challenge, 1)), ErrInvalidChallenge)
+
+	r.ErrorIs(i.Redeem("not:a:challenge", "0"), ErrInvalidChallenge)
+
+	// too late
+	late := i.Issue(1)
+	lateSolution := Solve(late.Challenge, 1)
+	now = now.Add(ValidFor + time.Minute)
+	r.ErrorIs(i.Redeem(late.Challenge, lateSolution), ErrExpired)
+
+	// expired challenges are forgotten
+	r.NoError(i.Redeem(i.Issue(0).Challenge, "anything"), "difficulty 0 takes every solution")
+	i.mu.Lock()
+	r.Len(i.redeemed, 1)
+	i.mu.Unlock()
+}
@@ -13,6 +13,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ssbc/go-muxrpc/v2"
@@ -28,6 +30,22 @@ type ServerEndpointDetails struct {
 
 	ListenAddressMUXRPC string // defaults to ":8008"
 
+	// AdditionalListenAddressesMUXRPC are opened next to ListenAddressMUXRPC, see ParseListenAddr for the format.
+	// Those with a public IP or a different port than the main one are advertised as well.
+	AdditionalListenAddressesMUXRPC []string
+
+	// PublicAddresses are additional multiserver transport addresses the room is reachable under.
+	// For instance "net:[2001:db8::1]:8008", "onion:abc...xyz.onion:8008" or "wss://room.example:443".
+	// The "~shs:" part is added by MultiserverAddresses() and must be omitted.
+	PublicAddresses []string
+
+	// ListenAddressOnion is the (optional) local address tor forwards the onion service to.
+	// Either host:port or unix:/path/to/socket, see ParseListenAddr.
+	ListenAddressOnion string
+
+	// OnionAddress is the onion service as host.onion:port, advertised as an onion: multiserver address.
+	OnionAddress string
+
 	// Domain sets the DNS name for all the HTTP(S) URLs.
 	Domain    string
 	PortHTTPS uint // 0 assumes default (443)
@@ -62,15 +80,151 @@ func (sed ServerEndpointDetails) URLForAlias(a string) string {
 	return u.String()
 }
 
-// MultiserverAddress returns net:domain:muxport~shs:roomPubKeyInBase64
+// URLForNotice returns the web page of a notice, for instance one of the news posts
+func (sed ServerEndpointDetails) URLForNotice(id int64) string {
+	var u url.URL
+	u.Path = "/notice/show"
+	u.RawQuery = url.Values{"id": []string{strconv.FormatInt(id, 10)}}.Encode()
+
+	if sed.Development {
+		u.Scheme = "http"
+		u.Host = fmt.Sprintf("localhost:%d", sed.PortHTTPS)
+		return u.String()
+	}
+
+	u.Scheme = "https"
+	u.Host = sed.Domain
+	return u.String()
+}
+
+// MultiserverAddress returns all the addresses of MultiserverAddresses() joined by ';'.
+// The first one is always net:domain:muxport~shs:roomPubKeyInBase64
 // ie: the room servers https://github.com/ssbc/multiserver-address
 func (sed ServerEndpointDetails) MultiserverAddress() string {
+	return strings.Join(sed.MultiserverAddresses(), ";")
+}
+
+// MultiserverAddresses returns net:domain:muxport~shs:roomPubKeyInBase64,
+// followed by the public additional listeners, the PublicAddresses and the OnionAddress with the same shs part appended.
+func (sed ServerEndpointDetails) MultiserverAddresses() []string {
 	addr, err := net.ResolveTCPAddr("tcp", sed.ListenAddressMUXRPC)
 	if err != nil {
 		panic(err)
 	}
-	var roomPubKey = base64.StdEncoding.EncodeToString(sed.RoomID.PubKey())
-	return fmt.Sprintf("net:%s:%d~shs:%s", sed.Domain, addr.Port, roomPubKey)
+	var shsPart = "~shs:" + base64.StdEncoding.EncodeToString(sed.RoomID.PubKey())
+
+	transports := []string{fmt.Sprintf("net:%s:%d", sed.Domain, addr.Port)}
+	for _, listenAddr := range sed.AdditionalListenAddressesMUXRPC {
+		if transport, ok := sed.advertisedTransport(listenAddr); ok {
+			transports = append(transports, transport)
+		}
+	}
+	transports = append(transports, sed.PublicAddresses...)
+	if sed.OnionAddress != "" {
+		transports = append(transports, "onion:"+sed.OnionAddress)
+	}
+
+	var (
+		addrs = make([]string, 0, len(transports))
+		seen  = make(map[string]struct{}, len(transports))
+	)
+	for _, transport := range transports {
+		if _, has := seen[transport]; has {
+			continue
+		}
+		seen[transport] = struct{}{}
+		addrs = append(addrs, transport+shsPart)
+	}
+	return addrs
+}
+
+// advertisedTransport returns the net: transport under which the listener can be reached from the outside.
+// Unix sockets, loopback and private addresses are not advertised. Unspecified hosts (like :8008 or [::]:8008) use the domain.
+func (sed ServerEndpointDetails) advertisedTransport(listenAddr string) (string, bool) {
+	addr, err := ParseListenAddr(listenAddr)
+	if err != nil {
+		return "", false
+	}
+
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return "", false
+	}
+
+	ip := tcpAddr.IP
+	switch {
+	case ip == nil || ip.IsUnspecified():
+		return "net:" + net.JoinHostPort(sed.Domain, strconv.Itoa(tcpAddr.Port)), true
+	case ip.IsLoopback() || isPrivateIP(ip) || ip.IsLinkLocalUnicast():
+		return "", false
+	default:
+		return "net:" + net.JoinHostPort(ip.String(), strconv.Itoa(tcpAddr.Port)), true
+	}
+}
+
+// isPrivateIP reports whether ip is in one of the private ranges of RFC 1918 and RFC 4193,
+// like net.IP.IsPrivate which needs Go 1.17
+func isPrivateIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4[0] == 10 ||
+			(ip4[0] == 172 && ip4[1]&0xf0 == 16) ||
+			(ip4[0] == 192 && ip4[1] == 168)
+	}
+	return len(ip) == net.IPv6len && ip[0]&0xfe == 0xfc
+}
+
+// ValidateTransportAddress checks that a is a multiserver transport address the room can advertise.
+// It needs to be a single address (no ';') without the shs part (no '~') and use one of the known protocols.
+func ValidateTransportAddress(a string) error {
+	if strings.ContainsAny(a, ";~") {
+		return fmt.Errorf("multiserver transport %q: needs to be a single address without the shs part", a)
+	}
+
+	colon := strings.Index(a, ":")
+	if colon < 1 {
+		return fmt.Errorf("multiserver transport %q: missing protocol prefix", a)
+	}
+	proto, rest := a[:colon], a[colon+1:]
+
+	switch proto {
+	case "net", "onion", "ws":
+		hostPort := rest
+		if proto == "ws" {
+			// multiserver websocket addresses are URLs, like ws://host:port
+			if !strings.HasPrefix(rest, "//") {
+				return fmt.Errorf("multiserver transport %q: expected ws://host:port", a)
+			}
+			hostPort = strings.TrimPrefix(rest, "//")
+		}
+		// IPv6 hosts need brackets, like net:[2001:db8::1]:8008
+		host, port, err := net.SplitHostPort(hostPort)
+		if err != nil {
+			return fmt.Errorf("multiserver transport %q: expected host:port: %w", a, err)
+		}
+		if host == "" {
+			return fmt.Errorf("multiserver transport %q: empty host", a)
+		}
+		if _, err := net.LookupPort("tcp", port); err != nil {
+			return fmt.Errorf("multiserver transport %q: invalid port: %w", a, err)
+		}
+		if proto == "onion" && !strings.HasSuffix(host, ".onion") {
+			return fmt.Errorf("multiserver transport %q: not an .onion host", a)
+		}
+		return nil
+
+	case "wss":
+		u, err := url.Parse(a)
+		if err != nil {
+			return fmt.Errorf("multiserver transport %q: invalid url: %w", a, err)
+		}
+		if u.Hostname() == "" {
+			return fmt.Errorf("multiserver transport %q: empty host", a)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("multiserver transport %q: unsupported protocol %q", a, proto)
+	}
 }
 
 // EndpointStat gives some information about a connected peer
@@ -93,7 +247,11 @@ type Endpoints interface {
 type Network interface {
 	Connect(ctx context.Context, addr net.Addr) error
 	Serve(context.Context, ...muxrpc.HandlerWrapper) error
+
+	// GetListenAddr returns the address of the primary listener
 	GetListenAddr() net.Addr
+	// GetListenAddrs returns the addresses of all the listeners
+	GetListenAddrs() []net.Addr
 
 	GetAllEndpoints() []EndpointStat
 	Endpoints
@@ -102,11 +260,13 @@ type Network interface {
 
 	// WebsockHandler returns a "middleware" like thing that is able to upgrade a
 	// websocket request to a muxrpc connection and authenticate using shs.
-	// It calls the next handler if it fails to upgrade the connection to websocket.
-	// However, it will error on the request and not call the passed handler
-	// if the websocket upgrade is successfull.
+	// Requests that don't ask for a websocket upgrade are passed to the next handler.
+	// The remote address is taken from the request as it is, see TrustedProxies.ResolveRemoteAddr.
 	WebsockHandler(next http.Handler) http.Handler
 
+	// WebsocketEndpoint is like WebsockHandler but without a next handler, to mount it on its own path.
+	WebsocketEndpoint() http.Handler
+
 	io.Closer
 }
 
@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ssbc/go-netwrap"
+	"go.mindeco.de/log"
+	"go.mindeco.de/log/level"
+)
+
+// Errors returned by ConnLimiter.Acquire
+var (
+	ErrTooManyConns      = errors.New("network: too many open connections")
+	ErrTooManyConnsForIP = errors.New("network: too many open connections from this address")
+	ErrAcceptRate        = errors.New("network: too many new connections from this address")
+)
+
+// ConnLimits configure a ConnLimiter. Zero disables a limit.
+type ConnLimits struct {
+	// MaxConns is the number of connections that can be open at the same time
+	MaxConns int
+
+	// MaxConnsPerIP is the number of connections one IP address can have open at the same time
+	MaxConnsPerIP int
+
+	// AcceptsPerMinutePerIP is how many new connections are accepted from one IP address per minute.
+	// Short bursts of up to this many connections are allowed.
+	AcceptsPerMinutePerIP int
+}
+
+// ConnLimiterStats are the counters of a ConnLimiter
+type ConnLimiterStats struct {
+	Open int
+
+	// the rejected connections, by the limit they hit
+	RejectedTotal uint64
+	RejectedPerIP uint64
+	RejectedRate  uint64
+}
+
+// Rejected returns the sum of all rejected connections
+func (s ConnLimiterStats) Rejected() uint64 {
+	return s.RejectedTotal + s.RejectedPerIP + s.RejectedRate
+}
+
+// rejectedWarnInterval limits how often the rejections are logged as a warning, to not flood the log during a flood
+const rejectedWarnInterval = time.Minute
+
+// ConnLimiter caps the number of incoming connections, in total and per IP address.
+// It is applied before the secret-handshake, so that floods of handshakes are turned away early.
+//
+// Loopback addresses are only counted against MaxConns, since they are usually a local reverse proxy or the tor daemon.
+type ConnLimiter struct {
+	logger log.Logger
+	limits ConnLimits
+
+	mu    sync.Mutex
+	open  int
+	perIP map[string]*ipLimitState
+	stats ConnLimiterStats
+
+	lastWarn       time.Time
+	rejectedAtWarn uint64
+}
+
+type ipLimitState struct {
+	open int
+
+	// token bucket for AcceptsPerMinutePerIP
+	tokens float64
+	last   time.Time
+}
+
+// NewConnLimiter returns a limiter for the passed limits. A zero ConnLimits only counts the connections.
+func NewConnLimiter(logger log.Logger, limits ConnLimits) *ConnLimiter {
+	return &ConnLimiter{
+		logger: logger,
+		limits: limits,
+		perIP:  make(map[string]*ipLimitState),
+	}
+}
+
+// ConnWrapper returns a wrapper that rejects connections over the limits and releases them once they are closed.
+// It needs to be applied before the secret-handshake.
+func (cl *ConnLimiter) ConnWrapper() netwrap.ConnWrapper {
+	return func(c net.Conn) (net.Conn, error) {
+		release, err := cl.Acquire(c.RemoteAddr())
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		return &limitedConn{Conn: c, release: release}, nil
+	}
+}
+
+// CloseOnError closes the connection if the passed wrapper fails.
+// The wrappers after the ConnLimiter, like the secret-handshake, need it so that failed handshakes give their slot back.
+func CloseOnError(next netwrap.ConnWrapper) netwrap.ConnWrapper {
+	return func(c net.Conn) (net.Conn, error) {
+		wrapped, err := next(c)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		return wrapped, nil
+	}
+}
+
+// Acquire counts a new connection from addr, if it is within the limits.
+// The returned function needs to be called once the connection is closed. It can be called more than once.
+func (cl *ConnLimiter) Acquire(addr net.Addr) (func(), error) {
+	ip := limitedIP(addr)
+	now := time.Now()
+
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	var st *ipLimitState
+	if ip != "" {
+		st = cl.perIP[ip]
+		if st == nil {
+			cl.pruneLocked(now)
+			st = &ipLimitState{tokens: float64(cl.limits.AcceptsPerMinutePerIP), last: now}
+			cl.perIP[ip] = st
+		}
+
+		// every attempt uses up a token, also the ones that are rejected by the other limits
+		if rate := cl.limits.AcceptsPerMinutePerIP; rate > 0 {
+			st.refill(now, rate)
+			if st.tokens < 1 {
+				cl.stats.RejectedRate++
+				cl.rejectedLocked(now, addr, ErrAcceptRate)
+				return nil, ErrAcceptRate
+			}
+			st.tokens--
+		}
+
+		if max := cl.limits.MaxConnsPerIP; max > 0 && st.open >= max {
+			cl.stats.RejectedPerIP++
+			cl.rejectedLocked(now, addr, ErrTooManyConnsForIP)
+			return nil, ErrTooManyConnsForIP
+		}
+	}
+
+	if max := cl.limits.MaxConns; max > 0 && cl.open >= max {
+		cl.stats.RejectedTotal++
+		cl.rejectedLocked(now, addr, ErrTooManyConns)
+		return nil, ErrTooManyConns
+	}
+
+	cl.open++
+	if st != nil {
+		st.open++
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cl.mu.Lock()
+			cl.open--
+			if st != nil {
+				st.open--
+			}
+			cl.mu.Unlock()
+		})
+	}, nil
+}
+
+// SetLimits changes the limits. Connections that are already open are not closed, even if they are over the new limits.
+func (cl *ConnLimiter) SetLimits(limits ConnLimits) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	cl.limits = limits
+}
+
+// Stats returns the number of open and rejected connections
+func (cl *ConnLimiter) Stats() ConnLimiterStats {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	s := cl.stats
+	s.Open = cl.open
+	return s
+}
+
+// rejectedLocked logs a rejected connection. Warnings are only logged once per rejectedWarnInterval, with the number of rejections since the last one.
+func (cl *ConnLimiter) rejectedLocked(now time.Time, addr net.Addr, reason error) {
+	level.Debug(cl.logger).Log("event", "connection rejected", "remote", addr.String(), "reason", reason)
+
+	if now.Sub(cl.lastWarn) < rejectedWarnInterval {
+		return
+	}
+	rejected := cl.stats.Rejected()
+	level.Warn(cl.logger).Log("event", "rejecting connections", "reason", reason, "rejected", rejected-cl.rejectedAtWarn, "open", cl.open)
+	cl.lastWarn = now
+	cl.rejectedAtWarn = rejected
+}
+
+// pruneLocked drops the addresses without open connections and a full token bucket, once there are a lot of them
+func (cl *ConnLimiter) pruneLocked(now time.Time) {
+	if len(cl.perIP) < 1024 {
+		return
+	}
+	for ip, st := range cl.perIP {
+		if st.open > 0 {
+			continue
+		}
+		if rate := cl.limits.AcceptsPerMinutePerIP; rate > 0 {
+			st.refill(now, rate)
+			if st.tokens < float64(rate) {
+				continue
+			}
+		}
+		delete(cl.perIP, ip)
+	}
+}
+
+func (st *ipLimitState) refill(now time.Time, perMinute int) {
+	st.tokens += now.Sub(st.last).Minutes() * float64(perMinute)
+	if max := float64(perMinute); st.tokens > max {
+		st.tokens = max
+	}
+	st.last = now
+}
+
+// limitedIP returns the address that the per-IP limits apply to, or an empty string if they don't apply
+func limitedIP(addr net.Addr) string {
+	ip := addrIP(addr)
+	if ip == nil || ip.IsLoopback() {
+		return ""
+	}
+	return ip.String()
+}
+
+// limitedConn gives the connection back to the limiter once it is closed
+type limitedConn struct {
+	net.Conn
+	release func()
+}
+
+func (c *limitedConn) Close() error {
+	c.release()
+	return c.Conn.Close()
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mindeco.de/log"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+)
+
+func TestConnLimiter(t *testing.T) {
+	r := require.New(t)
+
+	cl := network.NewConnLimiter(log.NewNopLogger(), network.ConnLimits{
+		MaxConns:              4,
+		MaxConnsPerIP:         2,
+		AcceptsPerMinutePerIP: 3,
+	})
+
+	alice := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1000}
+	bob := &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 1000}
+	local := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1000}
+
+	releaseA1, err := cl.Acquire(alice)
+	r.NoError(err)
+	releaseA2, err := cl.Acquire(alice)
+	r.NoError(err)
+
+	_, err = cl.Acquire(alice)
+	r.ErrorIs(err, network.ErrTooManyConnsForIP)
+
+	// one closed, but all the tokens are used up
+	releaseA1()
+	releaseA1() // releasing twice doesn't count twice
+	_, err = cl.Acquire(alice)
+	r.ErrorIs(err, network.ErrAcceptRate)
+
+	// other addresses are not affected
+	_, err = cl.Acquire(bob)
+	r.NoError(err)
+
+	// loopback is only counted against the total
+	_, err = cl.Acquire(local)
+	r.NoError(err)
+	_, err = cl.Acquire(local)
+	r.NoError(err)
+	_, err = cl.Acquire(local)
+	r.ErrorIs(err, network.ErrTooManyConns)
+
+	releaseA2()
+
+	s := cl.Stats()
+	r.Equal(3, s.Open)
+	r.EqualValues(1, s.RejectedTotal)
+	r.EqualValues(1, s.RejectedPerIP)
+	r.EqualValues(1, s.RejectedRate)
+	r.EqualValues(3, s.Rejected())
+}
+
+func TestConnLimiterWrapper(t *testing.T) {
+	r := require.New(t)
+
+	cl := network.NewConnLimiter(log.NewNopLogger(), network.ConnLimits{MaxConns: 1})
+	wrap := cl.ConnWrapper()
+
+	c1, _ := net.Pipe()
+	wrapped, err := wrap(c1)
+	r.NoError(err)
+	r.Equal(1, cl.Stats().Open)
+
+	c2, other := net.Pipe()
+	_, err = wrap(c2)
+	r.ErrorIs(err, network.ErrTooManyConns)
+
+	// the rejected connection was closed
+	_, err = other.Write([]byte("hello"))
+	r.Error(err)
+
+	r.NoError(wrapped.Close())
+	r.Equal(0, cl.Stats().Open)
+}
+
+func TestConnLimiterFailedHandshake(t *testing.T) {
+	r := require.New(t)
+
+	cl := network.NewConnLimiter(log.NewNopLogger(), network.ConnLimits{MaxConns: 1})
+	addr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1000}
+
+	handshake := network.CloseOnError(func(c net.Conn) (net.Conn, error) {
+		return nil, errors.New("secret-handshake failed")
+	})
+
+	c, other := net.Pipe()
+	limited, err := cl.ConnWrapper()(c)
+	r.NoError(err)
+	r.Equal(1, cl.Stats().Open)
+
+	_, err = handshake(limited)
+	r.Error(err)
+
+	// the connection was closed and its slot is free again
+	_, err = other.Write([]byte("hello"))
+	r.Error(err)
+	r.Equal(0, cl.Stats().Open)
+
+	release, err := cl.Acquire(addr)
+	r.NoError(err)
+	release()
+}
+
+func TestConnLimiterSetLimits(t *testing.T) {
+	r := require.New(t)
+
+	cl := network.NewConnLimiter(log.NewNopLogger(), network.ConnLimits{MaxConns: 1})
+
+	_, err := cl.Acquire(&net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 1000})
+	r.NoError(err)
+	_, err = cl.Acquire(&net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 1000})
+	r.ErrorIs(err, network.ErrTooManyConns)
+
+	cl.SetLimits(network.ConnLimits{MaxConns: 2})
+	_, err = cl.Acquire(&net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 1000})
+	r.NoError(err)
+	r.Equal(2, cl.Stats().Open)
+}
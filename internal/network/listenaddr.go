@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ParseListenAddr turns a listen address, as passed on the command line, into a net.Addr.
+// Addresses prefixed with "unix:" are paths to unix socket files, everything else is resolved as a TCP host:port pair.
+func ParseListenAddr(s string) (net.Addr, error) {
+	if path := strings.TrimPrefix(s, "unix:"); path != s {
+		if path == "" {
+			return nil, fmt.Errorf("listen address %q: empty socket path", s)
+		}
+		return &net.UnixAddr{Net: "unix", Name: path}, nil
+	}
+
+	addr, err := net.ResolveTCPAddr("tcp", s)
+	if err != nil {
+		return nil, fmt.Errorf("listen address %q: %w", s, err)
+	}
+	return addr, nil
+}
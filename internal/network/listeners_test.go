@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network_test
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ssbc/go-muxrpc/v2"
+	"github.com/ssbc/go-netwrap"
+	"github.com/ssbc/go-secretstream"
+	"github.com/stretchr/testify/require"
+	"go.mindeco.de/log"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/keys"
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+)
+
+func TestMultipleListeners(t *testing.T) {
+	r := require.New(t)
+
+	if l, err := net.Listen("tcp6", "[::1]:0"); err != nil {
+		t.Skip("no IPv6 loopback available:", err)
+	} else {
+		l.Close()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var appkey = make([]byte, 32)
+	rand.Read(appkey)
+
+	logger := log.NewLogfmtLogger(os.Stderr)
+
+	kpServ, err := keys.NewKeyPair(nil)
+	r.NoError(err)
+
+	// count the connections that went through the listener specific wrappers
+	var v6Conns, unixConns int32
+	countingWrapper := func(cnt *int32) netwrap.ConnWrapper {
+		return func(c net.Conn) (net.Conn, error) {
+			atomic.AddInt32(cnt, 1)
+			return c, nil
+		}
+	}
+
+	sockPath := filepath.Join(t.TempDir(), "proxy.sock")
+
+	connected := make(chan muxrpc.Endpoint, 3)
+	server, err := network.New(network.Options{
+		Logger:  logger,
+		AppKey:  appkey,
+		KeyPair: kpServ,
+
+		ListenAddr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0},
+		AdditionalListeners: []network.ListenerOptions{
+			{
+				Addr:                &net.TCPAddr{IP: net.IPv6loopback, Port: 0},
+				AfterSecureWrappers: []netwrap.ConnWrapper{countingWrapper(&v6Conns)},
+			},
+			{
+				Addr:                &net.UnixAddr{Net: "unix", Name: sockPath},
+				AfterSecureWrappers: []netwrap.ConnWrapper{countingWrapper(&unixConns)},
+			},
+		},
+
+		MakeHandler: func(net.Conn) (muxrpc.Handler, error) {
+			return connectNotifier(connected), nil
+		},
+	})
+	r.NoError(err)
+
+	go func() {
+		err := server.Serve(ctx)
+		if err != nil && err != context.Canceled {
+			panic(err)
+		}
+	}()
+
+	addrs := server.GetListenAddrs()
+	r.Len(addrs, 3)
+	r.Equal(addrs[0].String(), server.GetListenAddr().String())
+
+	// dial each listener with a different client
+	for i, addr := range addrs {
+		kpClient, err := keys.NewKeyPair(nil)
+		r.NoError(err)
+
+		clientSHS, err := secretstream.NewClient(kpClient.Pair, appkey)
+		r.NoError(err)
+
+		plainAddr := netwrap.GetAddr(addr, "tcp")
+		if plainAddr == nil {
+			plainAddr = netwrap.GetAddr(addr, "unix")
+		}
+		r.NotNil(plainAddr, "listener %d: no dialable address", i)
+
+		conn, err := netwrap.Dial(plainAddr, clientSHS.ConnWrapper(kpServ.Feed.PubKey()))
+		r.NoError(err, "listener %d: dial failed", i)
+		defer conn.Close()
+
+		select {
+		case <-connected:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("listener %d: no connection", i)
+		}
+	}
+
+	r.EqualValues(1, atomic.LoadInt32(&v6Conns), "ipv6 listener wrapper not applied once")
+	r.EqualValues(1, atomic.LoadInt32(&unixConns), "unix listener wrapper not applied once")
+
+	r.NoError(server.Close())
+
+	_, err = os.Stat(sockPath)
+	r.True(os.IsNotExist(err), "socket file should be removed on close")
+}
+
+func TestUnixListenerKeepsOtherFiles(t *testing.T) {
+	r := require.New(t)
+
+	var appkey = make([]byte, 32)
+	rand.Read(appkey)
+
+	kpServ, err := keys.NewKeyPair(nil)
+	r.NoError(err)
+
+	// a regular file where the socket should go
+	sockPath := filepath.Join(t.TempDir(), "room.sock")
+	r.NoError(os.WriteFile(sockPath, []byte("not a socket"), 0600))
+
+	server, err := network.New(network.Options{
+		Logger:  log.NewLogfmtLogger(os.Stderr),
+		AppKey:  appkey,
+		KeyPair: kpServ,
+
+		ListenAddr: &net.UnixAddr{Net: "unix", Name: sockPath},
+
+		MakeHandler: func(net.Conn) (muxrpc.Handler, error) {
+			return nil, nil
+		},
+	})
+	r.NoError(err)
+
+	err = server.Serve(context.Background())
+	r.Error(err)
+	r.Contains(err.Error(), "is not a unix socket")
+
+	content, err := os.ReadFile(sockPath)
+	r.NoError(err)
+	r.Equal("not a socket", string(content), "file should be left alone")
+}
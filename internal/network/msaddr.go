@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ssbc/go-netwrap"
+	"github.com/ssbc/go-secretstream"
+	refs "github.com/ssbc/go-ssb-refs"
+)
+
+// ErrNoDialableAddress is returned by ParseMultiserverAddress if none of the addresses uses the net: transport
+var ErrNoDialableAddress = errors.New("multiserver address: no net:host:port~shs:key address found")
+
+// ParseMultiserverAddress picks the first net:host:port~shs:key address out of a (possibly ';' joined) multiserver address.
+// It returns the feed of the shs key and an address that can be passed to Network.Connect().
+func ParseMultiserverAddress(msaddr string) (refs.FeedRef, net.Addr, error) {
+	for _, addr := range strings.Split(msaddr, ";") {
+		parts := strings.Split(strings.TrimSpace(addr), "~")
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "net:") || !strings.HasPrefix(parts[1], "shs:") {
+			continue
+		}
+
+		hostPort := strings.TrimPrefix(parts[0], "net:")
+		i := strings.LastIndex(hostPort, ":")
+		if i < 1 {
+			return refs.FeedRef{}, nil, fmt.Errorf("multiserver address %q: expected host:port", addr)
+		}
+		// accept IPv6 hosts with and without brackets
+		host := strings.TrimSuffix(strings.TrimPrefix(hostPort[:i], "["), "]")
+		tcpAddr, err := net.ResolveTCPAddr("tcp", net.JoinHostPort(host, hostPort[i+1:]))
+		if err != nil {
+			return refs.FeedRef{}, nil, fmt.Errorf("multiserver address %q: %w", addr, err)
+		}
+
+		pubKey, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(parts[1], "shs:"))
+		if err != nil {
+			return refs.FeedRef{}, nil, fmt.Errorf("multiserver address %q: invalid shs key: %w", addr, err)
+		}
+		feed, err := refs.NewFeedRefFromBytes(pubKey, refs.RefAlgoFeedSSB1)
+		if err != nil {
+			return refs.FeedRef{}, nil, fmt.Errorf("multiserver address %q: invalid shs key: %w", addr, err)
+		}
+
+		return feed, netwrap.WrapAddr(tcpAddr, secretstream.Addr{PubKey: pubKey}), nil
+	}
+
+	return refs.FeedRef{}, nil, ErrNoDialableAddress
+}
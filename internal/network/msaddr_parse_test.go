@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ssbc/go-netwrap"
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMultiserverAddress(t *testing.T) {
+	r := require.New(t)
+
+	roomID, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("ohai"), 8), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	feed, addr, err := ParseMultiserverAddress("onion:abcdefghijklmnop.onion:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=;net:127.0.0.1:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=")
+	r.NoError(err)
+	r.True(feed.Equal(roomID))
+	r.Equal("127.0.0.1:8008", netwrap.GetAddr(addr, "tcp").String())
+
+	gotRef, err := GetFeedRefFromAddr(addr)
+	r.NoError(err)
+	r.True(gotRef.Equal(roomID))
+
+	_, _, err = ParseMultiserverAddress("net:::1:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=")
+	r.NoError(err, "ipv6 hosts should work")
+
+	_, addr, err = ParseMultiserverAddress("net:[::1]:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=")
+	r.NoError(err, "bracketed ipv6 hosts should work")
+	r.Equal("[::1]:8008", netwrap.GetAddr(addr, "tcp").String())
+
+	_, _, err = ParseMultiserverAddress("wss://the.ho.st~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=")
+	r.True(errors.Is(err, ErrNoDialableAddress))
+
+	_, _, err = ParseMultiserverAddress("net:127.0.0.1:8008~shs:nope")
+	r.Error(err)
+}
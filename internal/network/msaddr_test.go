@@ -34,3 +34,114 @@ func TestMultiserverAddress(t *testing.T) {
 	a.True(strings.HasSuffix(gotMultiAddr, base64.StdEncoding.EncodeToString(sed.RoomID.PubKey())), "public key missing? %s", gotMultiAddr)
 
 }
+
+func TestMultiserverAddresses(t *testing.T) {
+	a := assert.New(t)
+
+	var sed ServerEndpointDetails
+	sed.Domain = "the.ho.st"
+	sed.ListenAddressMUXRPC = ":8008"
+	sed.PublicAddresses = []string{
+		"net:[2001:db8::1]:8008",
+		"wss://the.ho.st:443",
+		"net:the.ho.st:8008", // duplicate of the primary
+		"onion:abcdefghijklmnop.onion:8008",
+	}
+
+	roomID, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("ohai"), 8), refs.RefAlgoFeedSSB1)
+	if err != nil {
+		t.Error(err)
+	}
+	sed.RoomID = roomID
+
+	shs := "~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk="
+	a.Equal([]string{
+		"net:the.ho.st:8008" + shs,
+		"net:[2001:db8::1]:8008" + shs,
+		"wss://the.ho.st:443" + shs,
+		"onion:abcdefghijklmnop.onion:8008" + shs,
+	}, sed.MultiserverAddresses())
+
+	gotMultiAddr := sed.MultiserverAddress()
+	a.Equal(4, len(strings.Split(gotMultiAddr, ";")))
+	a.True(strings.HasPrefix(gotMultiAddr, "net:the.ho.st:8008"+shs+";"), "primary address not first? %s", gotMultiAddr)
+}
+
+func TestValidateTransportAddress(t *testing.T) {
+	a := assert.New(t)
+
+	for _, good := range []string{
+		"net:the.ho.st:8008",
+		"net:[::1]:8008",
+		"net:10.0.0.1:8008",
+		"ws://the.ho.st:80",
+		"wss://the.ho.st:443",
+		"wss://the.ho.st",
+		"onion:abcdefghijklmnop.onion:8008",
+	} {
+		a.NoError(ValidateTransportAddress(good), "expected %q to be valid", good)
+	}
+
+	for _, bad := range []string{
+		"",
+		"the.ho.st:8008",
+		"net:the.ho.st",
+		"net::8008",
+		"net:the.ho.st:nope",
+		"net:the.ho.st:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=",
+		"net:a:8008;net:b:8008",
+		"onion:not-onion.com:8008",
+		"udp:the.ho.st:8008",
+		"wss://",
+		"ws:the.ho.st:80",
+		"net:2001:db8::1:8008",
+	} {
+		a.Error(ValidateTransportAddress(bad), "expected %q to be invalid", bad)
+	}
+}
+
+func TestMultiserverAddressOnion(t *testing.T) {
+	a := assert.New(t)
+
+	var sed ServerEndpointDetails
+	sed.Domain = "the.ho.st"
+	sed.ListenAddressMUXRPC = ":8008"
+	sed.OnionAddress = "abcdefghijklmnop.onion:8008"
+
+	roomID, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("ohai"), 8), refs.RefAlgoFeedSSB1)
+	if err != nil {
+		t.Error(err)
+	}
+	sed.RoomID = roomID
+
+	a.Equal("net:the.ho.st:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=;onion:abcdefghijklmnop.onion:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=", sed.MultiserverAddress())
+}
+
+func TestMultiserverAddressAdditionalListeners(t *testing.T) {
+	a := assert.New(t)
+
+	var sed ServerEndpointDetails
+	sed.Domain = "the.ho.st"
+	sed.ListenAddressMUXRPC = "0.0.0.0:8008"
+	sed.AdditionalListenAddressesMUXRPC = []string{
+		"[::]:8008",               // dual-stack, same as the main one
+		"[2001:db8::1]:8009",      // public IPv6
+		"192.168.1.10:8008",       // LAN
+		"127.0.0.1:8010",          // loopback
+		":8011",                   // all interfaces, other port
+		"unix:/run/room/mux.sock", // reverse proxy
+	}
+
+	roomID, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("ohai"), 8), refs.RefAlgoFeedSSB1)
+	if err != nil {
+		t.Error(err)
+	}
+	sed.RoomID = roomID
+
+	shs := "~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk="
+	a.Equal([]string{
+		"net:the.ho.st:8008" + shs,
+		"net:[2001:db8::1]:8009" + shs,
+		"net:the.ho.st:8011" + shs,
+	}, sed.MultiserverAddresses())
+}
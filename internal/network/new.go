@@ -11,8 +11,10 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ssbc/go-muxrpc/v2"
 	"github.com/ssbc/go-netwrap"
@@ -30,21 +32,73 @@ const DefaultPort = 8008
 type Options struct {
 	Logger log.Logger
 
-	Dialer     netwrap.Dialer
+	Dialer netwrap.Dialer
+
+	// ListenAddr is the primary address to listen on, returned by GetListenAddr()
 	ListenAddr net.Addr
 
+	// AdditionalListeners are opened next to ListenAddr.
+	// For instance IPv6 next to IPv4, a LAN interface or a unix socket used by a reverse proxy.
+	AdditionalListeners []ListenerOptions
+
+	// OnionListenAddr is an optional listener, the target of a tor onion service (HiddenServicePort).
+	// Can be a *net.TCPAddr or a *net.UnixAddr. Connections on it are handled just like the ones on ListenAddr.
+	OnionListenAddr net.Addr
+
 	KeyPair     *keys.KeyPair
 	AppKey      []byte
 	MakeHandler func(net.Conn) (muxrpc.Handler, error)
 
 	ConnTracker ConnTracker
 
+	// ConnLimiter is applied to all incoming connections, before the secret-handshake.
+	// Outgoing connections are not limited.
+	ConnLimiter *ConnLimiter
+
+	// ProxyProtocol makes the listeners read the PROXY protocol header on connections from TrustedProxies and unix sockets.
+	// The address from the header is used as the remote address of the connection.
+	ProxyProtocol  bool
+	TrustedProxies TrustedProxies
+
+	// Websocket configures the connections that come in through WebsockHandler and WebsocketEndpoint
+	Websocket WebsocketOptions
+
 	// PreSecureWrappers are applied before the shs+boxstream wrapping takes place
 	// usefull for accessing the sycall.Conn to apply control options on the socket
 	BefreCryptoWrappers []netwrap.ConnWrapper
 
 	// AfterSecureWrappers are applied afterwards, usefull to debug muxrpc content
 	AfterSecureWrappers []netwrap.ConnWrapper
+
+	// OnSessionEnd is called once a peer connection, over TCP or websocket, is closed
+	OnSessionEnd func(SessionEnd)
+}
+
+// SessionEnd describes a peer connection that was closed
+type SessionEnd struct {
+	Remote refs.FeedRef
+
+	Started, Ended time.Time
+
+	// Websocket is true if the peer connected over the websocket handler, otherwise it was TCP (or a unix socket)
+	Websocket bool
+}
+
+// ListenerOptions configure one of the addresses the node listens on
+type ListenerOptions struct {
+	Addr net.Addr
+
+	// BefreCryptoWrappers are applied after Options.BefreCryptoWrappers, only on connections of this listener
+	BefreCryptoWrappers []netwrap.ConnWrapper
+
+	// AfterSecureWrappers are applied after Options.AfterSecureWrappers, only on connections of this listener
+	AfterSecureWrappers []netwrap.ConnWrapper
+}
+
+// an opened listener and the options it was created with
+type listener struct {
+	opts ListenerOptions
+	lis  net.Listener
 }
 
 type node struct {
@@ -56,7 +110,8 @@ type node struct {
 
 	listenerLock sync.Mutex
 	lisClose     sync.Once
-	lis          net.Listener
+	listenerOpts []ListenerOptions
+	listeners    []listener
 
 	dialer       netwrap.Dialer
 	secretServer *secretstream.Server
@@ -79,6 +134,7 @@ func New(opts Options) (Network, error) {
 		opts:    opts,
 		remotes: make(map[string]muxrpc.Endpoint),
 	}
+	n.opts.Websocket.setDefaults()
 
 	if opts.ConnTracker == nil {
 		opts.ConnTracker = NewLastWinsTracker()
@@ -106,6 +162,14 @@ func New(opts Options) (Network, error) {
 	n.beforeCryptoConnWrappers = opts.BefreCryptoWrappers
 	n.afterSecureConnWrappers = opts.AfterSecureWrappers
 
+	if opts.ListenAddr != nil {
+		n.listenerOpts = append(n.listenerOpts, ListenerOptions{Addr: opts.ListenAddr})
+	}
+	n.listenerOpts = append(n.listenerOpts, opts.AdditionalListeners...)
+	if opts.OnionListenAddr != nil {
+		n.listenerOpts = append(n.listenerOpts, ListenerOptions{Addr: opts.OnionListenAddr})
+	}
+
 	n.listening = make(chan struct{})
 
 	n.log = opts.Logger
@@ -189,9 +253,9 @@ func (n *node) removeRemote(edp muxrpc.Endpoint) {
 	delete(n.remotes, r.String())
 }
 
-func (n *node) handleConnection(ctx context.Context, origConn net.Conn, isServer bool, hws ...muxrpc.HandlerWrapper) {
+func (n *node) handleConnection(ctx context.Context, origConn net.Conn, isServer bool, afterSecure []netwrap.ConnWrapper, hws ...muxrpc.HandlerWrapper) {
 	// TODO: overhaul events and logging levels
-	conn, err := n.applyConnWrappers(origConn)
+	conn, err := n.applyConnWrappers(origConn, afterSecure)
 	if err != nil {
 		origConn.Close()
 		level.Error(n.log).Log("msg", "node/Serve: failed to wrap connection", "err", err)
@@ -214,10 +278,12 @@ func (n *node) handleConnection(ctx context.Context, origConn net.Conn, isServer
 		return
 	}
 
+	started := time.Now()
 	defer func() {
 		n.connTracker.OnClose(conn)
 		conn.Close()
 		origConn.Close()
+		n.sessionEnded(remoteRef, started, false)
 	}()
 
 	h, err := n.opts.MakeHandler(conn)
@@ -255,19 +321,37 @@ func (n *node) handleConnection(ctx context.Context, origConn net.Conn, isServer
 	// level.Error(n.log).Log("conn", "serve-defer-terminate", "err", err)
 }
 
-// Serve starts the network listener and configured resources like local discovery.
+// sessionEnded calls the OnSessionEnd hook, if there is one
+func (n *node) sessionEnded(remote refs.FeedRef, started time.Time, websocket bool) {
+	if n.opts.OnSessionEnd == nil {
+		return
+	}
+	n.opts.OnSessionEnd(SessionEnd{
+		Remote:    remote,
+		Started:   started,
+		Ended:     time.Now(),
+		Websocket: websocket,
+	})
+}
+
+// Serve starts the network listeners and configured resources like local discovery.
 // Canceling the passed context makes the function return. Defers take care of stopping these resources.
 func (n *node) Serve(ctx context.Context, wrappers ...muxrpc.HandlerWrapper) error {
-	// TODO: make multiple listeners (localhost:8008 should not restrict or kill connections)
-	lisWrap := netwrap.NewListenerWrapper(n.secretServer.Addr(), append(n.opts.BefreCryptoWrappers, n.secretServer.ConnWrapper())...)
-	var err error
+	if len(n.listenerOpts) == 0 {
+		return errors.New("node/Serve: no listen addresses configured")
+	}
 
 	n.listenerLock.Lock()
-	n.lis, err = netwrap.Listen(n.opts.ListenAddr, lisWrap)
-	if err != nil {
-		n.listenerLock.Unlock()
-		return fmt.Errorf("error creating listener: %w", err)
+	for _, lo := range n.listenerOpts {
+		lis, err := n.listen(lo)
+		if err != nil {
+			n.closeListeners()
+			n.listenerLock.Unlock()
+			return fmt.Errorf("error creating listener on %s: %w", lo.Addr, err)
+		}
+		n.listeners = append(n.listeners, listener{opts: lo, lis: lis})
 	}
+	listeners := n.listeners
 	n.lisClose = sync.Once{} // reset once
 	close(n.listening)
 	n.listenerLock.Unlock()
@@ -275,37 +359,22 @@ func (n *node) Serve(ctx context.Context, wrappers ...muxrpc.HandlerWrapper) err
 	defer func() { // refresh listener to re-call
 		n.lisClose.Do(func() {
 			n.listenerLock.Lock()
-			n.lis.Close()
-			n.lis = nil
+			n.closeListeners()
 			n.listenerLock.Unlock()
 		})
 		n.listening = make(chan struct{})
 	}()
 
-	// accept in a goroutine so that we can react to context cancel and close the listener
-	newConn := make(chan net.Conn)
+	// accept in goroutines so that we can react to context cancel and close the listeners
+	newConn := make(chan acceptedConn)
+	var acceptors sync.WaitGroup
+	acceptors.Add(len(listeners))
+	for _, l := range listeners {
+		go n.acceptLoop(&acceptors, l, newConn)
+	}
 	go func() {
-		defer close(newConn)
-		for {
-			n.listenerLock.Lock()
-			if n.lis == nil {
-				n.listenerLock.Unlock()
-				return
-			}
-			n.listenerLock.Unlock()
-			conn, err := n.lis.Accept()
-			if err != nil {
-				if strings.Contains(err.Error(), "use of closed network connection") {
-					// yikes way of handling this
-					// but means this needs to be restarted anyway
-					return
-				}
-
-				continue
-			}
-
-			newConn <- conn
-		}
+		acceptors.Wait()
+		close(newConn)
 	}()
 
 	defer level.Debug(n.log).Log("event", "network listen loop exited")
@@ -313,15 +382,93 @@ func (n *node) Serve(ctx context.Context, wrappers ...muxrpc.HandlerWrapper) err
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case conn := <-newConn:
-			if conn == nil {
+		case ac, ok := <-newConn:
+			if !ok {
 				return nil
 			}
-			go n.handleConnection(ctx, conn, true, wrappers...)
+			go n.handleConnection(ctx, ac.conn, true, ac.afterSecure, wrappers...)
+		}
+	}
+}
+
+// a connection from one of the listeners, together with that listeners after-secure wrappers
+type acceptedConn struct {
+	conn        net.Conn
+	afterSecure []netwrap.ConnWrapper
+}
+
+// acceptLoop accepts connections on the listener and passes them to newConn until it is closed
+func (n *node) acceptLoop(wg *sync.WaitGroup, l listener, newConn chan<- acceptedConn) {
+	defer wg.Done()
+	for {
+		conn, err := l.lis.Accept()
+		if err != nil {
+			if strings.Contains(err.Error(), "use of closed network connection") {
+				// yikes way of handling this
+				// but means this needs to be restarted anyway
+				return
+			}
+
+			continue
 		}
+
+		newConn <- acceptedConn{conn: conn, afterSecure: l.opts.AfterSecureWrappers}
 	}
 }
 
+// listen opens a listener that does the secret-handshake on all its connections.
+// A stale unix socket file from a previous run is removed first.
+func (n *node) listen(lo ListenerOptions) (net.Listener, error) {
+	var beforeCrypto []netwrap.ConnWrapper
+	if n.opts.ProxyProtocol {
+		// first, so that everything after it sees the address of the client
+		beforeCrypto = append(beforeCrypto, n.opts.TrustedProxies.ProxyProtocolWrapper())
+	}
+	beforeCrypto = append(append(beforeCrypto, n.opts.BefreCryptoWrappers...), lo.BefreCryptoWrappers...)
+	if n.opts.ConnLimiter != nil {
+		// turn connections away before the handshake. it comes last so that the other wrappers still see the plain socket
+		beforeCrypto = append(beforeCrypto, n.opts.ConnLimiter.ConnWrapper())
+	}
+	// a failed handshake is closed, which also releases it from the limiter
+	shs := CloseOnError(n.secretServer.ConnWrapper())
+	lisWrap := netwrap.NewListenerWrapper(n.secretServer.Addr(), append(beforeCrypto, shs)...)
+
+	if ua, ok := lo.Addr.(*net.UnixAddr); ok {
+		if c, err := net.Dial("unix", ua.Name); err == nil {
+			c.Close()
+			return nil, fmt.Errorf("socket %s already in use", ua.Name)
+		}
+		// remove a stale socket left behind by a previous run, but nothing else
+		fi, err := os.Lstat(ua.Name)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("failed to stat %s: %w", ua.Name, err)
+		case fi.Mode()&os.ModeSocket == 0:
+			return nil, fmt.Errorf("%s exists and is not a unix socket", ua.Name)
+		default:
+			if err := os.Remove(ua.Name); err != nil {
+				return nil, fmt.Errorf("failed to remove stale socket %s: %w", ua.Name, err)
+			}
+		}
+	}
+
+	return netwrap.Listen(lo.Addr, lisWrap)
+}
+
+// closeListeners closes all open listeners, the caller needs to hold listenerLock
+func (n *node) closeListeners() error {
+	var closeErr error
+	for _, l := range n.listeners {
+		err := l.lis.Close()
+		if err != nil && !strings.Contains(err.Error(), "use of closed network connection") && closeErr == nil {
+			closeErr = fmt.Errorf("failed to close listener on %s: %w", l.opts.Addr, err)
+		}
+	}
+	n.listeners = nil
+	return closeErr
+}
+
 func (n *node) Connect(ctx context.Context, addr net.Addr) error {
 	select {
 	case <-ctx.Done():
@@ -350,23 +497,39 @@ func (n *node) Connect(ctx context.Context, addr net.Addr) error {
 	}
 
 	go func(c net.Conn) {
-		n.handleConnection(ctx, c, false)
+		n.handleConnection(ctx, c, false, nil)
 	}(conn)
 	return nil
 }
 
-// GetListenAddr waits for Serve() to be called!
+// GetListenAddr waits for Serve() to be called and returns the address of the primary listener!
 func (n *node) GetListenAddr() net.Addr {
+	addrs := n.GetListenAddrs()
+	if len(addrs) == 0 {
+		return nil
+	}
+	return addrs[0]
+}
+
+// GetListenAddrs waits for Serve() to be called and returns the addresses of all listeners
+func (n *node) GetListenAddrs() []net.Addr {
 	_, ok := <-n.listening
-	if !ok {
-		return n.lis.Addr()
+	if ok {
+		level.Error(n.log).Log("msg", "listener not ready")
+		return nil
 	}
-	level.Error(n.log).Log("msg", "listener not ready")
-	return nil
+
+	n.listenerLock.Lock()
+	defer n.listenerLock.Unlock()
+	addrs := make([]net.Addr, len(n.listeners))
+	for i, l := range n.listeners {
+		addrs[i] = l.lis.Addr()
+	}
+	return addrs
 }
 
-func (n *node) applyConnWrappers(conn net.Conn) (net.Conn, error) {
-	for i, cw := range n.afterSecureConnWrappers {
+func (n *node) applyConnWrappers(conn net.Conn, extra []netwrap.ConnWrapper) (net.Conn, error) {
+	for i, cw := range append(append([]netwrap.ConnWrapper{}, n.afterSecureConnWrappers...), extra...) {
 		var err error
 		conn, err = cw(conn)
 		if err != nil {
@@ -385,14 +548,12 @@ func (n *node) Close() error {
 	}
 	n.listenerLock.Lock()
 	defer n.listenerLock.Unlock()
-	if n.lis != nil {
-		var closeErr error
-		n.lisClose.Do(func() {
-			closeErr = n.lis.Close()
-		})
-		if closeErr != nil && !strings.Contains(closeErr.Error(), "use of closed network connection") {
-			return fmt.Errorf("ssb: network node failed to close it's listener: %w", closeErr)
-		}
+	var closeErr error
+	n.lisClose.Do(func() {
+		closeErr = n.closeListeners()
+	})
+	if closeErr != nil {
+		return fmt.Errorf("ssb: network node failed to close it's listeners: %w", closeErr)
 	}
 
 	n.remotesLock.Lock()
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network_test
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ssbc/go-muxrpc/v2"
+	"github.com/ssbc/go-netwrap"
+	"github.com/ssbc/go-secretstream"
+	"github.com/stretchr/testify/require"
+	"go.mindeco.de/log"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/keys"
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+)
+
+// TestOnionListener uses a tiny TCP to unix socket forwarder as a stand-in for the tor daemon
+func TestOnionListener(t *testing.T) {
+	r := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var appkey = make([]byte, 32)
+	rand.Read(appkey)
+
+	logger := log.NewLogfmtLogger(os.Stderr)
+
+	kpClient, err := keys.NewKeyPair(nil)
+	r.NoError(err)
+
+	kpServ, err := keys.NewKeyPair(nil)
+	r.NoError(err)
+
+	sockPath := filepath.Join(t.TempDir(), "onion.sock")
+	onionAddr, err := network.ParseListenAddr("unix:" + sockPath)
+	r.NoError(err)
+
+	connected := make(chan muxrpc.Endpoint, 1)
+	server, err := network.New(network.Options{
+		Logger:  logger,
+		AppKey:  appkey,
+		KeyPair: kpServ,
+
+		ListenAddr:      &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0},
+		OnionListenAddr: onionAddr,
+
+		MakeHandler: func(net.Conn) (muxrpc.Handler, error) {
+			return connectNotifier(connected), nil
+		},
+	})
+	r.NoError(err)
+
+	go func() {
+		err := server.Serve(ctx)
+		if err != nil && err != context.Canceled {
+			panic(err)
+		}
+	}()
+	server.GetListenAddr() // wait for the listeners
+
+	// the stand-in for tor: forwards plain TCP to the unix socket
+	torLis, err := net.Listen("tcp", "127.0.0.1:0")
+	r.NoError(err)
+	defer torLis.Close()
+	go func() {
+		for {
+			c, err := torLis.Accept()
+			if err != nil {
+				return
+			}
+			go func(in net.Conn) {
+				defer in.Close()
+				out, err := net.Dial("unix", sockPath)
+				if err != nil {
+					return
+				}
+				defer out.Close()
+				go io.Copy(out, in)
+				io.Copy(in, out)
+			}(c)
+		}
+	}()
+
+	client, err := network.New(network.Options{
+		Logger:  logger,
+		AppKey:  appkey,
+		KeyPair: kpClient,
+
+		MakeHandler: func(net.Conn) (muxrpc.Handler, error) {
+			return connectNotifier(make(chan muxrpc.Endpoint, 1)), nil
+		},
+	})
+	r.NoError(err)
+
+	viaTor := netwrap.WrapAddr(torLis.Addr(), secretstream.Addr{PubKey: kpServ.Feed.PubKey()})
+	err = client.Connect(ctx, viaTor)
+	r.NoError(err)
+
+	select {
+	case edp := <-connected:
+		remote, err := network.GetFeedRefFromAddr(edp.Remote())
+		r.NoError(err)
+		r.True(remote.Equal(kpClient.Feed), "wrong peer connected")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no connection over the onion listener")
+	}
+
+	r.NoError(client.Close())
+	r.NoError(server.Close())
+}
+
+type connectNotifier chan muxrpc.Endpoint
+
+func (connectNotifier) Handled(muxrpc.Method) bool { return true }
+
+func (cn connectNotifier) HandleConnect(ctx context.Context, e muxrpc.Endpoint) {
+	select {
+	case cn <- e:
+	default:
+	}
+}
+
+func (connectNotifier) HandleCall(ctx context.Context, req *muxrpc.Request) {}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// DefaultTrustedProxies are the loopback addresses, where a reverse proxy on the same host connects from
+var DefaultTrustedProxies = []string{"127.0.0.0/8", "::1/128"}
+
+// TrustedProxies are the addresses of reverse proxies.
+// Only their X-Forwarded-For and Forwarded headers and their PROXY protocol headers are used to find the address of the client.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses a list of CIDR ranges. Single IP addresses are also accepted.
+func ParseTrustedProxies(list []string) (TrustedProxies, error) {
+	var tp TrustedProxies
+	for _, s := range list {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("trusted proxies: %q is not an IP address or CIDR range", s)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			tp = append(tp, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxies: %w", err)
+		}
+		tp = append(tp, ipnet)
+	}
+	return tp, nil
+}
+
+// Contains returns true if ip is the address of a trusted proxy
+func (tp TrustedProxies) Contains(ip net.IP) bool {
+	for _, n := range tp {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientAddr returns the address of the client that made the request, as host:port.
+// If the request came from a trusted proxy, the forwarding headers are followed from the right, past all the trusted hops.
+// The port of a forwarded address is unknown and set to 0.
+func (tp TrustedProxies) ClientAddr(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !tp.Contains(ip) {
+		return req.RemoteAddr
+	}
+
+	hops := forwardedFor(req.Header)
+	if len(hops) == 0 {
+		return req.RemoteAddr
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(hops[i])
+		if hop == nil { // obfuscated or garbage, don't look further
+			break
+		}
+		ip = hop
+		if !tp.Contains(hop) {
+			break
+		}
+	}
+
+	return net.JoinHostPort(ip.String(), "0")
+}
+
+// ResolveRemoteAddr is a middleware that replaces the RemoteAddr of the requests with the ClientAddr.
+// Everything after it, like rate limiting, logging and the websocket handler, sees the address of the client instead of the proxy.
+func (tp TrustedProxies) ResolveRemoteAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.RemoteAddr = tp.ClientAddr(req)
+		next.ServeHTTP(w, req)
+	})
+}
+
+// forwardedFor returns the IP addresses of the hops from the Forwarded header or, if there is none, from X-Forwarded-For.
+// The client comes first, the proxy closest to the room last.
+func forwardedFor(h http.Header) []string {
+	var hops []string
+
+	for _, line := range h.Values("Forwarded") {
+		for _, elem := range strings.Split(line, ",") {
+			var addr string
+			for _, pair := range strings.Split(elem, ";") {
+				pair = strings.TrimSpace(pair)
+				if len(pair) > 4 && strings.EqualFold(pair[:4], "for=") {
+					addr = pair[4:]
+				}
+			}
+			hops = append(hops, stripForwardedPort(strings.Trim(addr, `"`)))
+		}
+	}
+	if len(hops) > 0 {
+		return hops
+	}
+
+	for _, line := range h.Values("X-Forwarded-For") {
+		for _, addr := range strings.Split(line, ",") {
+			hops = append(hops, stripForwardedPort(strings.TrimSpace(addr)))
+		}
+	}
+	return hops
+}
+
+// stripForwardedPort turns "[2001:db8::1]:4711", "192.0.2.1:80" and "[2001:db8::1]" into plain IP addresses
+func stripForwardedPort(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+}
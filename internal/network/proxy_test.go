@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network_test
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+)
+
+func TestTrustedProxiesClientAddr(t *testing.T) {
+	r := require.New(t)
+
+	tp, err := network.ParseTrustedProxies([]string{"127.0.0.0/8", "10.0.0.0/8", "2001:db8::1"})
+	r.NoError(err)
+
+	_, err = network.ParseTrustedProxies([]string{"not-an-ip"})
+	r.Error(err)
+
+	type testCase struct {
+		remote  string
+		headers map[string]string
+		want    string
+	}
+	cases := []testCase{
+		// no proxy
+		{"192.0.2.1:4000", nil, "192.0.2.1:4000"},
+		// spoofed by a client
+		{"192.0.2.1:4000", map[string]string{"X-Forwarded-For": "198.51.100.7"}, "192.0.2.1:4000"},
+		// trusted proxy
+		{"127.0.0.1:4000", map[string]string{"X-Forwarded-For": "198.51.100.7"}, "198.51.100.7:0"},
+		// a client that prepends a fake hop
+		{"127.0.0.1:4000", map[string]string{"X-Forwarded-For": "203.0.113.9, 198.51.100.7"}, "198.51.100.7:0"},
+		// two trusted hops
+		{"127.0.0.1:4000", map[string]string{"X-Forwarded-For": "198.51.100.7, 10.1.2.3"}, "198.51.100.7:0"},
+		// trusted proxy without a header
+		{"127.0.0.1:4000", nil, "127.0.0.1:4000"},
+		// Forwarded is preferred
+		{"[2001:db8::1]:4000", map[string]string{
+			"Forwarded":       `for="[2001:db8:cafe::17]:4711";proto=https`,
+			"X-Forwarded-For": "198.51.100.7",
+		}, "[2001:db8:cafe::17]:0"},
+		{"127.0.0.1:4000", map[string]string{"Forwarded": "for=198.51.100.7, for=10.0.0.2;by=10.0.0.3"}, "198.51.100.7:0"},
+		// obfuscated identifiers stop the search
+		{"127.0.0.1:4000", map[string]string{"Forwarded": "for=198.51.100.7, for=_hidden, for=10.0.0.2"}, "10.0.0.2:0"},
+	}
+
+	for i, tc := range cases {
+		req, err := http.NewRequest("GET", "/", nil)
+		r.NoError(err)
+		req.RemoteAddr = tc.remote
+		for k, v := range tc.headers {
+			req.Header.Set(k, v)
+		}
+		r.Equal(tc.want, tp.ClientAddr(req), "case %d", i)
+	}
+}
+
+func TestProxyProtocol(t *testing.T) {
+	r := require.New(t)
+
+	wrap := network.TrustedProxies{}.ProxyProtocolWrapper()
+
+	// roundtrip writes the header and the start of a handshake and returns the remote address and what was read after the header
+	roundtrip := func(header []byte) (net.Addr, string, error) {
+		server, client := net.Pipe()
+		defer server.Close()
+		defer client.Close()
+
+		go func() {
+			client.Write(append(header, []byte("hello, room")...))
+		}()
+
+		c, err := wrap(server)
+		if err != nil {
+			return nil, "", err
+		}
+		buf := make([]byte, 11)
+		_, err = io.ReadFull(c, buf)
+		return c.RemoteAddr(), string(buf), err
+	}
+
+	addr, rest, err := roundtrip([]byte("PROXY TCP4 198.51.100.7 192.0.2.2 56324 8008\r\n"))
+	r.NoError(err)
+	r.Equal("198.51.100.7:56324", addr.String())
+	r.Equal("hello, room", rest)
+
+	addr, rest, err = roundtrip([]byte("PROXY TCP6 2001:db8::17 2001:db8::1 4711 8008\r\n"))
+	r.NoError(err)
+	r.Equal("[2001:db8::17]:4711", addr.String())
+	r.Equal("hello, room", rest)
+
+	// version 2, with a TLV after the addresses
+	v2 := []byte("\r\n\r\n\x00\r\nQUIT\n")
+	v2 = append(v2, 0x21, 0x11, 0, 0)
+	payload := []byte{198, 51, 100, 7, 192, 0, 2, 2, 0, 0, 0x1f, 0x48, 0x04, 0x00, 0x01, 0x00}
+	binary.BigEndian.PutUint16(payload[8:10], 56324)
+	binary.BigEndian.PutUint16(v2[14:16], uint16(len(payload)))
+	v2 = append(v2, payload...)
+
+	addr, rest, err = roundtrip(v2)
+	r.NoError(err)
+	r.Equal("198.51.100.7:56324", addr.String())
+	r.Equal("hello, room", rest)
+
+	// no header, nothing is lost
+	addr, rest, err = roundtrip(nil)
+	r.NoError(err)
+	r.Equal("pipe", addr.String())
+	r.Equal("hello, room", rest)
+
+	_, _, err = roundtrip([]byte("PROXY TCP4 garbage\r\n"))
+	r.Error(err)
+}
+
+func TestProxyProtocolUntrusted(t *testing.T) {
+	r := require.New(t)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	r.NoError(err)
+	defer lis.Close()
+
+	go func() {
+		c, err := net.Dial("tcp", lis.Addr().String())
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		c.Write([]byte("PROXY TCP4 198.51.100.7 192.0.2.2 56324 8008\r\n"))
+	}()
+
+	c, err := lis.Accept()
+	r.NoError(err)
+	defer c.Close()
+
+	// only 10.0.0.0/8 is trusted, the header is left for the secret-handshake to fail on
+	tp, err := network.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	r.NoError(err)
+	wrapped, err := tp.ProxyProtocolWrapper()(c)
+	r.NoError(err)
+	r.Equal(c.RemoteAddr(), wrapped.RemoteAddr())
+}
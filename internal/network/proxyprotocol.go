@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ssbc/go-netwrap"
+)
+
+// proxyHeaderTimeout is how long a proxy has to send the PROXY protocol header
+const proxyHeaderTimeout = 10 * time.Second
+
+var (
+	proxyV1Prefix    = []byte("PROXY ")
+	proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+// ProxyProtocolWrapper reads the PROXY protocol header (version 1 or 2) that reverse proxies like haproxy and nginx send in front of the connection.
+// The connection it returns has the address of the client as its RemoteAddr.
+//
+// Only connections from trusted proxies and unix sockets are checked for a header, the others are returned as they are.
+// A connection from a trusted proxy without a header is also returned as it is.
+func (tp TrustedProxies) ProxyProtocolWrapper() netwrap.ConnWrapper {
+	return func(c net.Conn) (net.Conn, error) {
+		if ip := addrIP(c.RemoteAddr()); ip != nil && !tp.Contains(ip) {
+			return c, nil
+		}
+
+		if err := c.SetReadDeadline(time.Now().Add(proxyHeaderTimeout)); err != nil {
+			c.Close()
+			return nil, err
+		}
+
+		br := bufio.NewReader(c)
+		remote, err := readProxyHeader(br)
+		if err != nil {
+			c.Close()
+			return nil, fmt.Errorf("proxy protocol: %w", err)
+		}
+
+		if err := c.SetReadDeadline(time.Time{}); err != nil {
+			c.Close()
+			return nil, err
+		}
+
+		if remote == nil { // no header or a health check of the proxy
+			remote = c.RemoteAddr()
+		}
+		return &proxiedConn{Conn: c, r: br, remote: remote}, nil
+	}
+}
+
+// readProxyHeader consumes the header and returns the source address from it.
+// It returns nil if there is no header or the header doesn't carry an address.
+func readProxyHeader(br *bufio.Reader) (net.Addr, error) {
+	// the client of the secret-handshake speaks first, so there always are enough bytes to look at
+	start, err := br.Peek(len(proxyV1Prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bytes.Equal(start, proxyV1Prefix):
+		return readProxyV1(br)
+
+	case bytes.Equal(start, proxyV2Signature[:len(start)]):
+		sig, err := br.Peek(len(proxyV2Signature))
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(sig, proxyV2Signature) {
+			return nil, nil
+		}
+		return readProxyV2(br)
+	}
+
+	return nil, nil
+}
+
+// readProxyV1 parses a line like "PROXY TCP4 192.0.2.1 192.0.2.2 56324 8008\r\n"
+func readProxyV1(br *bufio.Reader) (net.Addr, error) {
+	var line []byte
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+		if len(line) > 107 { // the maximum length defined by the spec
+			return nil, fmt.Errorf("v1 header too long")
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, fmt.Errorf("v1 header not terminated by CRLF")
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("v1 header without protocol")
+	}
+	if fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, fmt.Errorf("invalid v1 header %q", strings.TrimSpace(string(line)))
+	}
+
+	ip := net.ParseIP(fields[2])
+	if ip == nil {
+		return nil, fmt.Errorf("invalid v1 source address %q", fields[2])
+	}
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid v1 source port %q", fields[4])
+	}
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
+
+// readProxyV2 parses the binary header
+func readProxyV2(br *bufio.Reader) (net.Addr, error) {
+	var hdr [16]byte
+	if _, err := io.ReadFull(br, hdr[:]); err != nil {
+		return nil, err
+	}
+
+	verCmd, family := hdr[12], hdr[13]
+	if verCmd>>4 != 2 {
+		return nil, fmt.Errorf("unsupported v2 version %d", verCmd>>4)
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint16(hdr[14:16]))
+	if _, err := io.ReadFull(br, payload); err != nil {
+		return nil, err
+	}
+
+	if verCmd&0x0f == 0 { // LOCAL, sent by the proxy itself
+		return nil, nil
+	}
+
+	switch family {
+	case 0x11: // TCP over IPv4
+		if len(payload) < 12 {
+			return nil, fmt.Errorf("short v2 IPv4 addresses")
+		}
+		return &net.TCPAddr{
+			IP:   net.IP(payload[0:4]),
+			Port: int(binary.BigEndian.Uint16(payload[8:10])),
+		}, nil
+
+	case 0x21: // TCP over IPv6
+		if len(payload) < 36 {
+			return nil, fmt.Errorf("short v2 IPv6 addresses")
+		}
+		return &net.TCPAddr{
+			IP:   net.IP(payload[0:16]),
+			Port: int(binary.BigEndian.Uint16(payload[32:34])),
+		}, nil
+	}
+
+	// unix sockets and UDP don't carry a useful address
+	return nil, nil
+}
+
+// addrIP returns the IP of TCP and UDP addresses and nil for the others, like unix sockets
+func addrIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		return a.IP
+	case *net.UDPAddr:
+		return a.IP
+	}
+	return nil
+}
+
+// proxiedConn reads through the buffer that was used for the header and reports the address of the client
+type proxiedConn struct {
+	net.Conn
+
+	r      *bufio.Reader
+	remote net.Addr
+}
+
+func (pc *proxiedConn) Read(b []byte) (int, error) {
+	return pc.r.Read(b)
+}
+
+func (pc *proxiedConn) RemoteAddr() net.Addr {
+	return pc.remote
+}
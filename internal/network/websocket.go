@@ -10,6 +10,9 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -18,58 +21,160 @@ import (
 	"go.mindeco.de/log/level"
 )
 
+// Defaults for WebsocketOptions
+const (
+	DefaultWebsocketBufferSize     = 4 * 1024
+	DefaultWebsocketMaxMessageSize = 1024 * 1024
+	DefaultWebsocketPingInterval   = 30 * time.Second
+	DefaultWebsocketIdleTimeout    = 90 * time.Second
+)
+
+// WebsocketOptions configure the websocket transport. Zero values use the defaults above.
+type WebsocketOptions struct {
+	// AllowedOrigins are the hosts browsers can open a connection from, like "room.example" or "*.room.example" for all subdomains.
+	// "*" allows all origins. Without any, only pages on the same host as the websocket endpoint can connect.
+	// Requests without an Origin header don't come from a browser and are always allowed.
+	AllowedOrigins []string
+
+	ReadBufferSize  int
+	WriteBufferSize int
+
+	// MaxMessageSize is the largest websocket message a peer can send. A negative value disables the limit.
+	MaxMessageSize int64
+
+	// PingInterval is how often the peers are pinged. A negative value disables the pings.
+	PingInterval time.Duration
+
+	// IdleTimeout closes connections that didn't send anything, not even an answer to a ping, for this long.
+	// A negative value disables it.
+	IdleTimeout time.Duration
+}
+
+func (o *WebsocketOptions) setDefaults() {
+	if o.ReadBufferSize <= 0 {
+		o.ReadBufferSize = DefaultWebsocketBufferSize
+	}
+	if o.WriteBufferSize <= 0 {
+		o.WriteBufferSize = DefaultWebsocketBufferSize
+	}
+	if o.MaxMessageSize == 0 {
+		o.MaxMessageSize = DefaultWebsocketMaxMessageSize
+	}
+	if o.PingInterval == 0 {
+		o.PingInterval = DefaultWebsocketPingInterval
+	}
+	if o.IdleTimeout == 0 {
+		o.IdleTimeout = DefaultWebsocketIdleTimeout
+	}
+}
+
+// checkOrigin implements the origin policy of AllowedOrigins
+func (o WebsocketOptions) checkOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+
+	allowed := o.AllowedOrigins
+	if len(allowed) == 0 {
+		reqHost := req.Host
+		if h, _, err := net.SplitHostPort(reqHost); err == nil {
+			reqHost = h
+		}
+		allowed = []string{reqHost}
+	}
+
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		switch {
+		case a == "*":
+			return true
+		case strings.HasPrefix(a, "*."):
+			if strings.HasSuffix(host, a[1:]) {
+				return true
+			}
+		case a == host:
+			return true
+		}
+	}
+	return false
+}
+
 // WebsockHandler returns a "middleware" like thing that is able to upgrade a
 // websocket request to a muxrpc connection and authenticate using shs.
-// It calls the next handler if it fails to upgrade the connection to websocket.
-// However, it will error on the request and not call the passed handler
-// if the websocket upgrade is successfull.
+// Requests that don't ask for a websocket upgrade are passed to the next handler.
+// Use WebsocketEndpoint instead, to serve the websockets on their own path.
 func (n *node) WebsockHandler(next http.Handler) http.Handler {
+	wsh := n.newWebsocketHandler()
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !websocket.IsWebSocketUpgrade(req) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		wsh.ServeHTTP(w, req)
+	})
+}
+
+// WebsocketEndpoint returns a handler that only serves the websocket connections, for mounting it on its own path.
+func (n *node) WebsocketEndpoint() http.Handler {
+	return n.newWebsocketHandler()
+}
+
+func (n *node) newWebsocketHandler() websocketHandelr {
+	opts := n.opts.Websocket
 	var upgrader = websocket.Upgrader{
-		ReadBufferSize:  1024 * 4,
-		WriteBufferSize: 1024 * 4,
+		ReadBufferSize:  opts.ReadBufferSize,
+		WriteBufferSize: opts.WriteBufferSize,
 
-		CheckOrigin: func(_ *http.Request) bool {
-			return true
-		},
+		CheckOrigin: opts.checkOrigin,
 
 		// 99% of the traffic will be ciphertext which is impossible to distinguish
 		// from randomness and thus also hard to compress
 		EnableCompression: false,
 
-		// if upgrading fails, just call the next handler and ignore the error
-		Error: func(w http.ResponseWriter, req *http.Request, _ int, _ error) {
-			next.ServeHTTP(w, req)
+		Error: func(w http.ResponseWriter, req *http.Request, status int, reason error) {
+			level.Debug(n.log).Log("event", "websocket upgrade failed", "status", status, "err", reason, "remote", req.RemoteAddr)
+			http.Error(w, http.StatusText(status), status)
 		},
 	}
 
 	var wsh websocketHandelr
-	wsh.next = next
 	wsh.upgrader = &upgrader
 	wsh.muxnetwork = n
-
 	return wsh
 }
 
 type websocketHandelr struct {
-	next http.Handler
-
 	muxnetwork *node
 
 	upgrader *websocket.Upgrader
 }
 
 func (wsh websocketHandelr) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	remoteAddrStr := req.Header.Get("X-Forwarded-For")
-	if remoteAddrStr == "" {
-		remoteAddrStr = req.RemoteAddr
-	}
+	// behind a reverse proxy, TrustedProxies.ResolveRemoteAddr needs to be applied before this handler
+	remoteAddrStr := req.RemoteAddr
 
 	remoteAddr, err := net.ResolveTCPAddr("tcp", remoteAddrStr)
 	if err != nil {
-		wsh.next.ServeHTTP(w, req)
+		http.Error(w, "unknown remote address", http.StatusBadRequest)
 		return
 	}
 
+	if cl := wsh.muxnetwork.opts.ConnLimiter; cl != nil {
+		release, err := cl.Acquire(remoteAddr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer release()
+	}
+
 	wsConn, err := wsh.upgrader.Upgrade(w, req, nil)
 	if err != nil {
 		return
@@ -78,14 +183,22 @@ func (wsh websocketHandelr) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	errLog := level.Error(wsh.muxnetwork.log)
 	errLog = kitlog.With(errLog, "remote", remoteAddrStr)
 
-	var wc net.Conn
-	wc = NewWebsockConn(wsConn)
+	wsOpts := wsh.muxnetwork.opts.Websocket
+	if wsOpts.MaxMessageSize > 0 {
+		wsConn.SetReadLimit(wsOpts.MaxMessageSize)
+	}
+
+	websockConn := NewWebsockConn(wsConn)
+	websockConn.remote = remoteAddr // the client, not the reverse proxy
+	websockConn.KeepAlive(wsOpts.PingInterval, wsOpts.IdleTimeout)
+
+	var wc net.Conn = websockConn
 
 	cw := wsh.muxnetwork.secretServer.ConnWrapper()
 	wc, err = cw(wc)
 	if err != nil {
 		errLog.Log("warning", "failed to authenticate", "err", err, "remote", remoteAddr)
-		wsConn.Close()
+		websockConn.Close()
 		return
 	}
 
@@ -105,13 +218,22 @@ func (wsh websocketHandelr) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	// 	return
 	// }
 
+	remoteRef, err := GetFeedRefFromAddr(wc.RemoteAddr())
+	if err != nil {
+		errLog.Log("warning", "failed to get feed after auth", "err", err, "remote", remoteAddr)
+		websockConn.Close()
+		return
+	}
+	started := time.Now()
+	defer wsh.muxnetwork.sessionEnded(remoteRef, started, true)
+
 	pkr := muxrpc.NewPacker(wc)
 
 	h, err := wsh.muxnetwork.opts.MakeHandler(wc)
 	if err != nil {
 		err = fmt.Errorf("websocket make handler failed: %w", err)
 		errLog.Log("warn", err)
-		wsConn.Close()
+		websockConn.Close()
 		return
 	}
 
@@ -123,18 +245,60 @@ func (wsh websocketHandelr) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	if err := srv.Serve(); err != nil {
 		errLog.Log("conn", "serve exited", "err", err, "peer", remoteAddr)
 	}
-	wsConn.Close()
+	websockConn.Close()
 }
 
 // WebsockConn emulates a normal net.Conn from a websocket connection
 type WebsockConn struct {
 	r   io.Reader
 	wsc *websocket.Conn
+
+	remote net.Addr
+
+	idleTimeout time.Duration
+	closeOnce   sync.Once
+	closed      chan struct{}
 }
 
 func NewWebsockConn(wsc *websocket.Conn) *WebsockConn {
 	return &WebsockConn{
-		wsc: wsc,
+		wsc:    wsc,
+		closed: make(chan struct{}),
+	}
+}
+
+// KeepAlive pings the other side every interval and closes the connection once nothing, not even the answer to a ping, arrived for timeout.
+// It needs to be called before the connection is used. Zero or negative values disable the pings or the timeout.
+// With a timeout, SetReadDeadline is overwritten by every read.
+func (conn *WebsockConn) KeepAlive(interval, timeout time.Duration) {
+	if timeout > 0 {
+		conn.idleTimeout = timeout
+		conn.wsc.SetReadDeadline(time.Now().Add(timeout))
+		conn.wsc.SetPongHandler(func(string) error {
+			return conn.wsc.SetReadDeadline(time.Now().Add(timeout))
+		})
+	}
+
+	if interval > 0 {
+		go conn.pingLoop(interval)
+	}
+}
+
+func (conn *WebsockConn) pingLoop(interval time.Duration) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-conn.closed:
+			return
+		case <-tick.C:
+		}
+
+		// WriteControl can be used next to the writer of Write
+		err := conn.wsc.WriteControl(websocket.PingMessage, nil, time.Now().Add(interval))
+		if err != nil {
+			return
+		}
 	}
 }
 
@@ -147,6 +311,9 @@ func (conn *WebsockConn) Read(data []byte) (int, error) {
 	}
 
 	n, err := conn.r.Read(data)
+	if err == nil && conn.idleTimeout > 0 {
+		conn.wsc.SetReadDeadline(time.Now().Add(conn.idleTimeout))
+	}
 	if err == io.EOF {
 		if err := conn.renewReader(); err != nil {
 			return -1, err
@@ -197,11 +364,18 @@ func (conn *WebsockConn) Write(data []byte) (int, error) {
 }
 
 func (conn *WebsockConn) Close() error {
+	conn.closeOnce.Do(func() { close(conn.closed) })
 	return conn.wsc.Close()
 }
 
-func (conn *WebsockConn) LocalAddr() net.Addr  { return conn.wsc.LocalAddr() }
-func (conn *WebsockConn) RemoteAddr() net.Addr { return conn.wsc.RemoteAddr() }
+func (conn *WebsockConn) LocalAddr() net.Addr { return conn.wsc.LocalAddr() }
+
+func (conn *WebsockConn) RemoteAddr() net.Addr {
+	if conn.remote != nil {
+		return conn.remote
+	}
+	return conn.wsc.RemoteAddr()
+}
 
 func (conn *WebsockConn) SetDeadline(t time.Time) error {
 	rErr := conn.wsc.SetReadDeadline(t)
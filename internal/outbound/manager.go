@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+// Package outbound keeps the room connected to a list of other peers, like pubs, sibling rooms or monitoring bots.
+// Each peer is dialed on its own and redialed with an exponential backoff whenever the connection drops.
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ssbc/go-muxrpc/v2"
+	kitlog "go.mindeco.de/log"
+	"go.mindeco.de/log/level"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+)
+
+// Connector is the part of network.Network the manager uses to dial peers and check on their connections.
+type Connector interface {
+	Connect(ctx context.Context, addr net.Addr) error
+	GetEndpointFor(refs.FeedRef) (muxrpc.Endpoint, bool)
+}
+
+const (
+	defaultMinBackoff    = 5 * time.Second
+	defaultMaxBackoff    = 5 * time.Minute
+	defaultCheckInterval = 30 * time.Second
+)
+
+// Status is a snapshot of the connection to one of the peers.
+type Status struct {
+	// ID is the ID of the roomdb entry. It is zero for peers that were passed on the command line.
+	ID int64
+
+	Address string
+	Comment string
+	Feed    refs.FeedRef
+
+	Connected      bool
+	ConnectedSince time.Time
+
+	LastAttempt time.Time
+	LastError   string
+	NextAttempt time.Time
+
+	// Failures counts the failed dial attempts since the peer was last seen connected.
+	Failures int
+}
+
+// Static returns true if the peer is not stored in the database and can't be removed at runtime.
+func (s Status) Static() bool { return s.ID == 0 }
+
+type peer struct {
+	// the address is parsed again before every dial, so that changed DNS records are picked up
+	address string
+
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	status Status
+}
+
+func (p *peer) getStatus() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status
+}
+
+// Manager keeps the outgoing connections to the configured peers open.
+type Manager struct {
+	logger  kitlog.Logger
+	rootCtx context.Context
+
+	net Connector
+	db  roomdb.PeersService
+
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	checkInterval time.Duration
+
+	mu      sync.Mutex
+	static  []*peer
+	dynamic map[int64]*peer
+}
+
+// NewManager creates a manager that dials peers through c until ctx is canceled.
+// db can be nil, in which case only peers added with AddStatic are dialed.
+func NewManager(ctx context.Context, logger kitlog.Logger, c Connector, db roomdb.PeersService) *Manager {
+	return &Manager{
+		logger:  kitlog.With(logger, "unit", "outbound"),
+		rootCtx: ctx,
+
+		net: c,
+		db:  db,
+
+		minBackoff:    defaultMinBackoff,
+		maxBackoff:    defaultMaxBackoff,
+		checkInterval: defaultCheckInterval,
+
+		dynamic: make(map[int64]*peer),
+	}
+}
+
+// AddStatic starts dialing a peer that isn't stored in the database, like the sibling rooms passed on the command line.
+func (m *Manager) AddStatic(msaddr, comment string) error {
+	feed, _, err := network.ParseMultiserverAddress(msaddr)
+	if err != nil {
+		return fmt.Errorf("outbound: invalid peer address: %w", err)
+	}
+
+	p := &peer{address: msaddr}
+	p.status = Status{Address: msaddr, Comment: comment, Feed: feed}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.static = append(m.static, p)
+	m.start(p)
+	return nil
+}
+
+// Reload reads the list of peers from the database.
+// It starts dialing new entries and stops (and disconnects) the ones that were removed.
+func (m *Manager) Reload(ctx context.Context) error {
+	if m.db == nil {
+		return nil
+	}
+
+	lst, err := m.db.List(ctx)
+	if err != nil {
+		return fmt.Errorf("outbound: failed to list peers: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := make(map[int64]struct{}, len(lst))
+	for _, entry := range lst {
+		current[entry.ID] = struct{}{}
+		if _, has := m.dynamic[entry.ID]; has {
+			continue
+		}
+
+		p := &peer{address: entry.Address}
+		p.status = Status{ID: entry.ID, Address: entry.Address, Comment: entry.Comment}
+		// errors are retried and reported by the dial loop
+		if feed, _, err := network.ParseMultiserverAddress(entry.Address); err == nil {
+			p.status.Feed = feed
+		}
+
+		m.dynamic[entry.ID] = p
+		m.start(p)
+	}
+
+	for id, p := range m.dynamic {
+		if _, has := current[id]; has {
+			continue
+		}
+		p.cancel()
+		delete(m.dynamic, id)
+	}
+
+	return nil
+}
+
+// Status returns the state of all peers. Static peers come first, followed by the database entries in the order they were added.
+func (m *Manager) Status() []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lst := make([]Status, 0, len(m.static)+len(m.dynamic))
+	for _, p := range m.static {
+		lst = append(lst, p.getStatus())
+	}
+
+	dynamic := make([]Status, 0, len(m.dynamic))
+	for _, p := range m.dynamic {
+		dynamic = append(dynamic, p.getStatus())
+	}
+	sort.Slice(dynamic, func(i, j int) bool { return dynamic[i].ID < dynamic[j].ID })
+
+	return append(lst, dynamic...)
+}
+
+// IsConfigured returns true if ref is one of the peers the room dials.
+func (m *Manager) IsConfigured(ref refs.FeedRef) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, p := range m.static {
+		if p.getStatus().Feed.Equal(ref) {
+			return true
+		}
+	}
+	for _, p := range m.dynamic {
+		if p.getStatus().Feed.Equal(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// start needs to be called with m.mu held
+func (m *Manager) start(p *peer) {
+	// the connection lives in this context, canceling it also closes the connection
+	ctx, cancel := context.WithCancel(m.rootCtx)
+	p.cancel = cancel
+	go m.keepConnected(ctx, p)
+}
+
+func (m *Manager) keepConnected(ctx context.Context, p *peer) {
+	logger := kitlog.With(m.logger, "addr", p.address)
+
+	backoff := m.minBackoff
+	for {
+		var wait time.Duration
+
+		if _, connected := m.net.GetEndpointFor(p.getStatus().Feed); connected {
+			p.mu.Lock()
+			if !p.status.Connected {
+				p.status.Connected = true
+				p.status.ConnectedSince = time.Now()
+			}
+			p.status.Failures = 0
+			p.status.LastError = ""
+			p.status.NextAttempt = time.Time{}
+			p.mu.Unlock()
+
+			backoff = m.minBackoff
+			wait = m.checkInterval
+		} else {
+			err := m.dial(ctx, p)
+
+			wait = backoff
+			backoff *= 2
+			if backoff > m.maxBackoff {
+				backoff = m.maxBackoff
+			}
+
+			now := time.Now()
+			p.mu.Lock()
+			p.status.Connected = false
+			p.status.ConnectedSince = time.Time{}
+			p.status.LastAttempt = now
+			p.status.NextAttempt = now.Add(wait)
+			if err != nil {
+				p.status.Failures++
+				p.status.LastError = err.Error()
+			}
+			p.mu.Unlock()
+
+			if err != nil {
+				level.Debug(logger).Log("event", "dial failed", "err", err, "retry-in", wait)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (m *Manager) dial(ctx context.Context, p *peer) error {
+	feed, addr, err := network.ParseMultiserverAddress(p.address)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.status.Feed = feed
+	p.mu.Unlock()
+
+	return m.net.Connect(ctx, addr)
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ssbc/go-muxrpc/v2"
+	"github.com/stretchr/testify/require"
+	"go.mindeco.de/log"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/mockdb"
+)
+
+// fakeConnector fails the first failDials calls to Connect and marks the peer as connected afterwards
+type fakeConnector struct {
+	mu        sync.Mutex
+	failDials int
+	dials     int
+	connected map[string]bool
+}
+
+func (fc *fakeConnector) Connect(ctx context.Context, addr net.Addr) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.dials++
+	if fc.dials <= fc.failDials {
+		return errors.New("connection refused")
+	}
+	ref, err := network.GetFeedRefFromAddr(addr)
+	if err != nil {
+		return err
+	}
+	fc.connected[ref.String()] = true
+	return nil
+}
+
+func (fc *fakeConnector) GetEndpointFor(ref refs.FeedRef) (muxrpc.Endpoint, bool) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return nil, fc.connected[ref.String()]
+}
+
+func (fc *fakeConnector) dialCount() int {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.dials
+}
+
+func testAddr(t *testing.T, b byte) (refs.FeedRef, string) {
+	feed, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{b}, 32), refs.RefAlgoFeedSSB1)
+	require.NoError(t, err)
+	return feed, fmt.Sprintf("net:127.0.0.1:%d~shs:%s", 8000+int(b), base64.StdEncoding.EncodeToString(feed.PubKey()))
+}
+
+func TestManagerBackoff(t *testing.T) {
+	r := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fc := &fakeConnector{failDials: 3, connected: make(map[string]bool)}
+
+	feed, addr := testAddr(t, 1)
+	db := new(mockdb.FakePeersService)
+	db.ListReturns([]roomdb.Peer{{ID: 1, Address: addr, Comment: "pub"}}, nil)
+
+	m := NewManager(ctx, log.NewNopLogger(), fc, db)
+	m.minBackoff = 10 * time.Millisecond
+	m.maxBackoff = 40 * time.Millisecond
+	m.checkInterval = 10 * time.Millisecond
+
+	r.NoError(m.Reload(ctx))
+	r.True(m.IsConfigured(feed))
+
+	r.Eventually(func() bool {
+		lst := m.Status()
+		return len(lst) == 1 && lst[0].Connected
+	}, time.Second, 5*time.Millisecond)
+
+	st := m.Status()[0]
+	r.EqualValues(1, st.ID)
+	r.Equal("pub", st.Comment)
+	r.Equal(0, st.Failures)
+	r.Equal("", st.LastError)
+	r.Equal(4, fc.dialCount(), "expected three failed and one successful dial")
+
+	// connected peers are not dialed again
+	time.Sleep(50 * time.Millisecond)
+	r.Equal(4, fc.dialCount())
+}
+
+func TestManagerReload(t *testing.T) {
+	r := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fc := &fakeConnector{failDials: 1000, connected: make(map[string]bool)}
+
+	staticFeed, staticAddr := testAddr(t, 1)
+	dbFeed, dbAddr := testAddr(t, 2)
+
+	db := new(mockdb.FakePeersService)
+	db.ListReturns([]roomdb.Peer{
+		{ID: 5, Address: dbAddr},
+		{ID: 3, Address: "not an address"},
+	}, nil)
+
+	m := NewManager(ctx, log.NewNopLogger(), fc, db)
+	m.minBackoff = time.Hour // only dial once
+
+	r.NoError(m.AddStatic(staticAddr, "sibling"))
+	r.Error(m.AddStatic("onion:nope.onion:8008", ""))
+	r.NoError(m.Reload(ctx))
+
+	lst := m.Status()
+	r.Len(lst, 3)
+	r.True(lst[0].Static())
+	r.EqualValues(3, lst[1].ID)
+	r.EqualValues(5, lst[2].ID)
+
+	// invalid addresses show up with their error
+	r.Eventually(func() bool {
+		return m.Status()[1].LastError != ""
+	}, time.Second, 5*time.Millisecond)
+
+	r.True(m.IsConfigured(staticFeed))
+	r.True(m.IsConfigured(dbFeed))
+
+	// the entry was removed from the database
+	db.ListReturns([]roomdb.Peer{{ID: 3, Address: "not an address"}}, nil)
+	r.NoError(m.Reload(ctx))
+
+	lst = m.Status()
+	r.Len(lst, 2)
+	r.False(m.IsConfigured(dbFeed))
+	r.True(m.IsConfigured(staticFeed))
+
+	// only the static peer and the removed one got to a dial
+	r.Eventually(func() bool { return fc.dialCount() == 2 }, time.Second, 5*time.Millisecond)
+}
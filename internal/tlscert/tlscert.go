@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+// Package tlscert provides the certificates when the room serves HTTPS itself, instead of behind a reverse proxy.
+// The certificate is either loaded from files or obtained from an ACME certificate authority, like Let's Encrypt.
+//
+// With ACME, the certificate for the domain of the room is managed by autocert, using the HTTP-01 (or TLS-ALPN-01) challenge.
+// A wildcard certificate for the alias subdomains can only be validated through DNS, so it is obtained with the DNS-01 challenge
+// and an external hook program that creates and removes the TXT records.
+package tlscert
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	kitlog "go.mindeco.de/log"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// LetsEncryptURL is the directory URL of the Let's Encrypt production CA
+const LetsEncryptURL = autocert.DefaultACMEDirectory
+
+// Options configure a Manager. Either CertFile and KeyFile or ACME need to be set.
+type Options struct {
+	// Domain is the domain of the room
+	Domain string
+
+	// Wildcard requests a certificate for *.Domain as well, for the alias subdomains.
+	// With ACME it needs a DNSHook.
+	Wildcard bool
+
+	// CertFile and KeyFile are the PEM encoded certificate chain and private key, like the ones certbot creates.
+	// The certificate needs to include the wildcard name itself if the alias subdomains are used.
+	CertFile, KeyFile string
+
+	// ACME enables obtaining and renewing the certificates from DirectoryURL.
+	ACME bool
+
+	// DirectoryURL is the ACME directory of the certificate authority. It defaults to LetsEncryptURL.
+	DirectoryURL string
+
+	// Email is passed to the CA as the contact for expiry notices and problems with the account
+	Email string
+
+	// CacheDir is where the account keys and the certificates are stored between restarts
+	CacheDir string
+
+	// DNSHook is the program that is called to create and remove the TXT records for the DNS-01 challenge.
+	// It is called as "hook present <record name> <value>" and "hook cleanup <record name> <value>".
+	DNSHook string
+
+	// HTTPClient is used to talk to the CA. It can be set to trust the root of a test CA, like pebble.
+	HTTPClient *http.Client
+}
+
+// Manager returns the certificates for the TLS listener
+type Manager struct {
+	logger kitlog.Logger
+	domain string
+
+	static *tls.Certificate
+
+	autocert *autocert.Manager
+	wildcard *wildcardIssuer
+
+	mu           sync.Mutex
+	wildcardCert *tls.Certificate
+}
+
+// New checks the options and loads the static certificate or the cached ACME ones.
+func New(logger kitlog.Logger, opts Options) (*Manager, error) {
+	if opts.Domain == "" {
+		return nil, fmt.Errorf("tlscert: domain can't be empty")
+	}
+
+	m := &Manager{
+		logger: logger,
+		domain: opts.Domain,
+	}
+
+	haveFiles := opts.CertFile != "" || opts.KeyFile != ""
+	switch {
+	case haveFiles && opts.ACME:
+		return nil, fmt.Errorf("tlscert: use either a certificate file or ACME, not both")
+
+	case haveFiles:
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tlscert: failed to load certificate: %w", err)
+		}
+		m.static = &cert
+		return m, nil
+
+	case !opts.ACME:
+		return nil, fmt.Errorf("tlscert: neither a certificate file nor ACME configured")
+	}
+
+	if opts.CacheDir == "" {
+		return nil, fmt.Errorf("tlscert: ACME needs a cache directory")
+	}
+	if opts.DirectoryURL == "" {
+		opts.DirectoryURL = LetsEncryptURL
+	}
+
+	cache := autocert.DirCache(opts.CacheDir)
+
+	m.autocert = &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      cache,
+		HostPolicy: autocert.HostWhitelist(opts.Domain),
+		Email:      opts.Email,
+		Client: &acme.Client{
+			DirectoryURL: opts.DirectoryURL,
+			HTTPClient:   opts.HTTPClient,
+		},
+	}
+
+	if opts.Wildcard {
+		if opts.DNSHook == "" {
+			return nil, fmt.Errorf("tlscert: the wildcard certificate for the alias subdomains needs a DNS hook")
+		}
+		m.wildcard = &wildcardIssuer{
+			logger:       kitlog.With(logger, "cert", "*."+opts.Domain),
+			domain:       opts.Domain,
+			directoryURL: opts.DirectoryURL,
+			email:        opts.Email,
+			hook:         opts.DNSHook,
+			httpClient:   opts.HTTPClient,
+			cache:        cache,
+		}
+
+		cert, err := m.wildcard.load(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		m.wildcardCert = cert
+	}
+
+	return m, nil
+}
+
+// TLSConfig returns the configuration for the HTTPS listener
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: m.getCertificate,
+		NextProtos:     []string{"h2", "http/1.1", acme.ALPNProto},
+		MinVersion:     tls.VersionTLS12,
+	}
+}
+
+// HTTPHandler answers the HTTP-01 challenges of the CA and passes all other requests to fallback.
+// It needs to be served on port 80 of the domain.
+func (m *Manager) HTTPHandler(fallback http.Handler) http.Handler {
+	if m.autocert == nil {
+		return fallback
+	}
+	return m.autocert.HTTPHandler(fallback)
+}
+
+// Run keeps the wildcard certificate renewed until ctx is canceled.
+// The certificate of the main domain is renewed by autocert itself.
+func (m *Manager) Run(ctx context.Context) error {
+	if m.wildcard == nil {
+		return nil
+	}
+	return m.wildcard.run(ctx, m.setWildcard)
+}
+
+func (m *Manager) setWildcard(cert *tls.Certificate) {
+	m.mu.Lock()
+	m.wildcardCert = cert
+	m.mu.Unlock()
+}
+
+func (m *Manager) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if m.static != nil {
+		return m.static, nil
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(hello.ServerName), ".")
+	if m.wildcard != nil && strings.HasSuffix(name, "."+m.domain) {
+		m.mu.Lock()
+		cert := m.wildcardCert
+		m.mu.Unlock()
+		if cert == nil {
+			return nil, fmt.Errorf("tlscert: no certificate for %s yet", name)
+		}
+		return cert, nil
+	}
+
+	if name == "" { // clients that don't send SNI get the main domain
+		hello.ServerName = m.domain
+	}
+	return m.autocert.GetCertificate(hello)
+}
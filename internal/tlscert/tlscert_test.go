@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package tlscert
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mindeco.de/log"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// selfSigned returns the PEM encoded key and certificate for the names
+func selfSigned(t *testing.T, names ...string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: names[0]},
+		DNSNames:     names,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	pem.Encode(&buf, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return buf.Bytes()
+}
+
+func TestStaticCertificate(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "fullchain.pem")
+	r.NoError(os.WriteFile(certFile, selfSigned(t, "room.test", "*.room.test"), 0600))
+
+	_, err := New(log.NewNopLogger(), Options{Domain: "room.test"})
+	r.Error(err, "neither files nor ACME")
+
+	_, err = New(log.NewNopLogger(), Options{Domain: "room.test", CertFile: certFile, KeyFile: certFile, ACME: true})
+	r.Error(err, "files and ACME")
+
+	m, err := New(log.NewNopLogger(), Options{Domain: "room.test", CertFile: certFile, KeyFile: certFile})
+	r.NoError(err)
+
+	for _, name := range []string{"room.test", "alice.room.test", ""} {
+		cert, err := m.TLSConfig().GetCertificate(&tls.ClientHelloInfo{ServerName: name})
+		r.NoError(err)
+		r.Equal(m.static, cert)
+	}
+
+	// the ACME challenges are not answered
+	r.Nil(m.autocert)
+}
+
+func TestWildcardFromCache(t *testing.T) {
+	r := require.New(t)
+
+	dir := t.TempDir()
+
+	_, err := New(log.NewNopLogger(), Options{Domain: "room.test", ACME: true, CacheDir: dir, Wildcard: true})
+	r.Error(err, "wildcard without a DNS hook")
+
+	// nothing cached yet
+	m, err := New(log.NewNopLogger(), Options{Domain: "room.test", ACME: true, CacheDir: dir, Wildcard: true, DNSHook: "/bin/true"})
+	r.NoError(err)
+
+	_, err = m.getCertificate(&tls.ClientHelloInfo{ServerName: "alice.room.test"})
+	r.Error(err)
+
+	r.NoError(autocert.DirCache(dir).Put(context.Background(), "room.test+wildcard", selfSigned(t, "*.room.test")))
+
+	m, err = New(log.NewNopLogger(), Options{Domain: "room.test", ACME: true, CacheDir: dir, Wildcard: true, DNSHook: "/bin/true"})
+	r.NoError(err)
+	r.NotNil(m.wildcardCert)
+
+	cert, err := m.getCertificate(&tls.ClientHelloInfo{ServerName: "Alice.Room.Test."})
+	r.NoError(err)
+	r.Equal([]string{"*.room.test"}, cert.Leaf.DNSNames)
+
+	// other domains are not handed to the CA
+	_, err = m.getCertificate(&tls.ClientHelloInfo{ServerName: "other.test"})
+	r.Error(err)
+}
@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package tlscert
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	kitlog "go.mindeco.de/log"
+	"go.mindeco.de/log/level"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	// renewBefore is how long before it expires the wildcard certificate is renewed
+	renewBefore = 30 * 24 * time.Hour
+
+	checkInterval = 12 * time.Hour
+	retryInterval = time.Hour
+
+	// the DNS hook might have to wait for the record to propagate
+	hookTimeout = 10 * time.Minute
+)
+
+// wildcardIssuer obtains the certificate for *.domain with the DNS-01 challenge.
+// The account key and the certificate are stored in the same cache as the ones of autocert, under their own names.
+type wildcardIssuer struct {
+	logger kitlog.Logger
+
+	domain       string
+	directoryURL string
+	email        string
+	hook         string
+	httpClient   *http.Client
+
+	cache autocert.Cache
+}
+
+func (w *wildcardIssuer) certKey() string    { return w.domain + "+wildcard" }
+func (w *wildcardIssuer) accountKey() string { return "acme_dns01_account+key" }
+
+// load returns the cached certificate, or nil if there is none yet
+func (w *wildcardIssuer) load(ctx context.Context) (*tls.Certificate, error) {
+	data, err := w.cache.Get(ctx, w.certKey())
+	if errors.Is(err, autocert.ErrCacheMiss) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tlscert: failed to read cached wildcard certificate: %w", err)
+	}
+
+	cert, err := tls.X509KeyPair(data, data)
+	if err != nil {
+		return nil, fmt.Errorf("tlscert: invalid cached wildcard certificate: %w", err)
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("tlscert: invalid cached wildcard certificate: %w", err)
+	}
+	return &cert, nil
+}
+
+// run checks the certificate every checkInterval and renews it if it is missing or expires soon
+func (w *wildcardIssuer) run(ctx context.Context, set func(*tls.Certificate)) error {
+	for {
+		wait := checkInterval
+
+		cert, err := w.load(ctx)
+		if err != nil {
+			level.Warn(w.logger).Log("event", "loading wildcard certificate failed", "err", err)
+		}
+		if cert == nil || time.Until(cert.Leaf.NotAfter) < renewBefore {
+			cert, err = w.obtain(ctx)
+			if err != nil {
+				level.Error(w.logger).Log("event", "obtaining wildcard certificate failed", "err", err)
+				wait = retryInterval
+			} else {
+				level.Info(w.logger).Log("event", "obtained wildcard certificate", "expires", cert.Leaf.NotAfter)
+				set(cert)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// obtain goes through the ACME order for *.domain and stores the new certificate in the cache
+func (w *wildcardIssuer) obtain(ctx context.Context) (*tls.Certificate, error) {
+	accountKey, err := w.loadAccountKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &acme.Client{
+		Key:          accountKey,
+		DirectoryURL: w.directoryURL,
+		HTTPClient:   w.httpClient,
+	}
+
+	var account acme.Account
+	if w.email != "" {
+		account.Contact = []string{"mailto:" + w.email}
+	}
+	_, err = client.Register(ctx, &account, acme.AcceptTOS)
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return nil, fmt.Errorf("tlscert: failed to register account: %w", err)
+	}
+
+	name := "*." + w.domain
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(name))
+	if err != nil {
+		return nil, fmt.Errorf("tlscert: failed to create order: %w", err)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		if err := w.authorize(ctx, client, authzURL); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return nil, fmt.Errorf("tlscert: order failed: %w", err)
+	}
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{name}}, certKey)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("tlscert: failed to finalize order: %w", err)
+	}
+
+	// store the key and the chain in one PEM file, like autocert does
+	var buf bytes.Buffer
+	keyDER, err := x509.MarshalECPrivateKey(certKey)
+	if err != nil {
+		return nil, err
+	}
+	pem.Encode(&buf, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	for _, der := range chain {
+		pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	if err := w.cache.Put(ctx, w.certKey(), buf.Bytes()); err != nil {
+		return nil, fmt.Errorf("tlscert: failed to store wildcard certificate: %w", err)
+	}
+
+	return w.load(ctx)
+}
+
+// authorize answers the dns-01 challenge of one authorization, using the hook to publish the TXT record
+func (w *wildcardIssuer) authorize(ctx context.Context, client *acme.Client, authzURL string) error {
+	authz, err := client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("tlscert: failed to get authorization: %w", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("tlscert: the CA offered no dns-01 challenge for %s", authz.Identifier.Value)
+	}
+
+	value, err := client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		return err
+	}
+	// the identifier of a wildcard authorization is the domain without the *.
+	record := "_acme-challenge." + authz.Identifier.Value
+
+	if err := w.runHook(ctx, "present", record, value); err != nil {
+		return err
+	}
+	defer func() {
+		if err := w.runHook(context.Background(), "cleanup", record, value); err != nil {
+			level.Warn(w.logger).Log("event", "dns hook cleanup failed", "err", err)
+		}
+	}()
+
+	if _, err := client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("tlscert: failed to accept challenge: %w", err)
+	}
+	if _, err := client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("tlscert: authorization of %s failed: %w", authz.Identifier.Value, err)
+	}
+	return nil
+}
+
+// runHook calls the DNS hook with the action, the name of the TXT record and its value
+func (w *wildcardIssuer) runHook(ctx context.Context, action, record, value string) error {
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, w.hook, action, record, value).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("tlscert: dns hook %s failed: %w (output: %s)", action, err, strings.TrimSpace(string(out)))
+	}
+	level.Debug(w.logger).Log("event", "dns hook", "action", action, "record", record)
+	return nil
+}
+
+// loadAccountKey reads the key of the ACME account from the cache or creates a new one
+func (w *wildcardIssuer) loadAccountKey(ctx context.Context) (crypto.Signer, error) {
+	data, err := w.cache.Get(ctx, w.accountKey())
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("tlscert: invalid cached account key")
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	if !errors.Is(err, autocert.ErrCacheMiss) {
+		return nil, fmt.Errorf("tlscert: failed to read cached account key: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	data = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	if err := w.cache.Put(ctx, w.accountKey(), data); err != nil {
+		return nil, fmt.Errorf("tlscert: failed to store account key: %w", err)
+	}
+	return key, nil
+}
@@ -9,8 +9,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ssbc/go-muxrpc/v2"
+	"go.mindeco.de/log/level"
+
 	refs "github.com/ssbc/go-ssb-refs"
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
@@ -29,6 +32,37 @@ type AttendantsInitialState struct {
 	IDs  []refs.FeedRef `json:"ids"`
 }
 
+// AttendantsArg are the options a client can pass to room.attendants
+type AttendantsArg struct {
+	// Extended adds the AttendantDetails to the state and joined messages
+	Extended bool `json:"extended"`
+}
+
+// AttendantDetails describe an attendant in the extended mode of room.attendants
+type AttendantDetails struct {
+	ID     refs.FeedRef `json:"id"`
+	Member bool         `json:"member"`
+
+	// Aliases holds the URLs of the aliases the attendant registered
+	Aliases []string `json:"aliases"`
+
+	// Joined is when the attendant joined, in milliseconds since the epoch
+	Joined int64 `json:"joined,omitempty"`
+}
+
+// AttendantsExtendedState is the first message of an extended room.attendants stream
+type AttendantsExtendedState struct {
+	Type       string             `json:"type"`
+	IDs        []refs.FeedRef     `json:"ids"`
+	Attendants []AttendantDetails `json:"attendants"`
+}
+
+// AttendantsExtendedJoined is emitted in the extended mode if a member joins. Left events are the same as in the normal mode.
+type AttendantsExtendedJoined struct {
+	Type string `json:"type"`
+	AttendantDetails
+}
+
 func (h *Handler) attendants(ctx context.Context, req *muxrpc.Request, snk *muxrpc.ByteSink) error {
 
 	// get public key from the calling peer
@@ -49,14 +83,27 @@ func (h *Handler) attendants(ctx context.Context, req *muxrpc.Request, snk *muxr
 		}
 	}
 
+	// legacy clients don't pass any arguments
+	var args []AttendantsArg
+	if len(req.RawArgs) > 0 {
+		if err := json.Unmarshal(req.RawArgs, &args); err != nil {
+			return fmt.Errorf("attendants: invalid arguments: %w", err)
+		}
+	}
+	extended := len(args) > 0 && args[0].Extended
+
 	// add peer to the state
 	h.state.AddEndpoint(peer, req.Endpoint())
 
+	if extended {
+		return h.extendedAttendants(snk)
+	}
+
 	// send the current state
 	snk.SetEncoding(muxrpc.TypeJSON)
 	err = json.NewEncoder(snk).Encode(AttendantsInitialState{
 		Type: "state",
-		IDs:  h.state.ListAsRefs(),
+		IDs:  h.state.ListAllAsRefs(),
 	})
 	if err != nil {
 		return err
@@ -69,11 +116,53 @@ func (h *Handler) attendants(ctx context.Context, req *muxrpc.Request, snk *muxr
 	return nil
 }
 
+func (h *Handler) extendedAttendants(snk *muxrpc.ByteSink) error {
+	ids := h.state.ListAllAsRefs()
+	state := AttendantsExtendedState{
+		Type:       "state",
+		IDs:        ids,
+		Attendants: make([]AttendantDetails, len(ids)),
+	}
+	for i, id := range ids {
+		state.Attendants[i] = h.attendantDetails(id)
+	}
+
+	toPeer := newAttendantsEncoder(snk)
+	toPeer.details = h.attendantDetails
+
+	toPeer.mu.Lock()
+	err := toPeer.enc.Encode(state)
+	toPeer.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	h.state.RegisterAttendantsUpdates(toPeer)
+	return nil
+}
+
+// attendantDetails uses the cached member and alias information and adds the join time.
+// If the lookup fails, only the ID and the join time are filled in.
+func (h *Handler) attendantDetails(who refs.FeedRef) AttendantDetails {
+	d, err := h.attendantsInfo.details(who)
+	if err != nil {
+		level.Warn(h.logger).Log("event", "attendant details lookup failed", "peer", who.ShortSigil(), "err", err)
+		d = AttendantDetails{ID: who, Aliases: []string{}}
+	}
+	if joined, has := h.state.JoinedAt(who); has {
+		d.Joined = joined.UnixNano() / int64(time.Millisecond)
+	}
+	return d
+}
+
 // a muxrpc json encoder for endpoints broadcasts
 type attendantsJSONEncoder struct {
 	mu  sync.Mutex // only one caller to forwarder at a time
 	snk *muxrpc.ByteSink
 	enc *json.Encoder
+
+	// if set, joined events carry the details of the attendant (extended mode)
+	details func(refs.FeedRef) AttendantDetails
 }
 
 func newAttendantsEncoder(snk *muxrpc.ByteSink) *attendantsJSONEncoder {
@@ -86,6 +175,17 @@ func newAttendantsEncoder(snk *muxrpc.ByteSink) *attendantsJSONEncoder {
 }
 
 func (uf *attendantsJSONEncoder) Joined(member refs.FeedRef) error {
+	if uf.details != nil {
+		d := uf.details(member)
+
+		uf.mu.Lock()
+		defer uf.mu.Unlock()
+		return uf.enc.Encode(AttendantsExtendedJoined{
+			Type:             "joined",
+			AttendantDetails: d,
+		})
+	}
+
 	uf.mu.Lock()
 	defer uf.mu.Unlock()
 	return uf.enc.Encode(AttendantsUpdate{
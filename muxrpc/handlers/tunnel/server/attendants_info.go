@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+)
+
+const (
+	// attendantsInfoTTL is how long the details of an attendant are cached
+	attendantsInfoTTL = time.Minute
+
+	// lookups are shared between the subscribers, so they don't use the context of one of them
+	attendantsInfoTimeout = 10 * time.Second
+)
+
+// attendantsInfoCache looks up the details for the extended room.attendants streams.
+// All subscribers get the joined events at about the same time, so only the first lookup for a feed goes to the database.
+// The others wait for it and use the same result.
+type attendantsInfoCache struct {
+	members roomdb.MembersService
+	aliases roomdb.AliasesService
+	urlFor  func(alias string) string
+
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*infoCacheEntry
+}
+
+type infoCacheEntry struct {
+	done    chan struct{} // closed once val and err are set
+	expires time.Time
+
+	val interface{}
+	err error
+}
+
+func newAttendantsInfoCache(members roomdb.MembersService, aliases roomdb.AliasesService, urlFor func(string) string) *attendantsInfoCache {
+	return &attendantsInfoCache{
+		members: members,
+		aliases: aliases,
+		urlFor:  urlFor,
+
+		ttl: attendantsInfoTTL,
+
+		entries: make(map[string]*infoCacheEntry),
+	}
+}
+
+// get returns the cached value for key or calls load, once, if there is none
+func (c *attendantsInfoCache) get(key string, load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	e, has := c.entries[key]
+	if has {
+		select {
+		case <-e.done:
+			// failed lookups are retried right away
+			if e.err != nil || time.Now().After(e.expires) {
+				has = false
+			}
+		default: // still loading
+		}
+	}
+
+	if has {
+		c.mu.Unlock()
+		<-e.done
+		return e.val, e.err
+	}
+
+	e = &infoCacheEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.pruneLocked()
+	c.mu.Unlock()
+
+	e.val, e.err = load()
+	e.expires = time.Now().Add(c.ttl)
+	close(e.done)
+
+	return e.val, e.err
+}
+
+// pruneLocked drops expired entries once there are a lot of them. c.mu needs to be held.
+func (c *attendantsInfoCache) pruneLocked() {
+	if len(c.entries) < 1024 {
+		return
+	}
+	now := time.Now()
+	for k, e := range c.entries {
+		select {
+		case <-e.done:
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		default:
+		}
+	}
+}
+
+// aliasURLs returns the alias URLs of all the feeds, from one listing of the aliases table
+func (c *attendantsInfoCache) aliasURLs() (map[string][]string, error) {
+	v, err := c.get("aliases", func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), attendantsInfoTimeout)
+		defer cancel()
+
+		lst, err := c.aliases.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		byFeed := make(map[string][]string)
+		for _, a := range lst {
+			key := a.Feed.String()
+			byFeed[key] = append(byFeed[key], c.urlFor(a.Name))
+		}
+		return byFeed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(map[string][]string), nil
+}
+
+func (c *attendantsInfoCache) isMember(who refs.FeedRef) (bool, error) {
+	v, err := c.get("member:"+who.String(), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), attendantsInfoTimeout)
+		defer cancel()
+
+		_, err := c.members.GetByFeed(ctx, who)
+		if err != nil {
+			if errors.Is(err, roomdb.ErrNotFound) {
+				return false, nil
+			}
+			return nil, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// details returns everything the extended mode sends about an attendant, except the join time
+func (c *attendantsInfoCache) details(who refs.FeedRef) (AttendantDetails, error) {
+	d := AttendantDetails{
+		ID:      who,
+		Aliases: []string{},
+	}
+
+	var err error
+	d.Member, err = c.isMember(who)
+	if err != nil {
+		return d, err
+	}
+
+	urls, err := c.aliasURLs()
+	if err != nil {
+		return d, err
+	}
+	if lst, has := urls[who.String()]; has {
+		d.Aliases = lst
+	}
+
+	return d, nil
+}
@@ -46,7 +46,7 @@ func (h connectHandler) HandleConnect(ctx context.Context, edp muxrpc.Endpoint)
 		return
 	}
 
-	h.state.Remove(peer)
+	h.state.RemoveEndpoint(peer, edp)
 }
 
 // HandleDuplex here implements the tunnel.connect behavior of the server-side. It receives incoming events
@@ -72,6 +72,7 @@ func (h connectHandler) HandleDuplex(ctx context.Context, req *muxrpc.Request, p
 	if err != nil {
 		return err
 	}
+	h.state.MarkActive(caller, req.Endpoint())
 
 	// make sure they dont want to connect to themselves
 	if caller.Equal(arg.Target) {
@@ -81,7 +82,7 @@ func (h connectHandler) HandleDuplex(ctx context.Context, req *muxrpc.Request, p
 	// see if we have and endpoint for the target
 	edp, has := h.state.Has(arg.Target)
 	if !has {
-		return fmt.Errorf("could not connect to:%s", arg.Target.String())
+		return h.connectViaSibling(ctx, caller, arg, peerSrc, peerSnk)
 	}
 
 	// call connect on them
@@ -91,6 +92,8 @@ func (h connectHandler) HandleDuplex(ctx context.Context, req *muxrpc.Request, p
 
 	targetSrc, targetSnk, err := edp.Duplex(ctx, muxrpc.TypeBinary, muxrpc.Method{"tunnel", "connect"}, argWorigin)
 	if err != nil {
+		// try one of the other connections of the target next time
+		h.state.MarkFailed(arg.Target, edp)
 		return fmt.Errorf("could not connect to:%s", arg.Target.String())
 	}
 
@@ -105,6 +108,33 @@ func (h connectHandler) HandleDuplex(ctx context.Context, req *muxrpc.Request, p
 	return nil
 }
 
+// connectViaSibling chains the call through the federated sibling room the target is attending
+func (h connectHandler) connectViaSibling(ctx context.Context, caller refs.FeedRef, arg ConnectArg, peerSrc *muxrpc.ByteSource, peerSnk *muxrpc.ByteSink) error {
+	sibling, siblingEdp, has := h.state.HasRemote(arg.Target)
+	if !has {
+		return fmt.Errorf("could not connect to:%s", arg.Target.String())
+	}
+
+	var argWorigin connectWithOriginArg
+	argWorigin.Portal = sibling
+	argWorigin.Target = arg.Target
+	argWorigin.Origin = caller
+
+	targetSrc, targetSnk, err := siblingEdp.Duplex(ctx, muxrpc.TypeBinary, muxrpc.Method{"federation", "connect"}, argWorigin)
+	if err != nil {
+		return fmt.Errorf("could not connect to:%s", arg.Target.String())
+	}
+
+	var cpy muxrpcDuplexCopy
+	cpy.logger = kitlog.With(h.logger, "caller", caller.ShortSigil(), "target", arg.Target.ShortSigil(), "sibling", sibling.ShortSigil())
+	cpy.ctx, cpy.cancel = context.WithCancel(ctx)
+
+	go cpy.do(targetSnk, peerSrc)
+	go cpy.do(peerSnk, targetSrc)
+
+	return nil
+}
+
 type muxrpcDuplexCopy struct {
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ssbc/go-muxrpc/v2"
+	"github.com/ssbc/go-muxrpc/v2/typemux"
+	kitlog "go.mindeco.de/log"
+	"go.mindeco.de/log/level"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+)
+
+/* federation manifest, only callable by trusted sibling rooms:
+{
+	"attendants": "source",
+	"connect": "duplex"
+}
+
+Sibling rooms only ever share their own attendants and only relay tunnel.connect calls to them.
+This way attendant lists and tunnels never travel more than one hop and can't loop between rooms.
+*/
+
+// federationPeers is the set of trusted sibling rooms
+type federationPeers struct {
+	mu  sync.Mutex
+	set map[string]struct{}
+}
+
+// TrustFederationPeers adds sibling rooms that may follow our attendants and relay tunnels to them.
+func (h *Handler) TrustFederationPeers(peers ...refs.FeedRef) {
+	h.federation.mu.Lock()
+	defer h.federation.mu.Unlock()
+	if h.federation.set == nil {
+		h.federation.set = make(map[string]struct{})
+	}
+	for _, p := range peers {
+		h.federation.set[p.String()] = struct{}{}
+	}
+}
+
+// IsFederationPeer returns true if the passed feed belongs to a trusted sibling room
+func (h *Handler) IsFederationPeer(ref refs.FeedRef) bool {
+	h.federation.mu.Lock()
+	defer h.federation.mu.Unlock()
+	_, has := h.federation.set[ref.String()]
+	return has
+}
+
+func (h *Handler) RegisterFederation(mux typemux.HandlerMux) {
+	var namespace = muxrpc.Method{"federation"}
+	mux.RegisterSource(append(namespace, "attendants"), federationAttendants{h})
+	mux.RegisterDuplex(append(namespace, "connect"), federationConnect{h})
+}
+
+// federationAttendants serves our own attendants to sibling rooms and follows theirs once connected to them
+type federationAttendants struct{ h *Handler }
+
+func (fa federationAttendants) HandleSource(ctx context.Context, req *muxrpc.Request, snk *muxrpc.ByteSink) error {
+	peer, err := network.GetFeedRefFromAddr(req.RemoteAddr())
+	if err != nil {
+		return err
+	}
+
+	if !fa.h.IsFederationPeer(peer) {
+		return fmt.Errorf("federation: %s is not a trusted room", peer.ShortSigil())
+	}
+
+	// send the current state, only of the peers connected to us
+	snk.SetEncoding(muxrpc.TypeJSON)
+	err = json.NewEncoder(snk).Encode(AttendantsInitialState{
+		Type: "state",
+		IDs:  fa.h.state.ListAsRefs(),
+	})
+	if err != nil {
+		return err
+	}
+
+	fa.h.state.RegisterLocalAttendantsUpdates(newAttendantsEncoder(snk))
+	return nil
+}
+
+// siblingAttendantsEvent is either AttendantsInitialState or AttendantsUpdate
+type siblingAttendantsEvent struct {
+	Type string         `json:"type"`
+	ID   refs.FeedRef   `json:"id"`
+	IDs  []refs.FeedRef `json:"ids"`
+}
+
+// HandleConnect follows the attendants of a sibling room for as long as the connection to it lasts.
+func (fa federationAttendants) HandleConnect(ctx context.Context, edp muxrpc.Endpoint) {
+	sibling, err := network.GetFeedRefFromAddr(edp.Remote())
+	if err != nil || !fa.h.IsFederationPeer(sibling) {
+		return
+	}
+	logger := kitlog.With(fa.h.logger, "sibling", sibling.ShortSigil())
+	defer fa.h.state.ClearRemote(sibling)
+
+	src, err := edp.Source(ctx, muxrpc.TypeJSON, muxrpc.Method{"federation", "attendants"})
+	if err != nil {
+		level.Warn(logger).Log("event", "failed to follow sibling room", "err", err)
+		return
+	}
+	level.Info(logger).Log("event", "following sibling room")
+
+	for src.Next(ctx) {
+		body, err := src.Bytes()
+		if err != nil {
+			level.Warn(logger).Log("event", "failed to read sibling attendants", "err", err)
+			return
+		}
+
+		var evt siblingAttendantsEvent
+		if err := json.Unmarshal(body, &evt); err != nil {
+			level.Warn(logger).Log("event", "invalid sibling attendants event", "err", err)
+			return
+		}
+
+		switch evt.Type {
+		case "state":
+			for _, who := range evt.IDs {
+				fa.addSiblingAttendant(who, sibling, edp)
+			}
+		case "joined":
+			fa.addSiblingAttendant(evt.ID, sibling, edp)
+		case "left":
+			fa.h.state.RemoveRemote(evt.ID, sibling)
+		}
+	}
+
+	if err := src.Err(); err != nil && ctx.Err() == nil {
+		level.Debug(logger).Log("event", "sibling attendants stream closed", "err", err)
+	}
+}
+
+func (fa federationAttendants) addSiblingAttendant(who, sibling refs.FeedRef, edp muxrpc.Endpoint) {
+	// rooms are not attendants
+	if who.Equal(fa.h.netInfo.RoomID) || who.Equal(sibling) || fa.h.IsFederationPeer(who) {
+		return
+	}
+	fa.h.state.AddRemote(who, sibling, edp)
+}
+
+// federationConnect relays a tunnel.connect from a sibling room to one of our own attendants
+type federationConnect struct{ h *Handler }
+
+func (federationConnect) HandleConnect(context.Context, muxrpc.Endpoint) {}
+
+func (fc federationConnect) HandleDuplex(ctx context.Context, req *muxrpc.Request, peerSrc *muxrpc.ByteSource, peerSnk *muxrpc.ByteSink) error {
+	var args []connectWithOriginArg
+	err := json.Unmarshal(req.RawArgs, &args)
+	if err != nil {
+		return fmt.Errorf("federation connect: invalid arguments: %w", err)
+	}
+
+	if n := len(args); n != 1 {
+		return fmt.Errorf("federation connect: expected 1 argument, got %d", n)
+	}
+	arg := args[0]
+
+	sibling, err := network.GetFeedRefFromAddr(req.RemoteAddr())
+	if err != nil {
+		return err
+	}
+
+	if !fc.h.IsFederationPeer(sibling) {
+		return fmt.Errorf("federation: %s is not a trusted room", sibling.ShortSigil())
+	}
+
+	if !arg.Portal.Equal(fc.h.netInfo.RoomID) {
+		return fmt.Errorf("talking to the wrong room")
+	}
+
+	if arg.Origin.Equal(arg.Target) {
+		return fmt.Errorf("can't connect to self")
+	}
+
+	// the origin never dialed us, apply the same checks as for incoming connections
+	if fc.h.deniedKeys.HasFeed(ctx, arg.Origin) {
+		return fmt.Errorf("federation: %s has been banned", arg.Origin.ShortSigil())
+	}
+
+	pm, err := fc.h.config.GetPrivacyMode(ctx)
+	if err != nil {
+		return fmt.Errorf("running with unknown privacy mode")
+	}
+
+	if pm == roomdb.ModeRestricted {
+		if _, err := fc.h.membersdb.GetByFeed(ctx, arg.Origin); err != nil {
+			return fmt.Errorf("federation: access restricted to members")
+		}
+	}
+
+	// only relay to our own attendants, never to another sibling room
+	edp, has := fc.h.state.Has(arg.Target)
+	if !has {
+		return fmt.Errorf("could not connect to:%s", arg.Target.String())
+	}
+
+	targetSrc, targetSnk, err := edp.Duplex(ctx, muxrpc.TypeBinary, muxrpc.Method{"tunnel", "connect"}, arg)
+	if err != nil {
+		fc.h.state.MarkFailed(arg.Target, edp)
+		return fmt.Errorf("could not connect to:%s", arg.Target.String())
+	}
+
+	var cpy muxrpcDuplexCopy
+	cpy.logger = kitlog.With(fc.h.logger, "sibling", sibling.ShortSigil(), "origin", arg.Origin.ShortSigil(), "target", arg.Target.ShortSigil())
+	cpy.ctx, cpy.cancel = context.WithCancel(ctx)
+
+	go cpy.do(targetSnk, peerSrc)
+	go cpy.do(peerSnk, targetSrc)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ssbc/go-muxrpc/v2"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+)
+
+// setHidden changes whether the calling member is listed by room.attendants and tunnel.endpoints.
+// It takes one boolean argument and returns it once the preference is stored.
+// Hidden members can still be reached over tunnel.connect by peers that know their ID.
+func (h *Handler) setHidden(ctx context.Context, req *muxrpc.Request) (interface{}, error) {
+	peer, err := network.GetFeedRefFromAddr(req.RemoteAddr())
+	if err != nil {
+		return nil, err
+	}
+
+	var args []bool
+	if err := json.Unmarshal(req.RawArgs, &args); err != nil {
+		return nil, fmt.Errorf("setHidden: bad request: %w", err)
+	}
+	if n := len(args); n != 1 {
+		return nil, fmt.Errorf("setHidden: expected one argument got %d", n)
+	}
+	hidden := args[0]
+
+	member, err := h.membersdb.GetByFeed(ctx, peer)
+	if err != nil {
+		return nil, fmt.Errorf("setHidden: only members can hide themselves: %w", err)
+	}
+
+	if err := h.membersdb.SetHidden(ctx, member.ID, hidden); err != nil {
+		return nil, fmt.Errorf("setHidden: failed to store the preference: %w", err)
+	}
+
+	h.state.SetHidden(peer, hidden)
+
+	return hidden, nil
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ssbc/go-muxrpc/v2"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/broadcasts"
+	"github.com/ssbc/go-ssb-room/v2/roomstate"
+)
+
+// NoticesArg are the options a client can pass to room.notices
+type NoticesArg struct {
+	// Language only sends the posts in that language, all of them if it is empty
+	Language string `json:"language"`
+
+	// Old sends the posts that were already published before the new ones, the oldest first
+	Old bool `json:"old"`
+}
+
+// NoticeMessage is emitted for every news post of the room
+type NoticeMessage struct {
+	Type     string `json:"type"`
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	Content  string `json:"content"` // markdown
+	Language string `json:"language"`
+
+	// Published is when the post was published, in milliseconds since the epoch
+	Published int64 `json:"published"`
+
+	// URL is the web page of the post
+	URL string `json:"url"`
+}
+
+func (h *Handler) notices(ctx context.Context, req *muxrpc.Request, snk *muxrpc.ByteSink) error {
+	if h.newsdb == nil {
+		return fmt.Errorf("notices: the news of this room are not available")
+	}
+
+	var args []NoticesArg
+	if len(req.RawArgs) > 0 {
+		if err := json.Unmarshal(req.RawArgs, &args); err != nil {
+			return fmt.Errorf("notices: invalid arguments: %w", err)
+		}
+	}
+	var arg NoticesArg
+	if len(args) > 0 {
+		arg = args[0]
+	}
+
+	toPeer := newNoticesEncoder(snk, arg.Language, h.netInfo.URLForNotice)
+
+	// register before the old posts are listed, so that the ones published in between are not missed.
+	// they wait in the queue of the broadcast until the old ones are sent.
+	unregister := h.state.RegisterNoticesUpdates(toPeer)
+	go func() {
+		// the context of the request ends with the stream
+		<-ctx.Done()
+		unregister()
+	}()
+	defer toPeer.startLive()
+
+	if arg.Old {
+		posts, err := h.newsdb.List(ctx, arg.Language)
+		if err != nil {
+			return fmt.Errorf("notices: failed to list the news: %w", err)
+		}
+
+		// the list is newest first
+		for i := len(posts) - 1; i >= 0; i-- {
+			if err := toPeer.sendOld(roomstate.NoticeFromPost(posts[i])); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// a muxrpc json encoder for the notices broadcasts
+type noticesJSONEncoder struct {
+	mu  sync.Mutex // only one caller to forwarder at a time
+	snk *muxrpc.ByteSink
+	enc *json.Encoder
+
+	language string
+	urlFor   func(int64) string
+
+	// the posts from the broadcast wait until the old ones are sent, which might include them already
+	live    chan struct{}
+	sentOld map[int64]struct{}
+}
+
+func newNoticesEncoder(snk *muxrpc.ByteSink, language string, urlFor func(int64) string) *noticesJSONEncoder {
+	enc := json.NewEncoder(snk)
+	snk.SetEncoding(muxrpc.TypeJSON)
+	return &noticesJSONEncoder{
+		snk:      snk,
+		enc:      enc,
+		language: language,
+		urlFor:   urlFor,
+
+		live:    make(chan struct{}),
+		sentOld: make(map[int64]struct{}),
+	}
+}
+
+// sendOld sends one of the posts that were published before the stream was opened
+func (ne *noticesJSONEncoder) sendOld(post broadcasts.Notice) error {
+	ne.sentOld[post.ID] = struct{}{}
+	return ne.send(post)
+}
+
+// startLive lets the posts from the broadcast through
+func (ne *noticesJSONEncoder) startLive() {
+	close(ne.live)
+}
+
+func (ne *noticesJSONEncoder) Published(post broadcasts.Notice) error {
+	<-ne.live
+	if _, sent := ne.sentOld[post.ID]; sent {
+		return nil
+	}
+	return ne.send(post)
+}
+
+func (ne *noticesJSONEncoder) send(post broadcasts.Notice) error {
+	if ne.language != "" && post.Language != ne.language {
+		return nil
+	}
+
+	ne.mu.Lock()
+	defer ne.mu.Unlock()
+	return ne.enc.Encode(NoticeMessage{
+		Type:      "notice",
+		ID:        post.ID,
+		Title:     post.Title,
+		Content:   post.Content,
+		Language:  post.Language,
+		Published: post.Published.UnixNano() / int64(time.Millisecond),
+		URL:       ne.urlFor(post.NoticeID),
+	})
+}
+
+func (ne *noticesJSONEncoder) Close() error {
+	ne.mu.Lock()
+	defer ne.mu.Unlock()
+	return ne.snk.Close()
+}
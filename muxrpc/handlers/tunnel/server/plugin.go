@@ -23,15 +23,29 @@ import (
 	"isRoom": "async",
 	"ping": "sync",
 }
+
+endpoints sends the full list of peers on every change, unless it is called with {"incremental": true}.
+Then the first message is {"type": "state", "ids": [...]} followed by {"type": "delta", "added": [...], "removed": [...]}.
+
+room.attendants called with {"extended": true} adds an "attendants" list to the state message, with the member status, alias URLs and join time of each peer.
+Joined messages carry the same fields next to "type". Left messages don't change.
+
+room.notices streams the news posts of the room as {"type": "notice", ...} messages, see NoticeMessage.
+Called with {"language": "de"} it only sends the posts in that language, with {"old": true} the published ones are sent first, the oldest first.
+
+room.setHidden(true) removes the calling member from the attendants and endpoints lists, room.setHidden(false) lists them again.
 */
 
-func New(log kitlog.Logger, netInfo network.ServerEndpointDetails, m *roomstate.Manager, members roomdb.MembersService, config roomdb.RoomConfig) *Handler {
+func New(log kitlog.Logger, netInfo network.ServerEndpointDetails, m *roomstate.Manager, members roomdb.MembersService, denied roomdb.DeniedKeysService, aliases roomdb.AliasesService, config roomdb.RoomConfig, news roomdb.NewsService) *Handler {
 	var h = new(Handler)
 	h.netInfo = netInfo
 	h.logger = log
 	h.state = m
 	h.membersdb = members
+	h.deniedKeys = denied
 	h.config = config
+	h.newsdb = news
+	h.attendantsInfo = newAttendantsInfoCache(members, aliases, netInfo.URLForAlias)
 
 	return h
 }
@@ -57,9 +71,11 @@ func (h *Handler) RegisterRoom(mux typemux.HandlerMux) {
 	var namespace = muxrpc.Method{"room"}
 	mux.RegisterAsync(append(namespace, "metadata"), typemux.AsyncFunc(h.metadata))
 	mux.RegisterAsync(append(namespace, "ping"), typemux.AsyncFunc(h.ping))
+	mux.RegisterAsync(append(namespace, "setHidden"), typemux.AsyncFunc(h.setHidden))
 
 	mux.RegisterSource(append(namespace, "attendants"), typemux.SourceFunc(h.attendants))
 	mux.RegisterSource(append(namespace, "members"), typemux.SourceFunc(h.members))
+	mux.RegisterSource(append(namespace, "notices"), typemux.SourceFunc(h.notices))
 
 	mux.RegisterDuplex(append(namespace, "connect"), connectHandler{
 		logger: h.logger,
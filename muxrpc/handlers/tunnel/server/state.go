@@ -9,9 +9,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/ssbc/go-ssb-room/v2/internal/broadcasts"
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
 	"github.com/ssbc/go-ssb-room/v2/roomstate"
@@ -23,16 +25,28 @@ import (
 type Handler struct {
 	logger kitlog.Logger
 
-	netInfo   network.ServerEndpointDetails
-	state     *roomstate.Manager
-	membersdb roomdb.MembersService
-	config    roomdb.RoomConfig
+	netInfo    network.ServerEndpointDetails
+	state      *roomstate.Manager
+	membersdb  roomdb.MembersService
+	deniedKeys roomdb.DeniedKeysService
+	config     roomdb.RoomConfig
+
+	// for room.notices, nil if the news are not available
+	newsdb roomdb.NewsService
+
+	// for the extended room.attendants mode
+	attendantsInfo *attendantsInfoCache
+
+	federation federationPeers
 }
 
 type MetadataReply struct {
 	Name       string   `json:"name"`
 	Membership bool     `json:"membership"`
 	Features   []string `json:"features"`
+
+	// MultiserverAddress holds all the public addresses of the room, joined by ';'
+	MultiserverAddress string `json:"multiserverAddress"`
 }
 
 func (h *Handler) metadata(ctx context.Context, req *muxrpc.Request) (interface{}, error) {
@@ -46,8 +60,17 @@ func (h *Handler) metadata(ctx context.Context, req *muxrpc.Request) (interface{
 		return nil, err
 	}
 
+	branding, err := h.config.GetBranding(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var reply MetadataReply
 	reply.Name = h.netInfo.Domain
+	if branding.Name != "" {
+		reply.Name = branding.Name
+	}
+	reply.MultiserverAddress = h.netInfo.MultiserverAddress()
 
 	// check if caller is a member
 	if _, err := h.membersdb.GetByFeed(ctx, ref); err != nil {
@@ -79,7 +102,12 @@ func (h *Handler) metadata(ctx context.Context, req *muxrpc.Request) (interface{
 	return reply, nil
 }
 
-func (h *Handler) ping(context.Context, *muxrpc.Request) (interface{}, error) {
+func (h *Handler) ping(_ context.Context, req *muxrpc.Request) (interface{}, error) {
+	// pings keep this connection the preferred one for tunnel.connect
+	if ref, err := network.GetFeedRefFromAddr(req.RemoteAddr()); err == nil {
+		h.state.MarkActive(ref, req.Endpoint())
+	}
+
 	now := time.Now().UnixNano() / 1000
 	return now, nil
 }
@@ -101,11 +129,31 @@ func (h *Handler) leave(_ context.Context, req *muxrpc.Request) (interface{}, er
 		return nil, err
 	}
 
-	h.state.Remove(ref)
+	// only this connection leaves, others of the same feed stay in the room
+	h.state.RemoveEndpoint(ref, req.Endpoint())
 
 	return true, nil
 }
 
+// EndpointsArg are the options a client can pass to tunnel.endpoints
+type EndpointsArg struct {
+	// Incremental switches the stream from full lists to EndpointsInitialState and EndpointsDelta messages
+	Incremental bool `json:"incremental"`
+}
+
+// EndpointsInitialState is the first message of an incremental tunnel.endpoints stream
+type EndpointsInitialState struct {
+	Type string   `json:"type"` // always "state"
+	IDs  []string `json:"ids"`
+}
+
+// EndpointsDelta is emitted on incremental streams with the peers that joined or left since the last message
+type EndpointsDelta struct {
+	Type    string   `json:"type"` // always "delta"
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
 func (h *Handler) endpoints(ctx context.Context, req *muxrpc.Request, snk *muxrpc.ByteSink) error {
 
 	// get public key from the calling peer
@@ -129,15 +177,28 @@ func (h *Handler) endpoints(ctx context.Context, req *muxrpc.Request, snk *muxrp
 		}
 	}
 
+	// legacy clients don't pass any arguments
+	var args []EndpointsArg
+	if len(req.RawArgs) > 0 {
+		if err := json.Unmarshal(req.RawArgs, &args); err != nil {
+			return fmt.Errorf("endpoints: invalid arguments: %w", err)
+		}
+	}
+
 	// for future updates
-	toPeer := newEndpointsForwarder(snk)
+	var toPeer broadcasts.EndpointsEmitter
+	if len(args) > 0 && args[0].Incremental {
+		toPeer = newEndpointsDeltaEncoder(snk)
+	} else {
+		toPeer = newEndpointsForwarder(snk)
+	}
 	h.state.RegisterLegacyEndpoints(toPeer)
 
 	// add the peer to the room state if they arent already
 	h.state.AlreadyAdded(peer, req.Endpoint())
 
 	// update the peer with
-	toPeer.Update(h.state.List())
+	toPeer.Update(h.state.ListAll())
 
 	return nil
 }
@@ -169,3 +230,72 @@ func (uf *endpointsJSONEncoder) Close() error {
 	defer uf.mu.Unlock()
 	return uf.snk.Close()
 }
+
+// a muxrpc json encoder that only sends the changes to the previous list
+type endpointsDeltaEncoder struct {
+	mu  sync.Mutex // only one caller to forwarder at a time
+	snk *muxrpc.ByteSink
+	enc *json.Encoder
+
+	sent    bool
+	current map[string]struct{}
+}
+
+func newEndpointsDeltaEncoder(snk *muxrpc.ByteSink) *endpointsDeltaEncoder {
+	enc := json.NewEncoder(snk)
+	snk.SetEncoding(muxrpc.TypeJSON)
+	return &endpointsDeltaEncoder{
+		snk:     snk,
+		enc:     enc,
+		current: make(map[string]struct{}),
+	}
+}
+
+func (uf *endpointsDeltaEncoder) Update(members []string) error {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+
+	next := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		next[m] = struct{}{}
+	}
+
+	if !uf.sent {
+		uf.sent = true
+		uf.current = next
+		return uf.enc.Encode(EndpointsInitialState{
+			Type: "state",
+			IDs:  members,
+		})
+	}
+
+	delta := EndpointsDelta{
+		Type:    "delta",
+		Added:   []string{},
+		Removed: []string{},
+	}
+	for _, m := range members {
+		if _, has := uf.current[m]; !has {
+			delta.Added = append(delta.Added, m)
+		}
+	}
+	for m := range uf.current {
+		if _, has := next[m]; !has {
+			delta.Removed = append(delta.Removed, m)
+		}
+	}
+	uf.current = next
+
+	if len(delta.Added) == 0 && len(delta.Removed) == 0 {
+		return nil
+	}
+	sort.Strings(delta.Removed)
+
+	return uf.enc.Encode(delta)
+}
+
+func (uf *endpointsDeltaEncoder) Close() error {
+	uf.mu.Lock()
+	defer uf.mu.Unlock()
+	return uf.snk.Close()
+}
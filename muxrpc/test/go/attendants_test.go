@@ -140,6 +140,66 @@ func TestRoomAttendants(t *testing.T) {
 
 }
 
+func TestRoomAttendantsExtended(t *testing.T) {
+	testInit(t)
+	r := require.New(t)
+	a := assert.New(t)
+
+	testPath := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testPath)
+
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+
+	ts := makeNamedTestBot(t, "server", ctx, nil)
+	ctx = ts.ctx
+
+	alf := ts.makeTestClient("alf")
+	bre := ts.makeTestClient("bre")
+
+	err := ts.srv.Aliases.Register(ctx, "bre", bre.feed, []byte("signature"))
+	r.NoError(err)
+
+	src, err := alf.Source(ctx, muxrpc.TypeJSON, muxrpc.Method{"room", "attendants"}, server.AttendantsArg{Extended: true})
+	r.NoError(err)
+
+	a.True(src.Next(ctx))
+	var state server.AttendantsExtendedState
+	decodeJSONsrc(t, src, &state)
+	a.Equal("state", state.Type)
+	r.Len(state.Attendants, 1)
+	alfs := state.Attendants[0]
+	a.True(alfs.ID.Equal(alf.feed))
+	a.True(alfs.Member)
+	a.Len(alfs.Aliases, 0)
+	a.NotZero(alfs.Joined)
+
+	// bre joins
+	breSrc, err := bre.Source(ctx, muxrpc.TypeJSON, muxrpc.Method{"room", "attendants"})
+	r.NoError(err)
+	a.True(breSrc.Next(ctx))
+
+	a.True(src.Next(ctx))
+	var joined server.AttendantsExtendedJoined
+	decodeJSONsrc(t, src, &joined)
+	a.Equal("joined", joined.Type)
+	a.True(joined.ID.Equal(bre.feed))
+	a.True(joined.Member)
+	if a.Len(joined.Aliases, 1) {
+		a.Contains(joined.Aliases[0], "bre")
+	}
+	a.True(joined.Joined >= alfs.Joined)
+
+	ts.srv.Shutdown()
+	alf.Terminate()
+	bre.Terminate()
+	ts.srv.Close()
+
+	r.NoError(ts.serveGroup.Wait())
+	cancel()
+}
+
 func assertListContains(t *testing.T, lst []refs.FeedRef, who refs.FeedRef) {
 	var found = false
 	for _, feed := range lst {
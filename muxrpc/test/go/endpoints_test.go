@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/muxrpc/handlers/tunnel/server"
 )
 
 type announcements map[string]struct{}
@@ -110,6 +111,84 @@ func TestEndpointClients(t *testing.T) {
 	cancel()
 }
 
+// alf uses the incremental mode and only gets the changes after the first message
+func TestEndpointsIncremental(t *testing.T) {
+	testInit(t)
+
+	r := require.New(t)
+	a := assert.New(t)
+
+	testPath := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testPath)
+
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	t.Cleanup(cancel)
+
+	ts := makeNamedTestBot(t, "server", ctx, nil)
+	ctx = ts.ctx
+
+	alf := ts.makeTestClient("alf")
+	bre := ts.makeTestClient("bre")
+
+	src, err := alf.Source(ctx, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "endpoints"}, server.EndpointsArg{Incremental: true})
+	r.NoError(err)
+
+	msgs := make(chan []byte)
+	go func() {
+		for src.Next(ctx) {
+			body, err := src.Bytes()
+			if err != nil {
+				panic(err)
+			}
+			msgs <- body
+		}
+		close(msgs)
+	}()
+
+	next := func() []byte {
+		select {
+		case body := <-msgs:
+			t.Log("alf got:", string(body))
+			return body
+		case <-time.After(10 * time.Second):
+			r.FailNow("timeout")
+		}
+		return nil
+	}
+
+	var state server.EndpointsInitialState
+	r.NoError(json.Unmarshal(next(), &state))
+	a.Equal("state", state.Type)
+	a.Equal([]string{alf.feed.String()}, state.IDs)
+
+	var ret bool
+	err = bre.Async(ctx, &ret, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "announce"})
+	r.NoError(err)
+
+	var delta server.EndpointsDelta
+	r.NoError(json.Unmarshal(next(), &delta))
+	a.Equal("delta", delta.Type)
+	a.Equal([]string{bre.feed.String()}, delta.Added)
+	a.Len(delta.Removed, 0)
+
+	err = bre.Async(ctx, &ret, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "leave"})
+	r.NoError(err)
+
+	delta = server.EndpointsDelta{}
+	r.NoError(json.Unmarshal(next(), &delta))
+	a.Len(delta.Added, 0)
+	a.Equal([]string{bre.feed.String()}, delta.Removed)
+
+	ts.srv.Shutdown()
+	alf.Terminate()
+	bre.Terminate()
+	ts.srv.Close()
+
+	r.NoError(ts.serveGroup.Wait())
+	cancel()
+}
+
 // consume endpoint messaes and put each peer on the passed map
 func logEndpointsStream(ts *testSession, src *muxrpc.ByteSource, who string, a announcements) {
 	var edps []refs.FeedRef
@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package go_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ssbc/go-muxrpc/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/keys"
+	"github.com/ssbc/go-ssb-room/v2/muxrpc/handlers/tunnel/server"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomsrv"
+)
+
+// two sibling rooms share their attendants and relay tunnel.connect calls between them
+func TestFederatedAttendants(t *testing.T) {
+	testInit(t)
+	r := require.New(t)
+	a := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ts, sibling := makeFederatedRooms(t, ctx)
+	kpServer, kpSibling := ts.srv.Whoami(), sibling.srv.Whoami()
+
+	// alf attends the sibling room
+	alf := sibling.makeTestClient("alf")
+	var ok bool
+	err := alf.Async(ctx, &ok, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "announce"})
+	r.NoError(err)
+	r.True(ok)
+
+	// and shows up in our room
+	r.Eventually(func() bool {
+		_, _, has := ts.srv.StateManager.HasRemote(alf.feed)
+		return has
+	}, 10*time.Second, 100*time.Millisecond, "alf never showed up through the sibling room")
+	a.NotContains(ts.srv.StateManager.List(), alf.feed.String(), "alf is not a local attendant")
+
+	// bob attends our room and sees alf
+	bob := ts.makeTestClient("bob")
+	bobsSource, err := bob.Source(ctx, muxrpc.TypeJSON, muxrpc.Method{"room", "attendants"})
+	r.NoError(err)
+
+	r.True(bobsSource.Next(ctx))
+	var initState server.AttendantsInitialState
+	decodeJSONsrc(t, bobsSource, &initState)
+	a.Equal("state", initState.Type)
+	a.Len(initState.IDs, 2)
+	assertListContains(t, initState.IDs, alf.feed)
+	assertListContains(t, initState.IDs, bob.feed)
+
+	announcementsForBob := make(announcements)
+	go logAttendantsStream(ts, bobsSource, "bob", announcementsForBob)
+
+	// the sibling room sees bob but doesn't get alf echoed back, only local attendants are shared
+	r.Eventually(func() bool {
+		_, _, has := sibling.srv.StateManager.HasRemote(bob.feed)
+		return has
+	}, 5*time.Second, 100*time.Millisecond, "bob never showed up in the sibling room")
+	_, _, has := sibling.srv.StateManager.HasRemote(alf.feed)
+	a.False(has, "alf was echoed back to the sibling room")
+
+	// the sibling room runs restricted, bob needs to be a member there, too
+	_, err = sibling.srv.Members.Add(ctx, bob.feed, roomdb.RoleMember)
+	r.NoError(err)
+
+	// bob connects to alf, through the sibling room
+	testKexMsg := []byte("fake keyexchange")
+	testKexReply := []byte("fake kex reply")
+
+	receivedCall := make(chan refs.FeedRef, 1)
+	alf.mockedHandler.HandledCalls(func(m muxrpc.Method) bool { return m.String() == "tunnel.connect" })
+	alf.mockedHandler.HandleCallCalls(func(ctx context.Context, req *muxrpc.Request) {
+		if req.Method.String() != "tunnel.connect" {
+			return
+		}
+
+		var args []struct {
+			Portal refs.FeedRef `json:"portal"`
+			Origin refs.FeedRef `json:"origin"`
+		}
+		if err := json.Unmarshal(req.RawArgs, &args); err != nil || len(args) != 1 {
+			panic(fmt.Errorf("invalid tunnel.connect arguments: %v", err))
+		}
+		if !args[0].Portal.Equal(kpSibling) {
+			panic("alf expected the tunnel to come from the sibling room")
+		}
+		receivedCall <- args[0].Origin
+
+		src, err := req.ResponseSource()
+		if err != nil {
+			panic(err)
+		}
+		if !src.Next(ctx) {
+			panic(fmt.Errorf("did not get message from source: %v", src.Err()))
+		}
+		gotKexMsg, err := src.Bytes()
+		if err != nil {
+			panic(err)
+		}
+		if !bytes.Equal(testKexMsg, gotKexMsg) {
+			panic(fmt.Sprintf("wrong kex message: %q", gotKexMsg))
+		}
+
+		snk, err := req.ResponseSink()
+		if err != nil {
+			panic(err)
+		}
+		snk.Write(testKexReply)
+	})
+
+	var arg server.ConnectArg
+	arg.Portal = kpServer
+	arg.Target = alf.feed
+	src, snk, err := bob.Duplex(ctx, muxrpc.TypeBinary, muxrpc.Method{"tunnel", "connect"}, arg)
+	r.NoError(err)
+
+	select {
+	case origin := <-receivedCall:
+		a.True(origin.Equal(bob.feed), "wrong origin: %s", origin.ShortSigil())
+	case <-time.After(5 * time.Second):
+		t.Fatal("alf didn't get the tunnel.connect call")
+	}
+
+	_, err = snk.Write(testKexMsg)
+	r.NoError(err)
+
+	r.True(src.Next(ctx), "no reply from alf: %v", src.Err())
+	gotReply, err := src.Bytes()
+	r.NoError(err)
+	r.Equal(testKexReply, gotReply)
+
+	// alf leaves the sibling room, bob should see that
+	err = alf.Async(ctx, &ok, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "leave"})
+	r.NoError(err)
+	r.Eventually(func() bool {
+		_, _, has := ts.srv.StateManager.HasRemote(alf.feed)
+		return !has
+	}, 5*time.Second, 100*time.Millisecond, "alf should be gone")
+
+	// shut everything down
+	ts.srv.Shutdown()
+	sibling.srv.Shutdown()
+	alf.Terminate()
+	bob.Terminate()
+	ts.srv.Close()
+	sibling.srv.Close()
+
+	r.NoError(ts.serveGroup.Wait())
+	r.NoError(sibling.serveGroup.Wait())
+}
+
+// sibling rooms only relay tunnels from peers that could have connected to the target room directly
+func TestFederatedConnectChecksOrigin(t *testing.T) {
+	testInit(t)
+	r := require.New(t)
+	a := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ts, sibling := makeFederatedRooms(t, ctx)
+
+	// alf attends the sibling room
+	alf := sibling.makeTestClient("alf")
+	var ok bool
+	err := alf.Async(ctx, &ok, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "announce"})
+	r.NoError(err)
+	r.True(ok)
+
+	r.Eventually(func() bool {
+		_, _, has := ts.srv.StateManager.HasRemote(alf.feed)
+		return has
+	}, 10*time.Second, 100*time.Millisecond, "alf never showed up through the sibling room")
+
+	receivedCall := make(chan struct{}, 1)
+	alf.mockedHandler.HandledCalls(func(m muxrpc.Method) bool { return m.String() == "tunnel.connect" })
+	alf.mockedHandler.HandleCallCalls(func(ctx context.Context, req *muxrpc.Request) {
+		if req.Method.String() == "tunnel.connect" {
+			receivedCall <- struct{}{}
+		}
+	})
+
+	// bob is only a member of our room
+	bob := ts.makeTestClient("bob")
+
+	var arg server.ConnectArg
+	arg.Portal = ts.srv.Whoami()
+	arg.Target = alf.feed
+
+	assertConnectFails := func(msg string) {
+		src, _, err := bob.Duplex(ctx, muxrpc.TypeBinary, muxrpc.Method{"tunnel", "connect"}, arg)
+		r.NoError(err)
+
+		nextCtx, nextCancel := context.WithTimeout(ctx, 5*time.Second)
+		defer nextCancel()
+		a.False(src.Next(nextCtx), msg)
+		a.Error(src.Err(), msg)
+
+		select {
+		case <-receivedCall:
+			t.Error("alf got the tunnel.connect call:", msg)
+		default:
+		}
+	}
+
+	// the sibling room is restricted and bob isn't a member there
+	assertConnectFails("non-member was relayed to a restricted room")
+
+	// bob is a member but was banned by the sibling room
+	_, err = sibling.srv.Members.Add(ctx, bob.feed, roomdb.RoleMember)
+	r.NoError(err)
+	err = sibling.srv.DeniedKeys.Add(ctx, bob.feed, "rude")
+	r.NoError(err)
+	assertConnectFails("denied key was relayed")
+
+	// shut everything down
+	ts.srv.Shutdown()
+	sibling.srv.Shutdown()
+	alf.Terminate()
+	bob.Terminate()
+	ts.srv.Close()
+	sibling.srv.Close()
+
+	r.NoError(ts.serveGroup.Wait())
+	r.NoError(sibling.serveGroup.Wait())
+}
+
+// makeFederatedRooms starts two rooms that trust each other as siblings
+func makeFederatedRooms(t *testing.T, ctx context.Context) (ts, sibling *testSession) {
+	r := require.New(t)
+
+	kpServer, err := keys.NewKeyPair(nil)
+	r.NoError(err)
+	kpSibling, err := keys.NewKeyPair(nil)
+	r.NoError(err)
+
+	addrServer, addrSibling := freeLocalAddr(t), freeLocalAddr(t)
+
+	serverOpts := []roomsrv.Option{
+		roomsrv.WithKeyPair(kpServer),
+		roomsrv.WithFederationPeers(federationAddr(addrSibling, kpSibling)),
+	}
+	siblingOpts := []roomsrv.Option{
+		roomsrv.WithKeyPair(kpSibling),
+		roomsrv.WithFederationPeers(federationAddr(addrServer, kpServer)),
+	}
+
+	// the room with the smaller key dials, start the other one first so that the first dial works
+	if bytes.Compare(kpServer.Feed.PubKey(), kpSibling.Feed.PubKey()) < 0 {
+		sibling = makeNamedTestBotOnAddr(t, "sibling", addrSibling, ctx, siblingOpts)
+		ts = makeNamedTestBotOnAddr(t, "server", addrServer, ctx, serverOpts)
+	} else {
+		ts = makeNamedTestBotOnAddr(t, "server", addrServer, ctx, serverOpts)
+		sibling = makeNamedTestBotOnAddr(t, "sibling", addrSibling, ctx, siblingOpts)
+	}
+	return ts, sibling
+}
+
+func freeLocalAddr(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func federationAddr(addr string, kp *keys.KeyPair) string {
+	return fmt.Sprintf("net:%s~shs:%s", addr, base64.StdEncoding.EncodeToString(kp.Feed.PubKey()))
+}
@@ -87,6 +87,12 @@ func TestTunnelServerSimple(t *testing.T) {
 	r.Equal("srv", meta.Name)
 	r.True(meta.Membership, "not a member?")
 
+	// the name from the branding settings replaces the domain
+	r.NoError(serv.Config.SetBranding(ctx, roomdb.Branding{Name: "Hermies Club"}))
+	err = endpointA.Async(ctx, &meta, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "isRoom"})
+	r.NoError(err)
+	r.Equal("Hermies Club", meta.Name)
+
 	var ts int
 	err = endpointA.Async(ctx, &ts, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "ping"})
 	r.NoError(err)
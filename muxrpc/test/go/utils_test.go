@@ -81,6 +81,8 @@ func (bs botServer) Serve(s *roomsrv.Server) func() error {
 type testSession struct {
 	t testing.TB
 
+	name string
+
 	srv *roomsrv.Server
 
 	ctx        context.Context
@@ -91,6 +93,10 @@ type testSession struct {
 }
 
 func makeNamedTestBot(t testing.TB, name string, ctx context.Context, opts []roomsrv.Option) *testSession {
+	return makeNamedTestBotOnAddr(t, name, ":0", ctx, opts)
+}
+
+func makeNamedTestBotOnAddr(t testing.TB, name, listenAddr string, ctx context.Context, opts []roomsrv.Option) *testSession {
 	r := require.New(t)
 	testPath := filepath.Join("testrun", t.Name(), "bot-"+name)
 	os.RemoveAll(testPath)
@@ -119,7 +125,7 @@ func makeNamedTestBot(t testing.TB, name string, ctx context.Context, opts []roo
 	netInfo := network.ServerEndpointDetails{
 		Domain: name,
 
-		ListenAddressMUXRPC: ":0",
+		ListenAddressMUXRPC: listenAddr,
 
 		UseSubdomainForAliases: true,
 	}
@@ -130,6 +136,7 @@ func makeNamedTestBot(t testing.TB, name string, ctx context.Context, opts []roo
 
 	ts := testSession{
 		t:          t,
+		name:       name,
 		srv:        theBot,
 		clientKeys: make(map[string]*keys.KeyPair),
 	}
@@ -216,7 +223,7 @@ func (ts *testSession) makeTestClient(name string) testClient {
 	var meta server.MetadataReply
 	err = wsEndpoint.Async(ts.ctx, &meta, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "isRoom"})
 	r.NoError(err)
-	r.Equal("server", meta.Name)
+	r.Equal(ts.name, meta.Name)
 	r.True(meta.Membership, "not a member?")
 
 	return testClient{
@@ -131,6 +131,68 @@ func TestWebsocketDialing(t *testing.T) {
 	r.False(time.Time(pongTS).IsZero())
 }
 
+func TestWebsocketOrigins(t *testing.T) {
+	r := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// the domain of the test bot is its name
+	session := makeNamedTestBot(t, "server", ctx, nil)
+	server := session.srv
+
+	l, err := net.Listen("tcp4", "localhost:0")
+	r.NoError(err)
+
+	// mounted on its own path
+	mux := http.NewServeMux()
+	mux.Handle("/ws", server.Network.WebsocketEndpoint())
+	mux.Handle("/", failHandler{t: t})
+	go http.Serve(l, mux)
+
+	wsURL := "ws://" + l.Addr().String() + "/ws"
+
+	dial := func(origin string) (int, error) {
+		hdr := make(http.Header)
+		if origin != "" {
+			hdr.Set("Origin", origin)
+		}
+		conn, resp, err := websocket.DefaultDialer.DialContext(ctx, wsURL, hdr)
+		if conn != nil {
+			conn.Close()
+		}
+		if resp == nil {
+			return 0, err
+		}
+		return resp.StatusCode, err
+	}
+
+	// not a browser
+	status, err := dial("")
+	r.NoError(err)
+	r.Equal(http.StatusSwitchingProtocols, status)
+
+	// the room itself and the alias subdomains
+	status, err = dial("https://server")
+	r.NoError(err)
+	r.Equal(http.StatusSwitchingProtocols, status)
+
+	status, err = dial("https://alice.server")
+	r.NoError(err)
+	r.Equal(http.StatusSwitchingProtocols, status)
+
+	// some other page
+	status, err = dial("https://evil.example")
+	r.Error(err)
+	r.Equal(http.StatusForbidden, status)
+
+	// plain requests don't reach the next handler
+	resp, err := http.Get("http://" + l.Addr().String() + "/ws")
+	r.NoError(err)
+	resp.Body.Close()
+	r.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
 type failHandler struct {
 	t *testing.T
 }
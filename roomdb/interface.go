@@ -25,6 +25,27 @@ type RoomConfig interface {
 	SetPrivacyMode(context.Context, PrivacyMode) error
 	GetDefaultLanguage(context.Context) (string, error)
 	SetDefaultLanguage(context.Context, string) error
+
+	// GetBranding returns the name, tagline and accent color of the room and if it has a logo
+	GetBranding(context.Context) (Branding, error)
+
+	// SetBranding updates the name, tagline and accent color. HasLogo is ignored.
+	SetBranding(context.Context, Branding) error
+
+	// GetLogo returns ErrNotFound if no logo was uploaded
+	GetLogo(context.Context) (Logo, error)
+
+	// SetLogo replaces the logo of the room
+	SetLogo(context.Context, Logo) error
+
+	// RemoveLogo deletes the logo of the room
+	RemoveLogo(context.Context) error
+
+	// GetOpenInviteLimits returns the proof-of-work difficulty and the daily cap for invites in open mode
+	GetOpenInviteLimits(context.Context) (OpenInviteLimits, error)
+
+	// SetOpenInviteLimits updates both limits
+	SetOpenInviteLimits(context.Context, OpenInviteLimits) error
 }
 
 // AuthFallbackService allows password authentication which might be helpful for scenarios
@@ -96,6 +117,10 @@ type MembersService interface {
 	// It should also return an error if call would remove the last admin,
 	// since only admins can change roles doing so would leave the room in a crippled state.
 	SetRole(context.Context, int64, Role) error
+
+	// SetHidden changes whether the member is listed as an attendant of the room.
+	// It returns ErrNotFound if the member doesn't exist.
+	SetHidden(_ context.Context, id int64, hidden bool) error
 }
 
 // DeniedKeysService changes the lists of public keys that are not allowed to get into the room
@@ -126,6 +151,35 @@ type DeniedKeysService interface {
 	RemoveID(context.Context, int64) error
 }
 
+// PeersService stores the multiserver addresses of other peers (pubs, rooms or monitoring bots) the room keeps connected to.
+//counterfeiter:generate . PeersService
+type PeersService interface {
+	// Add adds the address to the list, together with a comment for other members.
+	// It returns the ID of the new entry.
+	Add(ctx context.Context, address, comment string) (int64, error)
+
+	// GetByID returns the list entry for that ID or an error
+	GetByID(context.Context, int64) (Peer, error)
+
+	// List returns a list of all the peers.
+	List(context.Context) ([]Peer, error)
+
+	// RemoveID removes the peer for the ID from the list.
+	RemoveID(context.Context, int64) error
+}
+
+// PresenceService remembers when members were connected to the room
+//counterfeiter:generate . PresenceService
+type PresenceService interface {
+	// RecordSession stores a closed connection of the member and adds it to the totals.
+	// It returns ErrNotFound if the feed is not a member.
+	RecordSession(_ context.Context, member refs.FeedRef, s Session) error
+
+	// GetByMemberID returns the totals and the most recent sessions of the member, the newest first.
+	// It returns ErrNotFound if the member was never seen.
+	GetByMemberID(context.Context, int64) (Presence, error)
+}
+
 // AliasesService manages alias handle registration and lookup
 //counterfeiter:generate . AliasesService
 type AliasesService interface {
@@ -156,6 +210,7 @@ type InvitesService interface {
 	// Consume checks if the passed token is still valid.
 	// If it is it adds newMember to the members of the room and invalidates the token.
 	// If the token isn't valid, it returns an error.
+	// Invites of join requests only work for the feed of the request, for others it returns ErrInviteForOtherFeed.
 	Consume(ctx context.Context, token string, newMember refs.FeedRef) (Invite, error)
 
 	// GetByToken returns the Invite if one for that token exists, or an error
@@ -174,6 +229,40 @@ type InvitesService interface {
 	Revoke(ctx context.Context, id int64) error
 }
 
+// JoinRequestsService keeps the requests of people who want to join the room until a moderator decides on them.
+//counterfeiter:generate . JoinRequestsService
+type JoinRequestsService interface {
+	// Create adds a pending request for the feed. It returns the token to check its status with or an error.
+	// If the feed already has a pending request it returns ErrAlreadyAdded.
+	Create(ctx context.Context, ref refs.FeedRef, message string) (string, error)
+
+	// GetByToken returns the request for that status token, or ErrNotFound
+	GetByToken(ctx context.Context, token string) (JoinRequest, error)
+
+	// GetByID returns the request for that ID, or ErrNotFound
+	GetByID(ctx context.Context, id int64) (JoinRequest, error)
+
+	// List returns all the requests, newest first
+	List(ctx context.Context) ([]JoinRequest, error)
+
+	// CountPending returns the number of requests that still need a decision
+	CountPending(ctx context.Context) (uint, error)
+
+	// Approve marks a pending request as approved by the member decidedBy.
+	// Without an invite the feed is added as a member in the same transaction.
+	// It returns ErrNotFound if there is no pending request with that ID and ErrDeniedKey if the feed was denied in the meantime.
+	Approve(ctx context.Context, id, decidedBy int64, withInvite bool) error
+
+	// HandOutInvite creates the personal invite of a request that was approved with one and returns its token.
+	// This only works once, after that or if there is no such request it returns ErrNotFound.
+	// The invite can only be consumed by the feed of the request.
+	HandOutInvite(ctx context.Context, token string) (string, error)
+
+	// Decline marks a pending request as declined by the member decidedBy.
+	// It returns ErrNotFound if there is no pending request with that ID.
+	Decline(ctx context.Context, id, decidedBy int64) error
+}
+
 // PinnedNoticesService allows an admin to assign Notices to specific placeholder pages.
 // like updates, privacy policy, code of conduct
 //counterfeiter:generate . PinnedNoticesService
@@ -188,14 +277,96 @@ type PinnedNoticesService interface {
 	Get(ctx context.Context, name PinnedNoticeName, language string) (*Notice, error)
 }
 
+// PagesService manages the pages that admins add next to the pinned notices.
+// The pinned notices are pages, too, but they can't be changed or removed here.
+//counterfeiter:generate . PagesService
+type PagesService interface {
+	// List returns the added pages with their translations, ordered by their menu position
+	List(context.Context) ([]Page, error)
+
+	// GetByID returns the added page with that ID
+	GetByID(context.Context, int64) (Page, error)
+
+	// GetBySlug returns the page with that slug, which can also be one of the pinned notices
+	GetBySlug(ctx context.Context, slug string) (Page, error)
+
+	// Create adds a new page without translations and returns its ID
+	Create(ctx context.Context, p Page) (int64, error)
+
+	// Update changes the name, slug and menu position of an added page
+	Update(ctx context.Context, p Page) error
+
+	// AddTranslation makes the notice a translation of the page
+	AddTranslation(ctx context.Context, pageID, noticeID int64) error
+
+	// Remove deletes an added page together with its translations
+	Remove(ctx context.Context, id int64) error
+}
+
+// NewsService manages the dated posts of the room news.
+// The notices of the posts are changed through the NoticesService.
+//counterfeiter:generate . NewsService
+type NewsService interface {
+	// List returns the published posts in that language, the newest first. An empty language returns the posts of all languages.
+	List(ctx context.Context, language string) ([]NewsPost, error)
+
+	// GetByID returns the post with that ID
+	GetByID(context.Context, int64) (NewsPost, error)
+
+	// Add publishes the notice as a new post, dated now
+	Add(ctx context.Context, noticeID int64) (NewsPost, error)
+
+	// Create saves the new notice and publishes it as a post, dated now.
+	// Either both are stored or neither of them.
+	Create(ctx context.Context, notice *Notice, author int64) (NewsPost, error)
+
+	// Remove deletes the post together with its notice
+	Remove(ctx context.Context, id int64) error
+}
+
+// CodeOfConductService keeps the versions of the code of conduct and which member accepted which one.
+// The text of the code of conduct is the NoticeCodeOfConduct pinned notice.
+//counterfeiter:generate . CodeOfConductService
+type CodeOfConductService interface {
+	// Current returns the version that the members have to accept. It is zero if there is none.
+	Current(context.Context) (CodeOfConductVersion, error)
+
+	// Publish starts a new version, which all the members have to accept again
+	Publish(context.Context) (CodeOfConductVersion, error)
+
+	// Accept records that the member accepted that version, now.
+	// Only the current version can be accepted, older ones return ErrCodeOfConductNotAccepted.
+	Accept(ctx context.Context, memberID, version int64) error
+
+	// Accepted returns the newest version the member accepted or ErrNotFound if they never did
+	Accepted(ctx context.Context, memberID int64) (CodeOfConductAcceptance, error)
+
+	// ListAccepted returns the newest version each member accepted, by the ID of the member.
+	// Members that never accepted one are not in it.
+	ListAccepted(context.Context) (map[int64]CodeOfConductAcceptance, error)
+}
+
 // NoticesService is the low level store to manage single notices
 //counterfeiter:generate . NoticesService
 type NoticesService interface {
 	// GetByID returns the page for that ID or an error
 	GetByID(context.Context, int64) (Notice, error)
 
-	// Save updates the passed page or creates it if it's ID is zero
-	Save(context.Context, *Notice) error
+	// Save publishes the passed page as a new revision by the author, or creates it if it's ID is zero
+	Save(ctx context.Context, n *Notice, author int64) error
+
+	// SaveDraft stores the passed page as a new draft revision by the author, without changing what the public sees.
+	// If the ID is zero, a new page is created which stays unpublished until one of its revisions is published.
+	SaveDraft(ctx context.Context, n *Notice, author int64) (NoticeRevision, error)
+
+	// ListRevisions returns all the revisions of a page, the newest first
+	ListRevisions(ctx context.Context, noticeID int64) ([]NoticeRevision, error)
+
+	// GetRevision returns a single revision
+	GetRevision(ctx context.Context, id int64) (NoticeRevision, error)
+
+	// Revert publishes the title and content of an older revision again, as a new revision by the author
+	Revert(ctx context.Context, revisionID int64, author int64) error
 
 	// RemoveID removes the page for that ID.
 	RemoveID(context.Context, int64) error
@@ -107,6 +107,19 @@ type FakeMembersService struct {
 	removeIDReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetHiddenStub        func(context.Context, int64, bool) error
+	setHiddenMutex       sync.RWMutex
+	setHiddenArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 bool
+	}
+	setHiddenReturns struct {
+		result1 error
+	}
+	setHiddenReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetRoleStub        func(context.Context, int64, roomdb.Role) error
 	setRoleMutex       sync.RWMutex
 	setRoleArgsForCall []struct {
@@ -572,6 +585,69 @@ func (fake *FakeMembersService) RemoveIDReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeMembersService) SetHidden(arg1 context.Context, arg2 int64, arg3 bool) error {
+	fake.setHiddenMutex.Lock()
+	ret, specificReturn := fake.setHiddenReturnsOnCall[len(fake.setHiddenArgsForCall)]
+	fake.setHiddenArgsForCall = append(fake.setHiddenArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.SetHiddenStub
+	fakeReturns := fake.setHiddenReturns
+	fake.recordInvocation("SetHidden", []interface{}{arg1, arg2, arg3})
+	fake.setHiddenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMembersService) SetHiddenCallCount() int {
+	fake.setHiddenMutex.RLock()
+	defer fake.setHiddenMutex.RUnlock()
+	return len(fake.setHiddenArgsForCall)
+}
+
+func (fake *FakeMembersService) SetHiddenCalls(stub func(context.Context, int64, bool) error) {
+	fake.setHiddenMutex.Lock()
+	defer fake.setHiddenMutex.Unlock()
+	fake.SetHiddenStub = stub
+}
+
+func (fake *FakeMembersService) SetHiddenArgsForCall(i int) (context.Context, int64, bool) {
+	fake.setHiddenMutex.RLock()
+	defer fake.setHiddenMutex.RUnlock()
+	argsForCall := fake.setHiddenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeMembersService) SetHiddenReturns(result1 error) {
+	fake.setHiddenMutex.Lock()
+	defer fake.setHiddenMutex.Unlock()
+	fake.SetHiddenStub = nil
+	fake.setHiddenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMembersService) SetHiddenReturnsOnCall(i int, result1 error) {
+	fake.setHiddenMutex.Lock()
+	defer fake.setHiddenMutex.Unlock()
+	fake.SetHiddenStub = nil
+	if fake.setHiddenReturnsOnCall == nil {
+		fake.setHiddenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setHiddenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeMembersService) SetRole(arg1 context.Context, arg2 int64, arg3 roomdb.Role) error {
 	fake.setRoleMutex.Lock()
 	ret, specificReturn := fake.setRoleReturnsOnCall[len(fake.setRoleArgsForCall)]
@@ -652,6 +728,8 @@ func (fake *FakeMembersService) Invocations() map[string][][]interface{} {
 	defer fake.removeFeedMutex.RUnlock()
 	fake.removeIDMutex.RLock()
 	defer fake.removeIDMutex.RUnlock()
+	fake.setHiddenMutex.RLock()
+	defer fake.setHiddenMutex.RUnlock()
 	fake.setRoleMutex.RLock()
 	defer fake.setRoleMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -27,6 +27,34 @@ type FakeNoticesService struct {
 		result1 roomdb.Notice
 		result2 error
 	}
+	GetRevisionStub        func(context.Context, int64) (roomdb.NoticeRevision, error)
+	getRevisionMutex       sync.RWMutex
+	getRevisionArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	getRevisionReturns struct {
+		result1 roomdb.NoticeRevision
+		result2 error
+	}
+	getRevisionReturnsOnCall map[int]struct {
+		result1 roomdb.NoticeRevision
+		result2 error
+	}
+	ListRevisionsStub        func(context.Context, int64) ([]roomdb.NoticeRevision, error)
+	listRevisionsMutex       sync.RWMutex
+	listRevisionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	listRevisionsReturns struct {
+		result1 []roomdb.NoticeRevision
+		result2 error
+	}
+	listRevisionsReturnsOnCall map[int]struct {
+		result1 []roomdb.NoticeRevision
+		result2 error
+	}
 	RemoveIDStub        func(context.Context, int64) error
 	removeIDMutex       sync.RWMutex
 	removeIDArgsForCall []struct {
@@ -39,11 +67,25 @@ type FakeNoticesService struct {
 	removeIDReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SaveStub        func(context.Context, *roomdb.Notice) error
+	RevertStub        func(context.Context, int64, int64) error
+	revertMutex       sync.RWMutex
+	revertArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 int64
+	}
+	revertReturns struct {
+		result1 error
+	}
+	revertReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveStub        func(context.Context, *roomdb.Notice, int64) error
 	saveMutex       sync.RWMutex
 	saveArgsForCall []struct {
 		arg1 context.Context
 		arg2 *roomdb.Notice
+		arg3 int64
 	}
 	saveReturns struct {
 		result1 error
@@ -51,6 +93,21 @@ type FakeNoticesService struct {
 	saveReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SaveDraftStub        func(context.Context, *roomdb.Notice, int64) (roomdb.NoticeRevision, error)
+	saveDraftMutex       sync.RWMutex
+	saveDraftArgsForCall []struct {
+		arg1 context.Context
+		arg2 *roomdb.Notice
+		arg3 int64
+	}
+	saveDraftReturns struct {
+		result1 roomdb.NoticeRevision
+		result2 error
+	}
+	saveDraftReturnsOnCall map[int]struct {
+		result1 roomdb.NoticeRevision
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -120,6 +177,136 @@ func (fake *FakeNoticesService) GetByIDReturnsOnCall(i int, result1 roomdb.Notic
 	}{result1, result2}
 }
 
+func (fake *FakeNoticesService) GetRevision(arg1 context.Context, arg2 int64) (roomdb.NoticeRevision, error) {
+	fake.getRevisionMutex.Lock()
+	ret, specificReturn := fake.getRevisionReturnsOnCall[len(fake.getRevisionArgsForCall)]
+	fake.getRevisionArgsForCall = append(fake.getRevisionArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetRevisionStub
+	fakeReturns := fake.getRevisionReturns
+	fake.recordInvocation("GetRevision", []interface{}{arg1, arg2})
+	fake.getRevisionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNoticesService) GetRevisionCallCount() int {
+	fake.getRevisionMutex.RLock()
+	defer fake.getRevisionMutex.RUnlock()
+	return len(fake.getRevisionArgsForCall)
+}
+
+func (fake *FakeNoticesService) GetRevisionCalls(stub func(context.Context, int64) (roomdb.NoticeRevision, error)) {
+	fake.getRevisionMutex.Lock()
+	defer fake.getRevisionMutex.Unlock()
+	fake.GetRevisionStub = stub
+}
+
+func (fake *FakeNoticesService) GetRevisionArgsForCall(i int) (context.Context, int64) {
+	fake.getRevisionMutex.RLock()
+	defer fake.getRevisionMutex.RUnlock()
+	argsForCall := fake.getRevisionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNoticesService) GetRevisionReturns(result1 roomdb.NoticeRevision, result2 error) {
+	fake.getRevisionMutex.Lock()
+	defer fake.getRevisionMutex.Unlock()
+	fake.GetRevisionStub = nil
+	fake.getRevisionReturns = struct {
+		result1 roomdb.NoticeRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNoticesService) GetRevisionReturnsOnCall(i int, result1 roomdb.NoticeRevision, result2 error) {
+	fake.getRevisionMutex.Lock()
+	defer fake.getRevisionMutex.Unlock()
+	fake.GetRevisionStub = nil
+	if fake.getRevisionReturnsOnCall == nil {
+		fake.getRevisionReturnsOnCall = make(map[int]struct {
+			result1 roomdb.NoticeRevision
+			result2 error
+		})
+	}
+	fake.getRevisionReturnsOnCall[i] = struct {
+		result1 roomdb.NoticeRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNoticesService) ListRevisions(arg1 context.Context, arg2 int64) ([]roomdb.NoticeRevision, error) {
+	fake.listRevisionsMutex.Lock()
+	ret, specificReturn := fake.listRevisionsReturnsOnCall[len(fake.listRevisionsArgsForCall)]
+	fake.listRevisionsArgsForCall = append(fake.listRevisionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.ListRevisionsStub
+	fakeReturns := fake.listRevisionsReturns
+	fake.recordInvocation("ListRevisions", []interface{}{arg1, arg2})
+	fake.listRevisionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNoticesService) ListRevisionsCallCount() int {
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
+	return len(fake.listRevisionsArgsForCall)
+}
+
+func (fake *FakeNoticesService) ListRevisionsCalls(stub func(context.Context, int64) ([]roomdb.NoticeRevision, error)) {
+	fake.listRevisionsMutex.Lock()
+	defer fake.listRevisionsMutex.Unlock()
+	fake.ListRevisionsStub = stub
+}
+
+func (fake *FakeNoticesService) ListRevisionsArgsForCall(i int) (context.Context, int64) {
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
+	argsForCall := fake.listRevisionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNoticesService) ListRevisionsReturns(result1 []roomdb.NoticeRevision, result2 error) {
+	fake.listRevisionsMutex.Lock()
+	defer fake.listRevisionsMutex.Unlock()
+	fake.ListRevisionsStub = nil
+	fake.listRevisionsReturns = struct {
+		result1 []roomdb.NoticeRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNoticesService) ListRevisionsReturnsOnCall(i int, result1 []roomdb.NoticeRevision, result2 error) {
+	fake.listRevisionsMutex.Lock()
+	defer fake.listRevisionsMutex.Unlock()
+	fake.ListRevisionsStub = nil
+	if fake.listRevisionsReturnsOnCall == nil {
+		fake.listRevisionsReturnsOnCall = make(map[int]struct {
+			result1 []roomdb.NoticeRevision
+			result2 error
+		})
+	}
+	fake.listRevisionsReturnsOnCall[i] = struct {
+		result1 []roomdb.NoticeRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeNoticesService) RemoveID(arg1 context.Context, arg2 int64) error {
 	fake.removeIDMutex.Lock()
 	ret, specificReturn := fake.removeIDReturnsOnCall[len(fake.removeIDArgsForCall)]
@@ -182,19 +369,83 @@ func (fake *FakeNoticesService) RemoveIDReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeNoticesService) Save(arg1 context.Context, arg2 *roomdb.Notice) error {
+func (fake *FakeNoticesService) Revert(arg1 context.Context, arg2 int64, arg3 int64) error {
+	fake.revertMutex.Lock()
+	ret, specificReturn := fake.revertReturnsOnCall[len(fake.revertArgsForCall)]
+	fake.revertArgsForCall = append(fake.revertArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.RevertStub
+	fakeReturns := fake.revertReturns
+	fake.recordInvocation("Revert", []interface{}{arg1, arg2, arg3})
+	fake.revertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeNoticesService) RevertCallCount() int {
+	fake.revertMutex.RLock()
+	defer fake.revertMutex.RUnlock()
+	return len(fake.revertArgsForCall)
+}
+
+func (fake *FakeNoticesService) RevertCalls(stub func(context.Context, int64, int64) error) {
+	fake.revertMutex.Lock()
+	defer fake.revertMutex.Unlock()
+	fake.RevertStub = stub
+}
+
+func (fake *FakeNoticesService) RevertArgsForCall(i int) (context.Context, int64, int64) {
+	fake.revertMutex.RLock()
+	defer fake.revertMutex.RUnlock()
+	argsForCall := fake.revertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNoticesService) RevertReturns(result1 error) {
+	fake.revertMutex.Lock()
+	defer fake.revertMutex.Unlock()
+	fake.RevertStub = nil
+	fake.revertReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNoticesService) RevertReturnsOnCall(i int, result1 error) {
+	fake.revertMutex.Lock()
+	defer fake.revertMutex.Unlock()
+	fake.RevertStub = nil
+	if fake.revertReturnsOnCall == nil {
+		fake.revertReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revertReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNoticesService) Save(arg1 context.Context, arg2 *roomdb.Notice, arg3 int64) error {
 	fake.saveMutex.Lock()
 	ret, specificReturn := fake.saveReturnsOnCall[len(fake.saveArgsForCall)]
 	fake.saveArgsForCall = append(fake.saveArgsForCall, struct {
 		arg1 context.Context
 		arg2 *roomdb.Notice
-	}{arg1, arg2})
+		arg3 int64
+	}{arg1, arg2, arg3})
 	stub := fake.SaveStub
 	fakeReturns := fake.saveReturns
-	fake.recordInvocation("Save", []interface{}{arg1, arg2})
+	fake.recordInvocation("Save", []interface{}{arg1, arg2, arg3})
 	fake.saveMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -208,17 +459,17 @@ func (fake *FakeNoticesService) SaveCallCount() int {
 	return len(fake.saveArgsForCall)
 }
 
-func (fake *FakeNoticesService) SaveCalls(stub func(context.Context, *roomdb.Notice) error) {
+func (fake *FakeNoticesService) SaveCalls(stub func(context.Context, *roomdb.Notice, int64) error) {
 	fake.saveMutex.Lock()
 	defer fake.saveMutex.Unlock()
 	fake.SaveStub = stub
 }
 
-func (fake *FakeNoticesService) SaveArgsForCall(i int) (context.Context, *roomdb.Notice) {
+func (fake *FakeNoticesService) SaveArgsForCall(i int) (context.Context, *roomdb.Notice, int64) {
 	fake.saveMutex.RLock()
 	defer fake.saveMutex.RUnlock()
 	argsForCall := fake.saveArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeNoticesService) SaveReturns(result1 error) {
@@ -244,15 +495,89 @@ func (fake *FakeNoticesService) SaveReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeNoticesService) SaveDraft(arg1 context.Context, arg2 *roomdb.Notice, arg3 int64) (roomdb.NoticeRevision, error) {
+	fake.saveDraftMutex.Lock()
+	ret, specificReturn := fake.saveDraftReturnsOnCall[len(fake.saveDraftArgsForCall)]
+	fake.saveDraftArgsForCall = append(fake.saveDraftArgsForCall, struct {
+		arg1 context.Context
+		arg2 *roomdb.Notice
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.SaveDraftStub
+	fakeReturns := fake.saveDraftReturns
+	fake.recordInvocation("SaveDraft", []interface{}{arg1, arg2, arg3})
+	fake.saveDraftMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNoticesService) SaveDraftCallCount() int {
+	fake.saveDraftMutex.RLock()
+	defer fake.saveDraftMutex.RUnlock()
+	return len(fake.saveDraftArgsForCall)
+}
+
+func (fake *FakeNoticesService) SaveDraftCalls(stub func(context.Context, *roomdb.Notice, int64) (roomdb.NoticeRevision, error)) {
+	fake.saveDraftMutex.Lock()
+	defer fake.saveDraftMutex.Unlock()
+	fake.SaveDraftStub = stub
+}
+
+func (fake *FakeNoticesService) SaveDraftArgsForCall(i int) (context.Context, *roomdb.Notice, int64) {
+	fake.saveDraftMutex.RLock()
+	defer fake.saveDraftMutex.RUnlock()
+	argsForCall := fake.saveDraftArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNoticesService) SaveDraftReturns(result1 roomdb.NoticeRevision, result2 error) {
+	fake.saveDraftMutex.Lock()
+	defer fake.saveDraftMutex.Unlock()
+	fake.SaveDraftStub = nil
+	fake.saveDraftReturns = struct {
+		result1 roomdb.NoticeRevision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNoticesService) SaveDraftReturnsOnCall(i int, result1 roomdb.NoticeRevision, result2 error) {
+	fake.saveDraftMutex.Lock()
+	defer fake.saveDraftMutex.Unlock()
+	fake.SaveDraftStub = nil
+	if fake.saveDraftReturnsOnCall == nil {
+		fake.saveDraftReturnsOnCall = make(map[int]struct {
+			result1 roomdb.NoticeRevision
+			result2 error
+		})
+	}
+	fake.saveDraftReturnsOnCall[i] = struct {
+		result1 roomdb.NoticeRevision
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeNoticesService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getByIDMutex.RLock()
 	defer fake.getByIDMutex.RUnlock()
+	fake.getRevisionMutex.RLock()
+	defer fake.getRevisionMutex.RUnlock()
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
 	fake.removeIDMutex.RLock()
 	defer fake.removeIDMutex.RUnlock()
+	fake.revertMutex.RLock()
+	defer fake.revertMutex.RUnlock()
 	fake.saveMutex.RLock()
 	defer fake.saveMutex.RUnlock()
+	fake.saveDraftMutex.RLock()
+	defer fake.saveDraftMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -13,6 +13,19 @@ import (
 )
 
 type FakeRoomConfig struct {
+	GetBrandingStub        func(context.Context) (roomdb.Branding, error)
+	getBrandingMutex       sync.RWMutex
+	getBrandingArgsForCall []struct {
+		arg1 context.Context
+	}
+	getBrandingReturns struct {
+		result1 roomdb.Branding
+		result2 error
+	}
+	getBrandingReturnsOnCall map[int]struct {
+		result1 roomdb.Branding
+		result2 error
+	}
 	GetDefaultLanguageStub        func(context.Context) (string, error)
 	getDefaultLanguageMutex       sync.RWMutex
 	getDefaultLanguageArgsForCall []struct {
@@ -26,6 +39,32 @@ type FakeRoomConfig struct {
 		result1 string
 		result2 error
 	}
+	GetLogoStub        func(context.Context) (roomdb.Logo, error)
+	getLogoMutex       sync.RWMutex
+	getLogoArgsForCall []struct {
+		arg1 context.Context
+	}
+	getLogoReturns struct {
+		result1 roomdb.Logo
+		result2 error
+	}
+	getLogoReturnsOnCall map[int]struct {
+		result1 roomdb.Logo
+		result2 error
+	}
+	GetOpenInviteLimitsStub        func(context.Context) (roomdb.OpenInviteLimits, error)
+	getOpenInviteLimitsMutex       sync.RWMutex
+	getOpenInviteLimitsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getOpenInviteLimitsReturns struct {
+		result1 roomdb.OpenInviteLimits
+		result2 error
+	}
+	getOpenInviteLimitsReturnsOnCall map[int]struct {
+		result1 roomdb.OpenInviteLimits
+		result2 error
+	}
 	GetPrivacyModeStub        func(context.Context) (roomdb.PrivacyMode, error)
 	getPrivacyModeMutex       sync.RWMutex
 	getPrivacyModeArgsForCall []struct {
@@ -39,6 +78,29 @@ type FakeRoomConfig struct {
 		result1 roomdb.PrivacyMode
 		result2 error
 	}
+	RemoveLogoStub        func(context.Context) error
+	removeLogoMutex       sync.RWMutex
+	removeLogoArgsForCall []struct {
+		arg1 context.Context
+	}
+	removeLogoReturns struct {
+		result1 error
+	}
+	removeLogoReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetBrandingStub        func(context.Context, roomdb.Branding) error
+	setBrandingMutex       sync.RWMutex
+	setBrandingArgsForCall []struct {
+		arg1 context.Context
+		arg2 roomdb.Branding
+	}
+	setBrandingReturns struct {
+		result1 error
+	}
+	setBrandingReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetDefaultLanguageStub        func(context.Context, string) error
 	setDefaultLanguageMutex       sync.RWMutex
 	setDefaultLanguageArgsForCall []struct {
@@ -51,6 +113,30 @@ type FakeRoomConfig struct {
 	setDefaultLanguageReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetLogoStub        func(context.Context, roomdb.Logo) error
+	setLogoMutex       sync.RWMutex
+	setLogoArgsForCall []struct {
+		arg1 context.Context
+		arg2 roomdb.Logo
+	}
+	setLogoReturns struct {
+		result1 error
+	}
+	setLogoReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetOpenInviteLimitsStub        func(context.Context, roomdb.OpenInviteLimits) error
+	setOpenInviteLimitsMutex       sync.RWMutex
+	setOpenInviteLimitsArgsForCall []struct {
+		arg1 context.Context
+		arg2 roomdb.OpenInviteLimits
+	}
+	setOpenInviteLimitsReturns struct {
+		result1 error
+	}
+	setOpenInviteLimitsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetPrivacyModeStub        func(context.Context, roomdb.PrivacyMode) error
 	setPrivacyModeMutex       sync.RWMutex
 	setPrivacyModeArgsForCall []struct {
@@ -67,6 +153,70 @@ type FakeRoomConfig struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeRoomConfig) GetBranding(arg1 context.Context) (roomdb.Branding, error) {
+	fake.getBrandingMutex.Lock()
+	ret, specificReturn := fake.getBrandingReturnsOnCall[len(fake.getBrandingArgsForCall)]
+	fake.getBrandingArgsForCall = append(fake.getBrandingArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetBrandingStub
+	fakeReturns := fake.getBrandingReturns
+	fake.recordInvocation("GetBranding", []interface{}{arg1})
+	fake.getBrandingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRoomConfig) GetBrandingCallCount() int {
+	fake.getBrandingMutex.RLock()
+	defer fake.getBrandingMutex.RUnlock()
+	return len(fake.getBrandingArgsForCall)
+}
+
+func (fake *FakeRoomConfig) GetBrandingCalls(stub func(context.Context) (roomdb.Branding, error)) {
+	fake.getBrandingMutex.Lock()
+	defer fake.getBrandingMutex.Unlock()
+	fake.GetBrandingStub = stub
+}
+
+func (fake *FakeRoomConfig) GetBrandingArgsForCall(i int) context.Context {
+	fake.getBrandingMutex.RLock()
+	defer fake.getBrandingMutex.RUnlock()
+	argsForCall := fake.getBrandingArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRoomConfig) GetBrandingReturns(result1 roomdb.Branding, result2 error) {
+	fake.getBrandingMutex.Lock()
+	defer fake.getBrandingMutex.Unlock()
+	fake.GetBrandingStub = nil
+	fake.getBrandingReturns = struct {
+		result1 roomdb.Branding
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRoomConfig) GetBrandingReturnsOnCall(i int, result1 roomdb.Branding, result2 error) {
+	fake.getBrandingMutex.Lock()
+	defer fake.getBrandingMutex.Unlock()
+	fake.GetBrandingStub = nil
+	if fake.getBrandingReturnsOnCall == nil {
+		fake.getBrandingReturnsOnCall = make(map[int]struct {
+			result1 roomdb.Branding
+			result2 error
+		})
+	}
+	fake.getBrandingReturnsOnCall[i] = struct {
+		result1 roomdb.Branding
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRoomConfig) GetDefaultLanguage(arg1 context.Context) (string, error) {
 	fake.getDefaultLanguageMutex.Lock()
 	ret, specificReturn := fake.getDefaultLanguageReturnsOnCall[len(fake.getDefaultLanguageArgsForCall)]
@@ -131,6 +281,134 @@ func (fake *FakeRoomConfig) GetDefaultLanguageReturnsOnCall(i int, result1 strin
 	}{result1, result2}
 }
 
+func (fake *FakeRoomConfig) GetLogo(arg1 context.Context) (roomdb.Logo, error) {
+	fake.getLogoMutex.Lock()
+	ret, specificReturn := fake.getLogoReturnsOnCall[len(fake.getLogoArgsForCall)]
+	fake.getLogoArgsForCall = append(fake.getLogoArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetLogoStub
+	fakeReturns := fake.getLogoReturns
+	fake.recordInvocation("GetLogo", []interface{}{arg1})
+	fake.getLogoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRoomConfig) GetLogoCallCount() int {
+	fake.getLogoMutex.RLock()
+	defer fake.getLogoMutex.RUnlock()
+	return len(fake.getLogoArgsForCall)
+}
+
+func (fake *FakeRoomConfig) GetLogoCalls(stub func(context.Context) (roomdb.Logo, error)) {
+	fake.getLogoMutex.Lock()
+	defer fake.getLogoMutex.Unlock()
+	fake.GetLogoStub = stub
+}
+
+func (fake *FakeRoomConfig) GetLogoArgsForCall(i int) context.Context {
+	fake.getLogoMutex.RLock()
+	defer fake.getLogoMutex.RUnlock()
+	argsForCall := fake.getLogoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRoomConfig) GetLogoReturns(result1 roomdb.Logo, result2 error) {
+	fake.getLogoMutex.Lock()
+	defer fake.getLogoMutex.Unlock()
+	fake.GetLogoStub = nil
+	fake.getLogoReturns = struct {
+		result1 roomdb.Logo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRoomConfig) GetLogoReturnsOnCall(i int, result1 roomdb.Logo, result2 error) {
+	fake.getLogoMutex.Lock()
+	defer fake.getLogoMutex.Unlock()
+	fake.GetLogoStub = nil
+	if fake.getLogoReturnsOnCall == nil {
+		fake.getLogoReturnsOnCall = make(map[int]struct {
+			result1 roomdb.Logo
+			result2 error
+		})
+	}
+	fake.getLogoReturnsOnCall[i] = struct {
+		result1 roomdb.Logo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRoomConfig) GetOpenInviteLimits(arg1 context.Context) (roomdb.OpenInviteLimits, error) {
+	fake.getOpenInviteLimitsMutex.Lock()
+	ret, specificReturn := fake.getOpenInviteLimitsReturnsOnCall[len(fake.getOpenInviteLimitsArgsForCall)]
+	fake.getOpenInviteLimitsArgsForCall = append(fake.getOpenInviteLimitsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetOpenInviteLimitsStub
+	fakeReturns := fake.getOpenInviteLimitsReturns
+	fake.recordInvocation("GetOpenInviteLimits", []interface{}{arg1})
+	fake.getOpenInviteLimitsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRoomConfig) GetOpenInviteLimitsCallCount() int {
+	fake.getOpenInviteLimitsMutex.RLock()
+	defer fake.getOpenInviteLimitsMutex.RUnlock()
+	return len(fake.getOpenInviteLimitsArgsForCall)
+}
+
+func (fake *FakeRoomConfig) GetOpenInviteLimitsCalls(stub func(context.Context) (roomdb.OpenInviteLimits, error)) {
+	fake.getOpenInviteLimitsMutex.Lock()
+	defer fake.getOpenInviteLimitsMutex.Unlock()
+	fake.GetOpenInviteLimitsStub = stub
+}
+
+func (fake *FakeRoomConfig) GetOpenInviteLimitsArgsForCall(i int) context.Context {
+	fake.getOpenInviteLimitsMutex.RLock()
+	defer fake.getOpenInviteLimitsMutex.RUnlock()
+	argsForCall := fake.getOpenInviteLimitsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRoomConfig) GetOpenInviteLimitsReturns(result1 roomdb.OpenInviteLimits, result2 error) {
+	fake.getOpenInviteLimitsMutex.Lock()
+	defer fake.getOpenInviteLimitsMutex.Unlock()
+	fake.GetOpenInviteLimitsStub = nil
+	fake.getOpenInviteLimitsReturns = struct {
+		result1 roomdb.OpenInviteLimits
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRoomConfig) GetOpenInviteLimitsReturnsOnCall(i int, result1 roomdb.OpenInviteLimits, result2 error) {
+	fake.getOpenInviteLimitsMutex.Lock()
+	defer fake.getOpenInviteLimitsMutex.Unlock()
+	fake.GetOpenInviteLimitsStub = nil
+	if fake.getOpenInviteLimitsReturnsOnCall == nil {
+		fake.getOpenInviteLimitsReturnsOnCall = make(map[int]struct {
+			result1 roomdb.OpenInviteLimits
+			result2 error
+		})
+	}
+	fake.getOpenInviteLimitsReturnsOnCall[i] = struct {
+		result1 roomdb.OpenInviteLimits
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRoomConfig) GetPrivacyMode(arg1 context.Context) (roomdb.PrivacyMode, error) {
 	fake.getPrivacyModeMutex.Lock()
 	ret, specificReturn := fake.getPrivacyModeReturnsOnCall[len(fake.getPrivacyModeArgsForCall)]
@@ -195,6 +473,129 @@ func (fake *FakeRoomConfig) GetPrivacyModeReturnsOnCall(i int, result1 roomdb.Pr
 	}{result1, result2}
 }
 
+func (fake *FakeRoomConfig) RemoveLogo(arg1 context.Context) error {
+	fake.removeLogoMutex.Lock()
+	ret, specificReturn := fake.removeLogoReturnsOnCall[len(fake.removeLogoArgsForCall)]
+	fake.removeLogoArgsForCall = append(fake.removeLogoArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.RemoveLogoStub
+	fakeReturns := fake.removeLogoReturns
+	fake.recordInvocation("RemoveLogo", []interface{}{arg1})
+	fake.removeLogoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRoomConfig) RemoveLogoCallCount() int {
+	fake.removeLogoMutex.RLock()
+	defer fake.removeLogoMutex.RUnlock()
+	return len(fake.removeLogoArgsForCall)
+}
+
+func (fake *FakeRoomConfig) RemoveLogoCalls(stub func(context.Context) error) {
+	fake.removeLogoMutex.Lock()
+	defer fake.removeLogoMutex.Unlock()
+	fake.RemoveLogoStub = stub
+}
+
+func (fake *FakeRoomConfig) RemoveLogoArgsForCall(i int) context.Context {
+	fake.removeLogoMutex.RLock()
+	defer fake.removeLogoMutex.RUnlock()
+	argsForCall := fake.removeLogoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRoomConfig) RemoveLogoReturns(result1 error) {
+	fake.removeLogoMutex.Lock()
+	defer fake.removeLogoMutex.Unlock()
+	fake.RemoveLogoStub = nil
+	fake.removeLogoReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRoomConfig) RemoveLogoReturnsOnCall(i int, result1 error) {
+	fake.removeLogoMutex.Lock()
+	defer fake.removeLogoMutex.Unlock()
+	fake.RemoveLogoStub = nil
+	if fake.removeLogoReturnsOnCall == nil {
+		fake.removeLogoReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeLogoReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRoomConfig) SetBranding(arg1 context.Context, arg2 roomdb.Branding) error {
+	fake.setBrandingMutex.Lock()
+	ret, specificReturn := fake.setBrandingReturnsOnCall[len(fake.setBrandingArgsForCall)]
+	fake.setBrandingArgsForCall = append(fake.setBrandingArgsForCall, struct {
+		arg1 context.Context
+		arg2 roomdb.Branding
+	}{arg1, arg2})
+	stub := fake.SetBrandingStub
+	fakeReturns := fake.setBrandingReturns
+	fake.recordInvocation("SetBranding", []interface{}{arg1, arg2})
+	fake.setBrandingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRoomConfig) SetBrandingCallCount() int {
+	fake.setBrandingMutex.RLock()
+	defer fake.setBrandingMutex.RUnlock()
+	return len(fake.setBrandingArgsForCall)
+}
+
+func (fake *FakeRoomConfig) SetBrandingCalls(stub func(context.Context, roomdb.Branding) error) {
+	fake.setBrandingMutex.Lock()
+	defer fake.setBrandingMutex.Unlock()
+	fake.SetBrandingStub = stub
+}
+
+func (fake *FakeRoomConfig) SetBrandingArgsForCall(i int) (context.Context, roomdb.Branding) {
+	fake.setBrandingMutex.RLock()
+	defer fake.setBrandingMutex.RUnlock()
+	argsForCall := fake.setBrandingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRoomConfig) SetBrandingReturns(result1 error) {
+	fake.setBrandingMutex.Lock()
+	defer fake.setBrandingMutex.Unlock()
+	fake.SetBrandingStub = nil
+	fake.setBrandingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRoomConfig) SetBrandingReturnsOnCall(i int, result1 error) {
+	fake.setBrandingMutex.Lock()
+	defer fake.setBrandingMutex.Unlock()
+	fake.SetBrandingStub = nil
+	if fake.setBrandingReturnsOnCall == nil {
+		fake.setBrandingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setBrandingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRoomConfig) SetDefaultLanguage(arg1 context.Context, arg2 string) error {
 	fake.setDefaultLanguageMutex.Lock()
 	ret, specificReturn := fake.setDefaultLanguageReturnsOnCall[len(fake.setDefaultLanguageArgsForCall)]
@@ -257,6 +658,130 @@ func (fake *FakeRoomConfig) SetDefaultLanguageReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
+func (fake *FakeRoomConfig) SetLogo(arg1 context.Context, arg2 roomdb.Logo) error {
+	fake.setLogoMutex.Lock()
+	ret, specificReturn := fake.setLogoReturnsOnCall[len(fake.setLogoArgsForCall)]
+	fake.setLogoArgsForCall = append(fake.setLogoArgsForCall, struct {
+		arg1 context.Context
+		arg2 roomdb.Logo
+	}{arg1, arg2})
+	stub := fake.SetLogoStub
+	fakeReturns := fake.setLogoReturns
+	fake.recordInvocation("SetLogo", []interface{}{arg1, arg2})
+	fake.setLogoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRoomConfig) SetLogoCallCount() int {
+	fake.setLogoMutex.RLock()
+	defer fake.setLogoMutex.RUnlock()
+	return len(fake.setLogoArgsForCall)
+}
+
+func (fake *FakeRoomConfig) SetLogoCalls(stub func(context.Context, roomdb.Logo) error) {
+	fake.setLogoMutex.Lock()
+	defer fake.setLogoMutex.Unlock()
+	fake.SetLogoStub = stub
+}
+
+func (fake *FakeRoomConfig) SetLogoArgsForCall(i int) (context.Context, roomdb.Logo) {
+	fake.setLogoMutex.RLock()
+	defer fake.setLogoMutex.RUnlock()
+	argsForCall := fake.setLogoArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRoomConfig) SetLogoReturns(result1 error) {
+	fake.setLogoMutex.Lock()
+	defer fake.setLogoMutex.Unlock()
+	fake.SetLogoStub = nil
+	fake.setLogoReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRoomConfig) SetLogoReturnsOnCall(i int, result1 error) {
+	fake.setLogoMutex.Lock()
+	defer fake.setLogoMutex.Unlock()
+	fake.SetLogoStub = nil
+	if fake.setLogoReturnsOnCall == nil {
+		fake.setLogoReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setLogoReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRoomConfig) SetOpenInviteLimits(arg1 context.Context, arg2 roomdb.OpenInviteLimits) error {
+	fake.setOpenInviteLimitsMutex.Lock()
+	ret, specificReturn := fake.setOpenInviteLimitsReturnsOnCall[len(fake.setOpenInviteLimitsArgsForCall)]
+	fake.setOpenInviteLimitsArgsForCall = append(fake.setOpenInviteLimitsArgsForCall, struct {
+		arg1 context.Context
+		arg2 roomdb.OpenInviteLimits
+	}{arg1, arg2})
+	stub := fake.SetOpenInviteLimitsStub
+	fakeReturns := fake.setOpenInviteLimitsReturns
+	fake.recordInvocation("SetOpenInviteLimits", []interface{}{arg1, arg2})
+	fake.setOpenInviteLimitsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRoomConfig) SetOpenInviteLimitsCallCount() int {
+	fake.setOpenInviteLimitsMutex.RLock()
+	defer fake.setOpenInviteLimitsMutex.RUnlock()
+	return len(fake.setOpenInviteLimitsArgsForCall)
+}
+
+func (fake *FakeRoomConfig) SetOpenInviteLimitsCalls(stub func(context.Context, roomdb.OpenInviteLimits) error) {
+	fake.setOpenInviteLimitsMutex.Lock()
+	defer fake.setOpenInviteLimitsMutex.Unlock()
+	fake.SetOpenInviteLimitsStub = stub
+}
+
+func (fake *FakeRoomConfig) SetOpenInviteLimitsArgsForCall(i int) (context.Context, roomdb.OpenInviteLimits) {
+	fake.setOpenInviteLimitsMutex.RLock()
+	defer fake.setOpenInviteLimitsMutex.RUnlock()
+	argsForCall := fake.setOpenInviteLimitsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRoomConfig) SetOpenInviteLimitsReturns(result1 error) {
+	fake.setOpenInviteLimitsMutex.Lock()
+	defer fake.setOpenInviteLimitsMutex.Unlock()
+	fake.SetOpenInviteLimitsStub = nil
+	fake.setOpenInviteLimitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRoomConfig) SetOpenInviteLimitsReturnsOnCall(i int, result1 error) {
+	fake.setOpenInviteLimitsMutex.Lock()
+	defer fake.setOpenInviteLimitsMutex.Unlock()
+	fake.SetOpenInviteLimitsStub = nil
+	if fake.setOpenInviteLimitsReturnsOnCall == nil {
+		fake.setOpenInviteLimitsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setOpenInviteLimitsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRoomConfig) SetPrivacyMode(arg1 context.Context, arg2 roomdb.PrivacyMode) error {
 	fake.setPrivacyModeMutex.Lock()
 	ret, specificReturn := fake.setPrivacyModeReturnsOnCall[len(fake.setPrivacyModeArgsForCall)]
@@ -322,12 +847,26 @@ func (fake *FakeRoomConfig) SetPrivacyModeReturnsOnCall(i int, result1 error) {
 func (fake *FakeRoomConfig) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getBrandingMutex.RLock()
+	defer fake.getBrandingMutex.RUnlock()
 	fake.getDefaultLanguageMutex.RLock()
 	defer fake.getDefaultLanguageMutex.RUnlock()
+	fake.getLogoMutex.RLock()
+	defer fake.getLogoMutex.RUnlock()
+	fake.getOpenInviteLimitsMutex.RLock()
+	defer fake.getOpenInviteLimitsMutex.RUnlock()
 	fake.getPrivacyModeMutex.RLock()
 	defer fake.getPrivacyModeMutex.RUnlock()
+	fake.removeLogoMutex.RLock()
+	defer fake.removeLogoMutex.RUnlock()
+	fake.setBrandingMutex.RLock()
+	defer fake.setBrandingMutex.RUnlock()
 	fake.setDefaultLanguageMutex.RLock()
 	defer fake.setDefaultLanguageMutex.RUnlock()
+	fake.setLogoMutex.RLock()
+	defer fake.setLogoMutex.RUnlock()
+	fake.setOpenInviteLimitsMutex.RLock()
+	defer fake.setOpenInviteLimitsMutex.RUnlock()
 	fake.setPrivacyModeMutex.RLock()
 	defer fake.setPrivacyModeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite/models"
+)
+
+// compiler assertion to ensure the struct fullfills the interface
+var _ roomdb.CodeOfConductService = (*CodeOfConduct)(nil)
+
+// CodeOfConduct keeps the versions of the code of conduct and who accepted them
+type CodeOfConduct struct {
+	db *sql.DB
+}
+
+// Current returns the newest version, or version zero if there is none
+func (c CodeOfConduct) Current(ctx context.Context) (roomdb.CodeOfConductVersion, error) {
+	return currentCodeOfConduct(ctx, c.db)
+}
+
+func currentCodeOfConduct(ctx context.Context, exec boil.ContextExecutor) (roomdb.CodeOfConductVersion, error) {
+	entry, err := models.CodeOfConductVersions(qm.OrderBy("id DESC")).One(ctx, exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.CodeOfConductVersion{}, nil
+		}
+		return roomdb.CodeOfConductVersion{}, err
+	}
+
+	return roomdb.CodeOfConductVersion{
+		Version:   entry.ID,
+		CreatedAt: entry.CreatedAt,
+	}, nil
+}
+
+// Publish starts a new version which all the members have to accept again
+func (c CodeOfConduct) Publish(ctx context.Context) (roomdb.CodeOfConductVersion, error) {
+	var entry models.CodeOfConductVersion
+	entry.CreatedAt = time.Now()
+
+	err := entry.Insert(ctx, c.db, boil.Whitelist("created_at"))
+	if err != nil {
+		return roomdb.CodeOfConductVersion{}, err
+	}
+
+	return roomdb.CodeOfConductVersion{
+		Version:   entry.ID,
+		CreatedAt: entry.CreatedAt,
+	}, nil
+}
+
+// Accept records that the member accepted the current version
+func (c CodeOfConduct) Accept(ctx context.Context, memberID, version int64) error {
+	return transact(c.db, func(tx *sql.Tx) error {
+		current, err := currentCodeOfConduct(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if current.Version == 0 || version != current.Version {
+			return roomdb.ErrCodeOfConductNotAccepted{Current: current.Version}
+		}
+
+		_, err = models.FindMember(ctx, tx, memberID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return roomdb.ErrNotFound
+			}
+			return err
+		}
+
+		var entry models.CodeOfConductAcceptance
+		entry.MemberID = memberID
+		entry.Version = version
+		entry.AcceptedAt = time.Now()
+
+		err = entry.Insert(ctx, tx, boil.Whitelist("member_id", "version", "accepted_at"))
+		if err != nil && isUniqueViolation(err) {
+			// accepting the same version twice is fine
+			return nil
+		}
+		return err
+	})
+}
+
+// Accepted returns the newest version that the member accepted
+func (c CodeOfConduct) Accepted(ctx context.Context, memberID int64) (roomdb.CodeOfConductAcceptance, error) {
+	entry, err := models.CodeOfConductAcceptances(
+		qm.Where("member_id = ?", memberID),
+		qm.OrderBy("version DESC"),
+	).One(ctx, c.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.CodeOfConductAcceptance{}, roomdb.ErrNotFound
+		}
+		return roomdb.CodeOfConductAcceptance{}, err
+	}
+
+	return convertCodeOfConductAcceptance(entry), nil
+}
+
+// ListAccepted returns the newest version that each member accepted
+func (c CodeOfConduct) ListAccepted(ctx context.Context) (map[int64]roomdb.CodeOfConductAcceptance, error) {
+	entries, err := models.CodeOfConductAcceptances(qm.OrderBy("version ASC")).All(ctx, c.db)
+	if err != nil {
+		return nil, err
+	}
+
+	// the newer versions overwrite the older ones
+	accepted := make(map[int64]roomdb.CodeOfConductAcceptance)
+	for _, entry := range entries {
+		accepted[entry.MemberID] = convertCodeOfConductAcceptance(entry)
+	}
+	return accepted, nil
+}
+
+func convertCodeOfConductAcceptance(entry *models.CodeOfConductAcceptance) roomdb.CodeOfConductAcceptance {
+	return roomdb.CodeOfConductAcceptance{
+		MemberID:   entry.MemberID,
+		Version:    entry.Version,
+		AcceptedAt: entry.AcceptedAt,
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+)
+
+func TestCodeOfConduct(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	r.NoError(err)
+	defer db.Close()
+
+	// nothing has to be accepted until the first version is published
+	current, err := db.CodeOfConduct.Current(ctx)
+	r.NoError(err)
+	r.EqualValues(0, current.Version)
+
+	current, err = db.CodeOfConduct.Publish(ctx)
+	r.NoError(err)
+	r.EqualValues(1, current.Version)
+
+	alf, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("alf!"), 8), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	alfID, err := db.Members.Add(ctx, alf, roomdb.RoleMember)
+	r.NoError(err)
+
+	bob, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("bob!"), 8), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	bobID, err := db.Members.Add(ctx, bob, roomdb.RoleMember)
+	r.NoError(err)
+
+	_, err = db.CodeOfConduct.Accepted(ctx, alfID)
+	r.True(errors.Is(err, roomdb.ErrNotFound))
+
+	r.NoError(db.CodeOfConduct.Accept(ctx, alfID, 1))
+	r.NoError(db.CodeOfConduct.Accept(ctx, alfID, 1), "accepting twice is fine")
+	r.NoError(db.CodeOfConduct.Accept(ctx, bobID, 1))
+
+	err = db.CodeOfConduct.Accept(ctx, 9999, 1)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "unknown member: %v", err)
+
+	accepted, err := db.CodeOfConduct.Accepted(ctx, alfID)
+	r.NoError(err)
+	r.EqualValues(1, accepted.Version)
+	r.False(accepted.AcceptedAt.IsZero())
+
+	// a new version has to be accepted again
+	current, err = db.CodeOfConduct.Publish(ctx)
+	r.NoError(err)
+	r.EqualValues(2, current.Version)
+
+	err = db.CodeOfConduct.Accept(ctx, bobID, 1)
+	var notAccepted roomdb.ErrCodeOfConductNotAccepted
+	r.True(errors.As(err, &notAccepted), "accepted an old version: %v", err)
+	r.EqualValues(2, notAccepted.Current)
+
+	r.NoError(db.CodeOfConduct.Accept(ctx, bobID, 2))
+
+	lst, err := db.CodeOfConduct.ListAccepted(ctx)
+	r.NoError(err)
+	r.Len(lst, 2)
+	r.EqualValues(1, lst[alfID].Version)
+	r.EqualValues(2, lst[bobID].Version)
+
+	// removing the member removes what they accepted
+	r.NoError(db.Members.RemoveID(ctx, bobID))
+	lst, err = db.CodeOfConduct.ListAccepted(ctx)
+	r.NoError(err)
+	r.Len(lst, 1)
+}
@@ -43,8 +43,7 @@ func (i Invites) Create(ctx context.Context, createdBy int64) (string, error) {
 		CreatedBy: createdBy,
 	}
 
-	tokenBytes := make([]byte, inviteTokenLength)
-
+	var token string
 	err := transact(i.db, func(tx *sql.Tx) error {
 
 		if createdBy == -1 {
@@ -68,43 +67,48 @@ func (i Invites) Create(ctx context.Context, createdBy int64) (string, error) {
 			newInvite.CreatedBy = m.ID
 		}
 
-		inserted := false
-	trying:
-		for tries := 100; tries > 0; tries-- {
-			// generate an invite code
-			rand.Read(tokenBytes)
+		var err error
+		token, err = insertInvite(ctx, tx, &newInvite)
+		return err
+	})
 
-			// hash the binary of the token for storage
-			h := sha256.New()
-			h.Write(tokenBytes)
-			newInvite.HashedToken = fmt.Sprintf("%x", h.Sum(nil))
+	if err != nil {
+		return "", err
+	}
 
-			// insert the new invite
-			err := newInvite.Insert(ctx, tx, boil.Infer())
-			if err != nil {
-				var sqlErr *sqlite.Error
-				if errors.As(err, &sqlErr) && sqlErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
-					// generated an existing token, retry
-					continue trying
-				}
-				return err
-			}
-			inserted = true
-			break // no error means it worked!
-		}
+	return token, nil
+}
 
-		if !inserted {
-			return errors.New("roomdb: failed to generate an invite token in a reasonable amount of time")
-		}
+// insertInvite stores the invite with a new random token and returns that token, base64 URL encoded.
+// no receiver name because it needs to use the passed transaction
+func insertInvite(ctx context.Context, tx *sql.Tx, newInvite *models.Invite) (string, error) {
+	tokenBytes := make([]byte, inviteTokenLength)
 
-		return nil
-	})
+	for tries := 100; tries > 0; tries-- {
+		// generate an invite code
+		rand.Read(tokenBytes)
 
-	if err != nil {
-		return "", err
+		// hash the binary of the token for storage
+		h := sha256.New()
+		h.Write(tokenBytes)
+		newInvite.HashedToken = fmt.Sprintf("%x", h.Sum(nil))
+
+		// insert the new invite
+		err := newInvite.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			var sqlErr *sqlite.Error
+			if errors.As(err, &sqlErr) && sqlErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+				// generated an existing token, retry
+				continue
+			}
+			return "", err
+		}
+
+		// no error means it worked!
+		return base64.URLEncoding.EncodeToString(tokenBytes), nil
 	}
 
-	return base64.URLEncoding.EncodeToString(tokenBytes), nil
+	return "", errors.New("roomdb: failed to generate an invite token in a reasonable amount of time")
 }
 
 // Consume checks if the passed token is still valid. If it is it adds newMember to the members of the room and invalidates the token.
@@ -130,6 +134,16 @@ func (i Invites) Consume(ctx context.Context, token string, newMember refs.FeedR
 			return err
 		}
 
+		// the invite of a join request is only for the feed that asked
+		jr, err := models.JoinRequests(qm.Where("invite_id = ?", entry.ID)).One(ctx, tx)
+		if err == nil {
+			if !jr.PubKey.FeedRef.Equal(newMember) {
+				return roomdb.ErrInviteForOtherFeed
+			}
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
 		_, err = i.members.add(ctx, tx, newMember, roomdb.RoleMember)
 		var alreadyAdded roomdb.ErrAlreadyAdded
 		if err != nil {
@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite/models"
+)
+
+// compiler assertion to ensure the struct fullfills the interface
+var _ roomdb.JoinRequestsService = (*JoinRequests)(nil)
+
+// JoinRequests implements the roomdb.JoinRequestsService.
+// Like the invites, the status tokens are stored as sha256 hashes.
+type JoinRequests struct {
+	db *sql.DB
+
+	members Members
+}
+
+// Create adds a pending request for the feed. It returns the token to check its status with or an error.
+// The returned token is base64 URL encoded and has inviteTokenLength when decoded.
+func (jr JoinRequests) Create(ctx context.Context, ref refs.FeedRef, message string) (string, error) {
+	var entry models.JoinRequest
+	entry.PubKey.FeedRef = ref
+	entry.Message = message
+
+	tokenBytes := make([]byte, inviteTokenLength)
+
+	err := transact(jr.db, func(tx *sql.Tx) error {
+		pending, err := models.JoinRequests(
+			qm.Where("pub_key = ? AND status = ?", ref.String(), roomdb.JoinRequestPending),
+		).Exists(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return roomdb.ErrAlreadyAdded{Ref: ref}
+		}
+
+		for tries := 100; tries > 0; tries-- {
+			rand.Read(tokenBytes)
+
+			h := sha256.New()
+			h.Write(tokenBytes)
+			entry.HashedToken = fmt.Sprintf("%x", h.Sum(nil))
+
+			err := entry.Insert(ctx, tx, boil.Whitelist("pub_key", "message", "hashed_token"))
+			if err != nil {
+				if isUniqueViolation(err) {
+					// generated an existing token, retry
+					continue
+				}
+				return err
+			}
+			return nil
+		}
+
+		return errors.New("roomdb: failed to generate a join request token in a reasonable amount of time")
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// GetByToken returns the request for that status token, or ErrNotFound
+func (jr JoinRequests) GetByToken(ctx context.Context, token string) (roomdb.JoinRequest, error) {
+	ht, err := getHashedToken(token)
+	if err != nil {
+		return roomdb.JoinRequest{}, err
+	}
+
+	entry, err := models.JoinRequests(qm.Where("hashed_token = ?", ht)).One(ctx, jr.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.JoinRequest{}, roomdb.ErrNotFound
+		}
+		return roomdb.JoinRequest{}, err
+	}
+
+	return convertJoinRequest(entry), nil
+}
+
+// GetByID returns the request for that ID, or ErrNotFound
+func (jr JoinRequests) GetByID(ctx context.Context, id int64) (roomdb.JoinRequest, error) {
+	entry, err := models.FindJoinRequest(ctx, jr.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.JoinRequest{}, roomdb.ErrNotFound
+		}
+		return roomdb.JoinRequest{}, err
+	}
+
+	return convertJoinRequest(entry), nil
+}
+
+// List returns all the requests, newest first
+func (jr JoinRequests) List(ctx context.Context) ([]roomdb.JoinRequest, error) {
+	all, err := models.JoinRequests(qm.OrderBy("created_at DESC, id DESC")).All(ctx, jr.db)
+	if err != nil {
+		return nil, err
+	}
+
+	lst := make([]roomdb.JoinRequest, len(all))
+	for i, entry := range all {
+		lst[i] = convertJoinRequest(entry)
+	}
+	return lst, nil
+}
+
+// CountPending returns the number of requests that still need a decision
+func (jr JoinRequests) CountPending(ctx context.Context) (uint, error) {
+	count, err := models.JoinRequests(qm.Where("status = ?", roomdb.JoinRequestPending)).Count(ctx, jr.db)
+	if err != nil {
+		return 0, err
+	}
+	return uint(count), nil
+}
+
+// Approve marks a pending request as approved.
+// Without an invite the feed is added as a member in the same transaction, so either both happen or neither.
+func (jr JoinRequests) Approve(ctx context.Context, id, decidedBy int64, withInvite bool) error {
+	return transact(jr.db, func(tx *sql.Tx) error {
+		entry, err := decide(ctx, tx, id, decidedBy, roomdb.JoinRequestApproved, withInvite)
+		if err != nil {
+			return err
+		}
+
+		// it might have been denied after the request was made
+		denied, err := models.DeniedKeys(qm.Where("pub_key = ?", entry.PubKey.FeedRef.String())).Exists(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if denied {
+			return roomdb.ErrDeniedKey
+		}
+
+		if withInvite {
+			// the invite is created when the requester checks the status, see HandOutInvite
+			return nil
+		}
+
+		_, err = jr.members.add(ctx, tx, entry.PubKey.FeedRef, roomdb.RoleMember)
+		var alreadyAdded roomdb.ErrAlreadyAdded
+		if err != nil && !errors.As(err, &alreadyAdded) {
+			return err
+		}
+		return nil
+	})
+}
+
+// Decline marks a pending request as declined
+func (jr JoinRequests) Decline(ctx context.Context, id, decidedBy int64) error {
+	return transact(jr.db, func(tx *sql.Tx) error {
+		_, err := decide(ctx, tx, id, decidedBy, roomdb.JoinRequestDeclined, false)
+		return err
+	})
+}
+
+func decide(ctx context.Context, tx *sql.Tx, id, decidedBy int64, status roomdb.JoinRequestStatus, withInvite bool) (*models.JoinRequest, error) {
+	entry, err := models.JoinRequests(
+		qm.Where("id = ? AND status = ?", id, roomdb.JoinRequestPending),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, roomdb.ErrNotFound
+		}
+		return nil, err
+	}
+
+	entry.Status = int64(status)
+	entry.DecidedBy = decidedBy
+	entry.DecidedAt = time.Now()
+	entry.WithInvite = withInvite
+
+	_, err = entry.Update(ctx, tx, boil.Whitelist("status", "decided_by", "decided_at", "with_invite"))
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// HandOutInvite creates the invite of a request that was approved with one, on behalf of the moderator who approved it.
+// Only the ID of the invite is kept with the request, so its token can't be shown again.
+func (jr JoinRequests) HandOutInvite(ctx context.Context, token string) (string, error) {
+	ht, err := getHashedToken(token)
+	if err != nil {
+		return "", err
+	}
+
+	var inviteToken string
+	err = transact(jr.db, func(tx *sql.Tx) error {
+		entry, err := models.JoinRequests(
+			qm.Where("hashed_token = ? AND status = ? AND with_invite = ? AND invite_id = 0", ht, roomdb.JoinRequestApproved, true),
+		).One(ctx, tx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return roomdb.ErrNotFound
+			}
+			return err
+		}
+
+		invite := models.Invite{CreatedBy: entry.DecidedBy}
+		inviteToken, err = insertInvite(ctx, tx, &invite)
+		if err != nil {
+			return err
+		}
+
+		entry.InviteID = invite.ID
+		_, err = entry.Update(ctx, tx, boil.Whitelist("invite_id"))
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return inviteToken, nil
+}
+
+// deleteOldJoinRequests removes the decided requests after 30 days.
+// The ones with an invite that wasn't used yet are kept, they bind it to the feed that asked.
+func deleteOldJoinRequests(tx boil.ContextExecutor) error {
+	_, err := models.JoinRequests(
+		qm.Where("status != ? AND decided_at < date('now', '-30 days')", roomdb.JoinRequestPending),
+		qm.Where("invite_id NOT IN (SELECT id FROM invites WHERE active = true)"),
+	).DeleteAll(context.Background(), tx)
+	if err != nil {
+		return fmt.Errorf("roomdb: failed to delete old join requests: %w", err)
+	}
+	return nil
+}
+
+func convertJoinRequest(entry *models.JoinRequest) roomdb.JoinRequest {
+	req := roomdb.JoinRequest{
+		ID:        entry.ID,
+		PubKey:    entry.PubKey.FeedRef,
+		Message:   entry.Message,
+		Status:    roomdb.JoinRequestStatus(entry.Status),
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if req.Status != roomdb.JoinRequestPending {
+		req.DecidedBy = entry.DecidedBy
+		req.DecidedAt = entry.DecidedAt
+		req.WithInvite = entry.WithInvite
+		req.InviteID = entry.InviteID
+	}
+
+	return req
+}
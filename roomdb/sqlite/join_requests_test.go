@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+)
+
+func TestJoinRequests(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	r.NoError(err)
+	defer db.Close()
+
+	alf, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("alf!"), 8), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	bob, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("bob!"), 8), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	mod, err := db.Members.Add(ctx, alf, roomdb.RoleModerator)
+	r.NoError(err)
+
+	count, err := db.JoinRequests.CountPending(ctx)
+	r.NoError(err)
+	r.EqualValues(0, count)
+
+	_, err = db.JoinRequests.GetByToken(ctx, "not a token")
+	r.Error(err)
+
+	tok, err := db.JoinRequests.Create(ctx, bob, "hello, I'm bob")
+	r.NoError(err)
+	r.NotEqual("", tok)
+
+	// only one pending request per feed
+	_, err = db.JoinRequests.Create(ctx, bob, "me again")
+	var already roomdb.ErrAlreadyAdded
+	r.True(errors.As(err, &already), "wrong error: %v", err)
+
+	req, err := db.JoinRequests.GetByToken(ctx, tok)
+	r.NoError(err)
+	r.True(req.PubKey.Equal(bob))
+	r.Equal("hello, I'm bob", req.Message)
+	r.Equal(roomdb.JoinRequestPending, req.Status)
+	r.EqualValues(0, req.DecidedBy)
+	r.True(req.DecidedAt.IsZero())
+
+	count, err = db.JoinRequests.CountPending(ctx)
+	r.NoError(err)
+	r.EqualValues(1, count)
+
+	// there is no invite before it is approved with one
+	_, err = db.JoinRequests.HandOutInvite(ctx, tok)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "wrong error: %v", err)
+
+	// approve it with an invite
+	r.NoError(db.JoinRequests.Approve(ctx, req.ID, mod, true))
+
+	req, err = db.JoinRequests.GetByID(ctx, req.ID)
+	r.NoError(err)
+	r.Equal(roomdb.JoinRequestApproved, req.Status)
+	r.Equal(mod, req.DecidedBy)
+	r.False(req.DecidedAt.IsZero())
+	r.True(req.WithInvite)
+	r.EqualValues(0, req.InviteID, "not handed out yet")
+
+	_, err = db.Members.GetByFeed(ctx, bob)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "added without the invite: %v", err)
+
+	// the requester gets the invite once
+	inviteToken, err := db.JoinRequests.HandOutInvite(ctx, tok)
+	r.NoError(err)
+
+	invite, err := db.Invites.GetByToken(ctx, inviteToken)
+	r.NoError(err)
+	r.Equal(mod, invite.CreatedBy.ID)
+
+	req, err = db.JoinRequests.GetByID(ctx, req.ID)
+	r.NoError(err)
+	r.Equal(invite.ID, req.InviteID)
+
+	_, err = db.JoinRequests.HandOutInvite(ctx, tok)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "handed out twice: %v", err)
+
+	// only bob can use it
+	mallory, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("mal!"), 8), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	_, err = db.Invites.Consume(ctx, inviteToken, mallory)
+	r.True(errors.Is(err, roomdb.ErrInviteForOtherFeed), "wrong error: %v", err)
+	_, err = db.Members.GetByFeed(ctx, mallory)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "mallory was added: %v", err)
+
+	_, err = db.Invites.Consume(ctx, inviteToken, bob)
+	r.NoError(err)
+	r.NoError(db.Members.RemoveFeed(ctx, bob))
+
+	// it can't be decided twice
+	err = db.JoinRequests.Decline(ctx, req.ID, mod)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "wrong error: %v", err)
+
+	// once decided, the feed can ask again
+	tok2, err := db.JoinRequests.Create(ctx, bob, "once more")
+	r.NoError(err)
+
+	req2, err := db.JoinRequests.GetByToken(ctx, tok2)
+	r.NoError(err)
+	r.NoError(db.JoinRequests.Decline(ctx, req2.ID, mod))
+
+	req2, err = db.JoinRequests.GetByToken(ctx, tok2)
+	r.NoError(err)
+	r.Equal(roomdb.JoinRequestDeclined, req2.Status)
+	r.False(req2.WithInvite)
+
+	_, err = db.JoinRequests.HandOutInvite(ctx, tok2)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "wrong error: %v", err)
+
+	lst, err := db.JoinRequests.List(ctx)
+	r.NoError(err)
+	r.Len(lst, 2)
+	r.Equal(req2.ID, lst[0].ID, "newest first")
+	r.Equal(req.ID, lst[1].ID)
+
+	count, err = db.JoinRequests.CountPending(ctx)
+	r.NoError(err)
+	r.EqualValues(0, count)
+
+	_, err = db.JoinRequests.GetByID(ctx, 9999)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "wrong error: %v", err)
+
+	// approving without an invite adds the member right away
+	tok3, err := db.JoinRequests.Create(ctx, bob, "third time")
+	r.NoError(err)
+	req3, err := db.JoinRequests.GetByToken(ctx, tok3)
+	r.NoError(err)
+	r.NoError(db.JoinRequests.Approve(ctx, req3.ID, mod, false))
+
+	bobMember, err := db.Members.GetByFeed(ctx, bob)
+	r.NoError(err)
+	r.Equal(roomdb.RoleMember, bobMember.Role)
+
+	// a member that exists already is fine
+	tok4, err := db.JoinRequests.Create(ctx, bob, "once more")
+	r.NoError(err)
+	req4, err := db.JoinRequests.GetByToken(ctx, tok4)
+	r.NoError(err)
+	r.NoError(db.JoinRequests.Approve(ctx, req4.ID, mod, false))
+
+	// keys that were denied after they asked are not let in
+	carl, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("carl"), 8), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	tok5, err := db.JoinRequests.Create(ctx, carl, "hi")
+	r.NoError(err)
+	req5, err := db.JoinRequests.GetByToken(ctx, tok5)
+	r.NoError(err)
+	r.NoError(db.DeniedKeys.Add(ctx, carl, "spam"))
+
+	err = db.JoinRequests.Approve(ctx, req5.ID, mod, false)
+	r.True(errors.Is(err, roomdb.ErrDeniedKey), "wrong error: %v", err)
+	_, err = db.Members.GetByFeed(ctx, carl)
+	r.True(errors.Is(err, roomdb.ErrNotFound), "carl was added: %v", err)
+
+	req5, err = db.JoinRequests.GetByID(ctx, req5.ID)
+	r.NoError(err)
+	r.Equal(roomdb.JoinRequestPending, req5.Status, "the decision was rolled back")
+}
@@ -101,6 +101,7 @@ func (m Members) GetByID(ctx context.Context, mid int64) (roomdb.Member, error)
 		Role:    roomdb.Role(entry.Role),
 		PubKey:  entry.PubKey.FeedRef,
 		Aliases: m.getAliases(entry),
+		Hidden:  entry.Hidden,
 	}, nil
 }
 
@@ -126,6 +127,7 @@ func (m Members) GetByFeed(ctx context.Context, h refs.FeedRef) (roomdb.Member,
 		Role:    roomdb.Role(entry.Role),
 		PubKey:  entry.PubKey.FeedRef,
 		Aliases: m.getAliases(entry),
+		Hidden:  entry.Hidden,
 	}, nil
 }
 
@@ -142,6 +144,7 @@ func (m Members) List(ctx context.Context) ([]roomdb.Member, error) {
 		members[i].Role = roomdb.Role(entry.Role)
 		members[i].PubKey = entry.PubKey.FeedRef
 		members[i].Aliases = m.getAliases(entry)
+		members[i].Hidden = entry.Hidden
 	}
 
 	return members, nil
@@ -224,3 +227,18 @@ func (m Members) SetRole(ctx context.Context, id int64, r roomdb.Role) error {
 		return err
 	})
 }
+
+// SetHidden updates the hidden preference of the passed memberID.
+func (m Members) SetHidden(ctx context.Context, id int64, hidden bool) error {
+	entry, err := models.FindMember(ctx, m.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.ErrNotFound
+		}
+		return err
+	}
+
+	entry.Hidden = hidden
+	_, err = entry.Update(ctx, m.db, boil.Whitelist("hidden"))
+	return err
+}
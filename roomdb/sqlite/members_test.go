@@ -244,6 +244,46 @@ func findMemberWithRole(t *testing.T, members []roomdb.Member, id int64, r roomd
 	}
 }
 
+func TestMembersSetHidden(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	require.NoError(t, err)
+
+	feed, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("1"), 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	id, err := db.Members.Add(ctx, feed, roomdb.RoleMember)
+	r.NoError(err)
+
+	// listed by default
+	m, err := db.Members.GetByID(ctx, id)
+	r.NoError(err)
+	r.False(m.Hidden)
+
+	err = db.Members.SetHidden(ctx, id, true)
+	r.NoError(err)
+
+	m, err = db.Members.GetByFeed(ctx, feed)
+	r.NoError(err)
+	r.True(m.Hidden)
+
+	members, err := db.Members.List(ctx)
+	r.NoError(err)
+	r.Len(members, 1)
+	r.True(members[0].Hidden)
+
+	err = db.Members.SetHidden(ctx, id+1, true)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	r.NoError(db.Close())
+}
+
 func TestMembersAliases(t *testing.T) {
 	r := require.New(t)
 	ctx := context.Background()
@@ -0,0 +1,15 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- multiserver addresses of other peers the room keeps an outgoing connection to
+CREATE TABLE peers (
+  id          INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  address     TEXT NOT NULL UNIQUE, -- net:host:port~shs:key
+  comment     TEXT NOT NULL,
+  created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate Down
+DROP TABLE peers;
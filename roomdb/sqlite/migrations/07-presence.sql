@@ -0,0 +1,32 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- totals of all the connections of a member, they are kept when old sessions are deleted
+CREATE TABLE member_presence (
+  id                INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id         INTEGER UNIQUE NOT NULL,
+  first_seen        DATETIME NOT NULL,
+  last_seen         DATETIME NOT NULL,
+  connected_seconds INTEGER NOT NULL DEFAULT 0,
+
+  FOREIGN KEY ( member_id ) REFERENCES members( "id" ) ON DELETE CASCADE
+);
+
+-- the recent connections of a member
+CREATE TABLE member_sessions (
+  id          INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id   INTEGER NOT NULL,
+  started_at  DATETIME NOT NULL,
+  ended_at    DATETIME NOT NULL,
+  transport   TEXT NOT NULL, -- tcp or websocket
+
+  FOREIGN KEY ( member_id ) REFERENCES members( "id" ) ON DELETE CASCADE
+);
+CREATE INDEX member_sessions_by_member ON member_sessions(member_id, ended_at);
+
+-- +migrate Down
+DROP INDEX member_sessions_by_member;
+DROP TABLE member_sessions;
+DROP TABLE member_presence;
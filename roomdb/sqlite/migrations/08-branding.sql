@@ -0,0 +1,18 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- how the room presents itself. empty values fall back to the defaults, like the domain instead of the name
+ALTER TABLE config ADD COLUMN name TEXT NOT NULL DEFAULT '';
+ALTER TABLE config ADD COLUMN tagline TEXT NOT NULL DEFAULT '';
+ALTER TABLE config ADD COLUMN accent_color TEXT NOT NULL DEFAULT ''; -- a CSS hex color like #6d28d9
+ALTER TABLE config ADD COLUMN logo BLOB NOT NULL DEFAULT x'';
+ALTER TABLE config ADD COLUMN logo_type TEXT NOT NULL DEFAULT ''; -- the content type of the logo, empty if there is none
+
+-- +migrate Down
+ALTER TABLE config DROP COLUMN name;
+ALTER TABLE config DROP COLUMN tagline;
+ALTER TABLE config DROP COLUMN accent_color;
+ALTER TABLE config DROP COLUMN logo;
+ALTER TABLE config DROP COLUMN logo_type;
@@ -0,0 +1,29 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- notices that were only saved as drafts so far are not shown to the public
+ALTER TABLE notices ADD COLUMN published BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- every save of a notice, the published ones and the drafts
+CREATE TABLE notice_revisions (
+  id          INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  notice_id   INTEGER NOT NULL,
+  title       TEXT NOT NULL,
+  content     TEXT NOT NULL,
+  author      INTEGER NOT NULL DEFAULT 0, -- the id of the member, 0 if unknown
+  draft       BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY ( notice_id ) REFERENCES notices( "id" ) ON DELETE CASCADE
+);
+CREATE INDEX notice_revisions_by_notice ON notice_revisions(notice_id, id);
+
+-- the existing notices are their first revision
+INSERT INTO notice_revisions (notice_id, title, content) SELECT id, title, content FROM notices;
+
+-- +migrate Down
+DROP INDEX notice_revisions_by_notice;
+DROP TABLE notice_revisions;
+ALTER TABLE notices DROP COLUMN published;
@@ -0,0 +1,23 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- pins are the pages of the room: the four well known ones and the ones admins add.
+-- the slug is the part of the URL under /page/ and the menu lists the added pages by their position.
+ALTER TABLE pins ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+ALTER TABLE pins ADD COLUMN menu_position INTEGER NOT NULL DEFAULT 0;
+
+UPDATE pins SET slug = 'description' WHERE name = 'NoticeDescription';
+UPDATE pins SET slug = 'news' WHERE name = 'NoticeNews';
+UPDATE pins SET slug = 'code-of-conduct' WHERE name = 'NoticeCodeOfConduct';
+UPDATE pins SET slug = 'privacy-policy' WHERE name = 'NoticePrivacyPolicy';
+
+CREATE UNIQUE INDEX pins_by_slug ON pins(slug);
+
+-- +migrate Down
+DROP INDEX pins_by_slug;
+DELETE FROM pin_notices WHERE pin_id IN (SELECT id FROM pins WHERE name NOT IN ('NoticeDescription', 'NoticeNews', 'NoticeCodeOfConduct', 'NoticePrivacyPolicy'));
+DELETE FROM pins WHERE name NOT IN ('NoticeDescription', 'NoticeNews', 'NoticeCodeOfConduct', 'NoticePrivacyPolicy');
+ALTER TABLE pins DROP COLUMN slug;
+ALTER TABLE pins DROP COLUMN menu_position;
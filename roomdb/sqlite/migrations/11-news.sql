@@ -0,0 +1,18 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- the news of the room are dated posts, each one a notice in a single language
+CREATE TABLE news_posts (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  notice_id     INTEGER NOT NULL UNIQUE,
+  published_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY ( notice_id ) REFERENCES notices( "id" ) ON DELETE CASCADE
+);
+CREATE INDEX news_posts_by_date ON news_posts(published_at, id);
+
+-- +migrate Down
+DROP INDEX news_posts_by_date;
+DROP TABLE news_posts;
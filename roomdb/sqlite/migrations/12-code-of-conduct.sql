@@ -0,0 +1,32 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- the versions of the code of conduct (all the translations of the NoticeCodeOfConduct pin).
+-- members have to accept the newest one.
+CREATE TABLE code_of_conduct_versions (
+  id          INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, -- the version number
+  created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- which member accepted which version and when
+CREATE TABLE code_of_conduct_acceptances (
+  id           INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id    INTEGER NOT NULL,
+  version      INTEGER NOT NULL,
+  accepted_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  UNIQUE ( member_id, version ),
+
+  FOREIGN KEY ( member_id ) REFERENCES members( "id" ) ON DELETE CASCADE,
+  FOREIGN KEY ( version ) REFERENCES code_of_conduct_versions( "id" ) ON DELETE CASCADE
+);
+CREATE INDEX code_of_conduct_acceptances_by_member ON code_of_conduct_acceptances(member_id, version);
+
+-- there is no version until an admin publishes one, so nobody is locked out by the upgrade
+
+-- +migrate Down
+DROP INDEX code_of_conduct_acceptances_by_member;
+DROP TABLE code_of_conduct_acceptances;
+DROP TABLE code_of_conduct_versions;
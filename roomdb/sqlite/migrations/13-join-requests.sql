@@ -0,0 +1,26 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- requests of people who aren't members yet to join the room, until a moderator approves or declines them.
+-- status is roomdb.JoinRequestStatus (0: pending, 1: approved, 2: declined)
+CREATE TABLE join_requests (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+  pub_key       TEXT NOT NULL,
+  message       TEXT NOT NULL,
+  status        INTEGER NOT NULL DEFAULT 0,
+  hashed_token  TEXT UNIQUE NOT NULL, -- to check the status with
+  with_invite   BOOLEAN NOT NULL DEFAULT FALSE, -- approved with a personal invite instead of adding the member directly
+  invite_id     INTEGER NOT NULL DEFAULT 0, -- that invite, once it was handed out. only the hashed token is kept with the invites
+  decided_by    INTEGER NOT NULL DEFAULT 0,
+  created_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  decided_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- only one pending request per feed
+CREATE UNIQUE INDEX join_requests_pending_by_key ON join_requests(pub_key) WHERE status = 0;
+
+-- +migrate Down
+DROP INDEX join_requests_pending_by_key;
+DROP TABLE join_requests;
@@ -0,0 +1,13 @@
+-- SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+--
+-- SPDX-License-Identifier: CC0-1.0
+
+-- +migrate Up
+-- how hard it is to get an invite in open mode.
+-- the proof-of-work is off until an admin sets a difficulty, apps that don't know about it couldn't get invites anymore.
+ALTER TABLE config ADD COLUMN open_invite_difficulty INTEGER NOT NULL DEFAULT 0; -- leading zero bits of the proof-of-work, 0 turns it off
+ALTER TABLE config ADD COLUMN open_invite_daily_limit INTEGER NOT NULL DEFAULT 10; -- invites per IP address and day, 0 means no limit
+
+-- +migrate Down
+ALTER TABLE config DROP COLUMN open_invite_difficulty;
+ALTER TABLE config DROP COLUMN open_invite_daily_limit;
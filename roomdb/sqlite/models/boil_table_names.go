@@ -4,27 +4,43 @@
 package models
 
 var TableNames = struct {
-	SIWSSBSessions      string
-	Aliases             string
-	Config              string
-	DeniedKeys          string
-	FallbackPasswords   string
-	FallbackResetTokens string
-	Invites             string
-	Members             string
-	Notices             string
-	PinNotices          string
-	Pins                string
+	SIWSSBSessions           string
+	Aliases                  string
+	CodeOfConductAcceptances string
+	CodeOfConductVersions    string
+	Config                   string
+	DeniedKeys               string
+	FallbackPasswords        string
+	FallbackResetTokens      string
+	Invites                  string
+	JoinRequests             string
+	MemberPresence           string
+	MemberSessions           string
+	Members                  string
+	NewsPosts                string
+	NoticeRevisions          string
+	Notices                  string
+	Peers                    string
+	PinNotices               string
+	Pins                     string
 }{
-	SIWSSBSessions:      "SIWSSB_sessions",
-	Aliases:             "aliases",
-	Config:              "config",
-	DeniedKeys:          "denied_keys",
-	FallbackPasswords:   "fallback_passwords",
-	FallbackResetTokens: "fallback_reset_tokens",
-	Invites:             "invites",
-	Members:             "members",
-	Notices:             "notices",
-	PinNotices:          "pin_notices",
-	Pins:                "pins",
+	SIWSSBSessions:           "SIWSSB_sessions",
+	Aliases:                  "aliases",
+	CodeOfConductAcceptances: "code_of_conduct_acceptances",
+	CodeOfConductVersions:    "code_of_conduct_versions",
+	Config:                   "config",
+	DeniedKeys:               "denied_keys",
+	FallbackPasswords:        "fallback_passwords",
+	FallbackResetTokens:      "fallback_reset_tokens",
+	Invites:                  "invites",
+	JoinRequests:             "join_requests",
+	MemberPresence:           "member_presence",
+	MemberSessions:           "member_sessions",
+	Members:                  "members",
+	NewsPosts:                "news_posts",
+	NoticeRevisions:          "notice_revisions",
+	Notices:                  "notices",
+	Peers:                    "peers",
+	PinNotices:               "pin_notices",
+	Pins:                     "pins",
 }
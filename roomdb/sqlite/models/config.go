@@ -28,6 +28,13 @@ type Config struct {
 	PrivacyMode            roomdb.PrivacyMode `boil:"privacyMode" json:"privacyMode" toml:"privacyMode" yaml:"privacyMode"`
 	DefaultLanguage        string             `boil:"defaultLanguage" json:"defaultLanguage" toml:"defaultLanguage" yaml:"defaultLanguage"`
 	UseSubdomainForAliases bool               `boil:"use_subdomain_for_aliases" json:"use_subdomain_for_aliases" toml:"use_subdomain_for_aliases" yaml:"use_subdomain_for_aliases"`
+	Name                   string             `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Tagline                string             `boil:"tagline" json:"tagline" toml:"tagline" yaml:"tagline"`
+	AccentColor            string             `boil:"accent_color" json:"accent_color" toml:"accent_color" yaml:"accent_color"`
+	Logo                   []byte             `boil:"logo" json:"logo" toml:"logo" yaml:"logo"`
+	LogoType               string             `boil:"logo_type" json:"logo_type" toml:"logo_type" yaml:"logo_type"`
+	OpenInviteDifficulty   int64              `boil:"open_invite_difficulty" json:"open_invite_difficulty" toml:"open_invite_difficulty" yaml:"open_invite_difficulty"`
+	OpenInviteDailyLimit   int64              `boil:"open_invite_daily_limit" json:"open_invite_daily_limit" toml:"open_invite_daily_limit" yaml:"open_invite_daily_limit"`
 
 	R *configR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L configL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -38,11 +45,25 @@ var ConfigColumns = struct {
 	PrivacyMode            string
 	DefaultLanguage        string
 	UseSubdomainForAliases string
+	Name                   string
+	Tagline                string
+	AccentColor            string
+	Logo                   string
+	LogoType               string
+	OpenInviteDifficulty   string
+	OpenInviteDailyLimit   string
 }{
 	ID:                     "id",
 	PrivacyMode:            "privacyMode",
 	DefaultLanguage:        "defaultLanguage",
 	UseSubdomainForAliases: "use_subdomain_for_aliases",
+	Name:                   "name",
+	Tagline:                "tagline",
+	AccentColor:            "accent_color",
+	Logo:                   "logo",
+	LogoType:               "logo_type",
+	OpenInviteDifficulty:   "open_invite_difficulty",
+	OpenInviteDailyLimit:   "open_invite_daily_limit",
 }
 
 var ConfigTableColumns = struct {
@@ -50,11 +71,25 @@ var ConfigTableColumns = struct {
 	PrivacyMode            string
 	DefaultLanguage        string
 	UseSubdomainForAliases string
+	Name                   string
+	Tagline                string
+	AccentColor            string
+	Logo                   string
+	LogoType               string
+	OpenInviteDifficulty   string
+	OpenInviteDailyLimit   string
 }{
 	ID:                     "config.id",
 	PrivacyMode:            "config.privacyMode",
 	DefaultLanguage:        "config.defaultLanguage",
 	UseSubdomainForAliases: "config.use_subdomain_for_aliases",
+	Name:                   "config.name",
+	Tagline:                "config.tagline",
+	AccentColor:            "config.accent_color",
+	Logo:                   "config.logo",
+	LogoType:               "config.logo_type",
+	OpenInviteDifficulty:   "config.open_invite_difficulty",
+	OpenInviteDailyLimit:   "config.open_invite_daily_limit",
 }
 
 // Generated where
@@ -94,11 +129,25 @@ var ConfigWhere = struct {
 	PrivacyMode            whereHelperroomdb_PrivacyMode
 	DefaultLanguage        whereHelperstring
 	UseSubdomainForAliases whereHelperbool
+	Name                   whereHelperstring
+	Tagline                whereHelperstring
+	AccentColor            whereHelperstring
+	Logo                   whereHelper__byte
+	LogoType               whereHelperstring
+	OpenInviteDifficulty   whereHelperint64
+	OpenInviteDailyLimit   whereHelperint64
 }{
 	ID:                     whereHelperint64{field: "\"config\".\"id\""},
 	PrivacyMode:            whereHelperroomdb_PrivacyMode{field: "\"config\".\"privacyMode\""},
 	DefaultLanguage:        whereHelperstring{field: "\"config\".\"defaultLanguage\""},
 	UseSubdomainForAliases: whereHelperbool{field: "\"config\".\"use_subdomain_for_aliases\""},
+	Name:                   whereHelperstring{field: "\"config\".\"name\""},
+	Tagline:                whereHelperstring{field: "\"config\".\"tagline\""},
+	AccentColor:            whereHelperstring{field: "\"config\".\"accent_color\""},
+	Logo:                   whereHelper__byte{field: "\"config\".\"logo\""},
+	LogoType:               whereHelperstring{field: "\"config\".\"logo_type\""},
+	OpenInviteDifficulty:   whereHelperint64{field: "\"config\".\"open_invite_difficulty\""},
+	OpenInviteDailyLimit:   whereHelperint64{field: "\"config\".\"open_invite_daily_limit\""},
 }
 
 // ConfigRels is where relationship names are stored.
@@ -118,9 +167,9 @@ func (*configR) NewStruct() *configR {
 type configL struct{}
 
 var (
-	configAllColumns            = []string{"id", "privacyMode", "defaultLanguage", "use_subdomain_for_aliases"}
+	configAllColumns            = []string{"id", "privacyMode", "defaultLanguage", "use_subdomain_for_aliases", "name", "tagline", "accent_color", "logo", "logo_type", "open_invite_difficulty", "open_invite_daily_limit"}
 	configColumnsWithoutDefault = []string{"privacyMode", "defaultLanguage", "use_subdomain_for_aliases"}
-	configColumnsWithDefault    = []string{"id"}
+	configColumnsWithDefault    = []string{"id", "name", "tagline", "accent_color", "logo", "logo_type", "open_invite_difficulty", "open_invite_daily_limit"}
 	configPrimaryKeyColumns     = []string{"id"}
 	configGeneratedColumns      = []string{"id"}
 )
@@ -27,6 +27,7 @@ type Member struct {
 	ID     int64            `boil:"id" json:"id" toml:"id" yaml:"id"`
 	Role   int64            `boil:"role" json:"role" toml:"role" yaml:"role"`
 	PubKey roomdb.DBFeedRef `boil:"pub_key" json:"pub_key" toml:"pub_key" yaml:"pub_key"`
+	Hidden bool             `boil:"hidden" json:"hidden" toml:"hidden" yaml:"hidden"`
 
 	R *memberR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L memberL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -36,20 +37,24 @@ var MemberColumns = struct {
 	ID     string
 	Role   string
 	PubKey string
+	Hidden string
 }{
 	ID:     "id",
 	Role:   "role",
 	PubKey: "pub_key",
+	Hidden: "hidden",
 }
 
 var MemberTableColumns = struct {
 	ID     string
 	Role   string
 	PubKey string
+	Hidden string
 }{
 	ID:     "members.id",
 	Role:   "members.role",
 	PubKey: "members.pub_key",
+	Hidden: "members.hidden",
 }
 
 // Generated where
@@ -58,37 +63,45 @@ var MemberWhere = struct {
 	ID     whereHelperint64
 	Role   whereHelperint64
 	PubKey whereHelperroomdb_DBFeedRef
+	Hidden whereHelperbool
 }{
 	ID:     whereHelperint64{field: "\"members\".\"id\""},
 	Role:   whereHelperint64{field: "\"members\".\"role\""},
 	PubKey: whereHelperroomdb_DBFeedRef{field: "\"members\".\"pub_key\""},
+	Hidden: whereHelperbool{field: "\"members\".\"hidden\""},
 }
 
 // MemberRels is where relationship names are stored.
 var MemberRels = struct {
 	FallbackPassword             string
+	MemberPresence               string
 	SIWSSBSessions               string
 	Aliases                      string
 	ForMemberFallbackResetTokens string
 	CreatedByFallbackResetTokens string
 	CreatedByInvites             string
+	MemberSessions               string
 }{
 	FallbackPassword:             "FallbackPassword",
+	MemberPresence:               "MemberPresence",
 	SIWSSBSessions:               "SIWSSBSessions",
 	Aliases:                      "Aliases",
 	ForMemberFallbackResetTokens: "ForMemberFallbackResetTokens",
 	CreatedByFallbackResetTokens: "CreatedByFallbackResetTokens",
 	CreatedByInvites:             "CreatedByInvites",
+	MemberSessions:               "MemberSessions",
 }
 
 // memberR is where relationships are stored.
 type memberR struct {
 	FallbackPassword             *FallbackPassword       `boil:"FallbackPassword" json:"FallbackPassword" toml:"FallbackPassword" yaml:"FallbackPassword"`
+	MemberPresence               *MemberPresence         `boil:"MemberPresence" json:"MemberPresence" toml:"MemberPresence" yaml:"MemberPresence"`
 	SIWSSBSessions               SIWSSBSessionSlice      `boil:"SIWSSBSessions" json:"SIWSSBSessions" toml:"SIWSSBSessions" yaml:"SIWSSBSessions"`
 	Aliases                      AliasSlice              `boil:"Aliases" json:"Aliases" toml:"Aliases" yaml:"Aliases"`
 	ForMemberFallbackResetTokens FallbackResetTokenSlice `boil:"ForMemberFallbackResetTokens" json:"ForMemberFallbackResetTokens" toml:"ForMemberFallbackResetTokens" yaml:"ForMemberFallbackResetTokens"`
 	CreatedByFallbackResetTokens FallbackResetTokenSlice `boil:"CreatedByFallbackResetTokens" json:"CreatedByFallbackResetTokens" toml:"CreatedByFallbackResetTokens" yaml:"CreatedByFallbackResetTokens"`
 	CreatedByInvites             InviteSlice             `boil:"CreatedByInvites" json:"CreatedByInvites" toml:"CreatedByInvites" yaml:"CreatedByInvites"`
+	MemberSessions               MemberSessionSlice      `boil:"MemberSessions" json:"MemberSessions" toml:"MemberSessions" yaml:"MemberSessions"`
 }
 
 // NewStruct creates a new relationship struct
@@ -103,6 +116,13 @@ func (r *memberR) GetFallbackPassword() *FallbackPassword {
 	return r.FallbackPassword
 }
 
+func (r *memberR) GetMemberPresence() *MemberPresence {
+	if r == nil {
+		return nil
+	}
+	return r.MemberPresence
+}
+
 func (r *memberR) GetSIWSSBSessions() SIWSSBSessionSlice {
 	if r == nil {
 		return nil
@@ -110,6 +130,13 @@ func (r *memberR) GetSIWSSBSessions() SIWSSBSessionSlice {
 	return r.SIWSSBSessions
 }
 
+func (r *memberR) GetMemberSessions() MemberSessionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.MemberSessions
+}
+
 func (r *memberR) GetAliases() AliasSlice {
 	if r == nil {
 		return nil
@@ -142,9 +169,9 @@ func (r *memberR) GetCreatedByInvites() InviteSlice {
 type memberL struct{}
 
 var (
-	memberAllColumns            = []string{"id", "role", "pub_key"}
+	memberAllColumns            = []string{"id", "role", "pub_key", "hidden"}
 	memberColumnsWithoutDefault = []string{"role", "pub_key"}
-	memberColumnsWithDefault    = []string{"id"}
+	memberColumnsWithDefault    = []string{"id", "hidden"}
 	memberPrimaryKeyColumns     = []string{"id"}
 	memberGeneratedColumns      = []string{"id"}
 )
@@ -438,6 +465,17 @@ func (o *Member) FallbackPassword(mods ...qm.QueryMod) fallbackPasswordQuery {
 	return FallbackPasswords(queryMods...)
 }
 
+// MemberPresence pointed to by the foreign key.
+func (o *Member) MemberPresence(mods ...qm.QueryMod) memberPresenceQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"member_id\" = ?", o.ID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return MemberPresences(queryMods...)
+}
+
 // SIWSSBSessions retrieves all the SIWSSB_session's SIWSSBSessions with an executor.
 func (o *Member) SIWSSBSessions(mods ...qm.QueryMod) sIWSSBSessionQuery {
 	var queryMods []qm.QueryMod
@@ -452,6 +490,20 @@ func (o *Member) SIWSSBSessions(mods ...qm.QueryMod) sIWSSBSessionQuery {
 	return SIWSSBSessions(queryMods...)
 }
 
+// MemberSessions retrieves all the member_session's MemberSessions with an executor.
+func (o *Member) MemberSessions(mods ...qm.QueryMod) memberSessionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"member_sessions\".\"member_id\"=?", o.ID),
+	)
+
+	return MemberSessions(queryMods...)
+}
+
 // Aliases retrieves all the alias's Aliases with an executor.
 func (o *Member) Aliases(mods ...qm.QueryMod) aliasQuery {
 	var queryMods []qm.QueryMod
@@ -625,6 +677,123 @@ func (memberL) LoadFallbackPassword(ctx context.Context, e boil.ContextExecutor,
 	return nil
 }
 
+// LoadMemberPresence allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (memberL) LoadMemberPresence(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMember interface{}, mods queries.Applicator) error {
+	var slice []*Member
+	var object *Member
+
+	if singular {
+		var ok bool
+		object, ok = maybeMember.(*Member)
+		if !ok {
+			object = new(Member)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMember)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMember))
+			}
+		}
+	} else {
+		s, ok := maybeMember.(*[]*Member)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMember)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMember))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &memberR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &memberR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`member_presence`),
+		qm.WhereIn(`member_presence.member_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load MemberPresence")
+	}
+
+	var resultSlice []*MemberPresence
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice MemberPresence")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for member_presence")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for member_presence")
+	}
+
+	if len(memberPresenceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.MemberPresence = foreign
+		if foreign.R == nil {
+			foreign.R = &memberPresenceR{}
+		}
+		foreign.R.Member = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ID == foreign.MemberID {
+				local.R.MemberPresence = foreign
+				if foreign.R == nil {
+					foreign.R = &memberPresenceR{}
+				}
+				foreign.R.Member = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadSIWSSBSessions allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (memberL) LoadSIWSSBSessions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMember interface{}, mods queries.Applicator) error {
@@ -739,6 +908,120 @@ func (memberL) LoadSIWSSBSessions(ctx context.Context, e boil.ContextExecutor, s
 	return nil
 }
 
+// LoadMemberSessions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (memberL) LoadMemberSessions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMember interface{}, mods queries.Applicator) error {
+	var slice []*Member
+	var object *Member
+
+	if singular {
+		var ok bool
+		object, ok = maybeMember.(*Member)
+		if !ok {
+			object = new(Member)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMember)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMember))
+			}
+		}
+	} else {
+		s, ok := maybeMember.(*[]*Member)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMember)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMember))
+			}
+		}
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &memberR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &memberR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`member_sessions`),
+		qm.WhereIn(`member_sessions.member_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load member_sessions")
+	}
+
+	var resultSlice []*MemberSession
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice member_sessions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on member_sessions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for member_sessions")
+	}
+
+	if len(memberSessionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.MemberSessions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &memberSessionR{}
+			}
+			foreign.R.Member = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.MemberID {
+				local.R.MemberSessions = append(local.R.MemberSessions, foreign)
+				if foreign.R == nil {
+					foreign.R = &memberSessionR{}
+				}
+				foreign.R.Member = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadAliases allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (memberL) LoadAliases(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMember interface{}, mods queries.Applicator) error {
@@ -1245,6 +1528,56 @@ func (o *Member) SetFallbackPassword(ctx context.Context, exec boil.ContextExecu
 	return nil
 }
 
+// SetMemberPresence of the member to the related item.
+// Sets o.R.MemberPresence to related.
+// Adds o to related.R.Member.
+func (o *Member) SetMemberPresence(ctx context.Context, exec boil.ContextExecutor, insert bool, related *MemberPresence) error {
+	var err error
+
+	if insert {
+		related.MemberID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"member_presence\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, []string{"member_id"}),
+			strmangle.WhereClause("\"", "\"", 0, memberPresencePrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.ID}
+
+		if boil.IsDebug(ctx) {
+			writer := boil.DebugWriterFrom(ctx)
+			fmt.Fprintln(writer, updateQuery)
+			fmt.Fprintln(writer, values)
+		}
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.MemberID = o.ID
+	}
+
+	if o.R == nil {
+		o.R = &memberR{
+			MemberPresence: related,
+		}
+	} else {
+		o.R.MemberPresence = related
+	}
+
+	if related.R == nil {
+		related.R = &memberPresenceR{
+			Member: o,
+		}
+	} else {
+		related.R.Member = o
+	}
+	return nil
+}
+
 // AddSIWSSBSessions adds the given related objects to the existing relationships
 // of the member, optionally inserting them as new records.
 // Appends related to o.R.SIWSSBSessions.
@@ -1298,6 +1631,59 @@ func (o *Member) AddSIWSSBSessions(ctx context.Context, exec boil.ContextExecuto
 	return nil
 }
 
+// AddMemberSessions adds the given related objects to the existing relationships
+// of the member, optionally inserting them as new records.
+// Appends related to o.R.MemberSessions.
+// Sets related.R.Member appropriately.
+func (o *Member) AddMemberSessions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*MemberSession) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.MemberID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"member_sessions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 0, []string{"member_id"}),
+				strmangle.WhereClause("\"", "\"", 0, memberSessionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.MemberID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &memberR{
+			MemberSessions: related,
+		}
+	} else {
+		o.R.MemberSessions = append(o.R.MemberSessions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &memberSessionR{
+				Member: o,
+			}
+		} else {
+			rel.R.Member = o
+		}
+	}
+	return nil
+}
+
 // AddAliases adds the given related objects to the existing relationships
 // of the member, optionally inserting them as new records.
 // Appends related to o.R.Aliases.
@@ -23,51 +23,58 @@ import (
 
 // Notice is an object representing the database table.
 type Notice struct {
-	ID       int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Title    string `boil:"title" json:"title" toml:"title" yaml:"title"`
-	Content  string `boil:"content" json:"content" toml:"content" yaml:"content"`
-	Language string `boil:"language" json:"language" toml:"language" yaml:"language"`
+	ID        int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Title     string `boil:"title" json:"title" toml:"title" yaml:"title"`
+	Content   string `boil:"content" json:"content" toml:"content" yaml:"content"`
+	Language  string `boil:"language" json:"language" toml:"language" yaml:"language"`
+	Published bool   `boil:"published" json:"published" toml:"published" yaml:"published"`
 
 	R *noticeR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L noticeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var NoticeColumns = struct {
-	ID       string
-	Title    string
-	Content  string
-	Language string
+	ID        string
+	Title     string
+	Content   string
+	Language  string
+	Published string
 }{
-	ID:       "id",
-	Title:    "title",
-	Content:  "content",
-	Language: "language",
+	ID:        "id",
+	Title:     "title",
+	Content:   "content",
+	Language:  "language",
+	Published: "published",
 }
 
 var NoticeTableColumns = struct {
-	ID       string
-	Title    string
-	Content  string
-	Language string
+	ID        string
+	Title     string
+	Content   string
+	Language  string
+	Published string
 }{
-	ID:       "notices.id",
-	Title:    "notices.title",
-	Content:  "notices.content",
-	Language: "notices.language",
+	ID:        "notices.id",
+	Title:     "notices.title",
+	Content:   "notices.content",
+	Language:  "notices.language",
+	Published: "notices.published",
 }
 
 // Generated where
 
 var NoticeWhere = struct {
-	ID       whereHelperint64
-	Title    whereHelperstring
-	Content  whereHelperstring
-	Language whereHelperstring
+	ID        whereHelperint64
+	Title     whereHelperstring
+	Content   whereHelperstring
+	Language  whereHelperstring
+	Published whereHelperbool
 }{
-	ID:       whereHelperint64{field: "\"notices\".\"id\""},
-	Title:    whereHelperstring{field: "\"notices\".\"title\""},
-	Content:  whereHelperstring{field: "\"notices\".\"content\""},
-	Language: whereHelperstring{field: "\"notices\".\"language\""},
+	ID:        whereHelperint64{field: "\"notices\".\"id\""},
+	Title:     whereHelperstring{field: "\"notices\".\"title\""},
+	Content:   whereHelperstring{field: "\"notices\".\"content\""},
+	Language:  whereHelperstring{field: "\"notices\".\"language\""},
+	Published: whereHelperbool{field: "\"notices\".\"published\""},
 }
 
 // NoticeRels is where relationship names are stored.
@@ -98,9 +105,9 @@ func (r *noticeR) GetPins() PinSlice {
 type noticeL struct{}
 
 var (
-	noticeAllColumns            = []string{"id", "title", "content", "language"}
+	noticeAllColumns            = []string{"id", "title", "content", "language", "published"}
 	noticeColumnsWithoutDefault = []string{"title", "content", "language"}
-	noticeColumnsWithDefault    = []string{"id"}
+	noticeColumnsWithDefault    = []string{"id", "published"}
 	noticePrimaryKeyColumns     = []string{"id"}
 	noticeGeneratedColumns      = []string{"id"}
 )
@@ -454,7 +461,7 @@ func (noticeL) LoadPins(ctx context.Context, e boil.ContextExecutor, singular bo
 	}
 
 	query := NewQuery(
-		qm.Select("\"pins\".\"id\", \"pins\".\"name\", \"a\".\"notice_id\""),
+		qm.Select("\"pins\".\"id\", \"pins\".\"name\", \"pins\".\"slug\", \"pins\".\"menu_position\", \"a\".\"notice_id\""),
 		qm.From("\"pins\""),
 		qm.InnerJoin("\"pin_notices\" as \"a\" on \"pins\".\"id\" = \"a\".\"pin_id\""),
 		qm.WhereIn("\"a\".\"notice_id\" in ?", args...),
@@ -475,7 +482,7 @@ func (noticeL) LoadPins(ctx context.Context, e boil.ContextExecutor, singular bo
 		one := new(Pin)
 		var localJoinCol int64
 
-		err = results.Scan(&one.ID, &one.Name, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Name, &one.Slug, &one.MenuPosition, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for pins")
 		}
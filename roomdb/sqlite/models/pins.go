@@ -23,37 +23,51 @@ import (
 
 // Pin is an object representing the database table.
 type Pin struct {
-	ID   int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name string `boil:"name" json:"name" toml:"name" yaml:"name"`
+	ID           int64  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name         string `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Slug         string `boil:"slug" json:"slug" toml:"slug" yaml:"slug"`
+	MenuPosition int64  `boil:"menu_position" json:"menu_position" toml:"menu_position" yaml:"menu_position"`
 
 	R *pinR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L pinL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var PinColumns = struct {
-	ID   string
-	Name string
+	ID           string
+	Name         string
+	Slug         string
+	MenuPosition string
 }{
-	ID:   "id",
-	Name: "name",
+	ID:           "id",
+	Name:         "name",
+	Slug:         "slug",
+	MenuPosition: "menu_position",
 }
 
 var PinTableColumns = struct {
-	ID   string
-	Name string
+	ID           string
+	Name         string
+	Slug         string
+	MenuPosition string
 }{
-	ID:   "pins.id",
-	Name: "pins.name",
+	ID:           "pins.id",
+	Name:         "pins.name",
+	Slug:         "pins.slug",
+	MenuPosition: "pins.menu_position",
 }
 
 // Generated where
 
 var PinWhere = struct {
-	ID   whereHelperint64
-	Name whereHelperstring
+	ID           whereHelperint64
+	Name         whereHelperstring
+	Slug         whereHelperstring
+	MenuPosition whereHelperint64
 }{
-	ID:   whereHelperint64{field: "\"pins\".\"id\""},
-	Name: whereHelperstring{field: "\"pins\".\"name\""},
+	ID:           whereHelperint64{field: "\"pins\".\"id\""},
+	Name:         whereHelperstring{field: "\"pins\".\"name\""},
+	Slug:         whereHelperstring{field: "\"pins\".\"slug\""},
+	MenuPosition: whereHelperint64{field: "\"pins\".\"menu_position\""},
 }
 
 // PinRels is where relationship names are stored.
@@ -84,9 +98,9 @@ func (r *pinR) GetNotices() NoticeSlice {
 type pinL struct{}
 
 var (
-	pinAllColumns            = []string{"id", "name"}
+	pinAllColumns            = []string{"id", "name", "slug", "menu_position"}
 	pinColumnsWithoutDefault = []string{"name"}
-	pinColumnsWithDefault    = []string{"id"}
+	pinColumnsWithDefault    = []string{"id", "slug", "menu_position"}
 	pinPrimaryKeyColumns     = []string{"id"}
 	pinGeneratedColumns      = []string{"id"}
 )
@@ -440,7 +454,7 @@ func (pinL) LoadNotices(ctx context.Context, e boil.ContextExecutor, singular bo
 	}
 
 	query := NewQuery(
-		qm.Select("\"notices\".\"id\", \"notices\".\"title\", \"notices\".\"content\", \"notices\".\"language\", \"a\".\"pin_id\""),
+		qm.Select("\"notices\".\"id\", \"notices\".\"title\", \"notices\".\"content\", \"notices\".\"language\", \"notices\".\"published\", \"a\".\"pin_id\""),
 		qm.From("\"notices\""),
 		qm.InnerJoin("\"pin_notices\" as \"a\" on \"notices\".\"id\" = \"a\".\"notice_id\""),
 		qm.WhereIn("\"a\".\"pin_id\" in ?", args...),
@@ -461,7 +475,7 @@ func (pinL) LoadNotices(ctx context.Context, e boil.ContextExecutor, singular bo
 		one := new(Notice)
 		var localJoinCol int64
 
-		err = results.Scan(&one.ID, &one.Title, &one.Content, &one.Language, &localJoinCol)
+		err = results.Scan(&one.ID, &one.Title, &one.Content, &one.Language, &one.Published, &localJoinCol)
 		if err != nil {
 			return errors.Wrap(err, "failed to scan eager loaded results for notices")
 		}
@@ -45,12 +45,45 @@ type Database struct {
 
 	DeniedKeys DeniedKeys
 
+	Peers Peers
+
+	Presence Presence
+
 	PinnedNotices PinnedNotices
 	Notices       Notices
+	Pages         Pages
+	News          News
+
+	CodeOfConduct CodeOfConduct
+
+	JoinRequests JoinRequests
+}
+
+// DefaultSessionRetention is how long the sessions of the members are kept, unless WithSessionRetention is used
+const DefaultSessionRetention = 90 * 24 * time.Hour
+
+// Option changes how the database is opened and maintained
+type Option func(*options)
+
+type options struct {
+	sessionRetention time.Duration
+}
+
+// WithSessionRetention changes how long the sessions of the members are kept by the cleanup routine.
+// Zero keeps them until a member has too many. The totals of a member are never deleted.
+func WithSessionRetention(d time.Duration) Option {
+	return func(o *options) {
+		o.sessionRetention = d
+	}
 }
 
 // Open looks for a database file 'fname'
-func Open(r repo.Interface) (*Database, error) {
+func Open(r repo.Interface, opts ...Option) (*Database, error) {
+	o := options{sessionRetention: DefaultSessionRetention}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	fname := r.GetPath("roomdb")
 
 	if dir := filepath.Dir(fname); dir != "" {
@@ -81,17 +114,17 @@ func Open(r repo.Interface) (*Database, error) {
 		log.Printf("roomdb: applied %d migrations", n)
 	}
 
-	if err := cleanup(db); err != nil {
+	if err := cleanup(db, o); err != nil {
 		return nil, err
 	}
 
-	// scrub old invites and reset tokens
+	// scrub old invites, reset tokens and sessions
 	go func() { // server might not restart as often
 		fiveDays := 5 * 24 * time.Hour
 		ticker := time.NewTicker(fiveDays)
 		for range ticker.C {
 			err := transact(db, func(tx *sql.Tx) error {
-				return cleanup(tx)
+				return cleanup(tx, o)
 			})
 			if err != nil {
 				// TODO: hook up logging
@@ -108,18 +141,24 @@ func Open(r repo.Interface) (*Database, error) {
 		Aliases:       Aliases{db},
 		AuthFallback:  AuthFallback{db},
 		AuthWithSSB:   AuthWithSSB{db},
+		CodeOfConduct: CodeOfConduct{db},
 		Config:        Config{db},
 		DeniedKeys:    DeniedKeys{db},
 		Invites:       Invites{db: db, members: ml},
+		JoinRequests:  JoinRequests{db: db, members: ml},
 		Notices:       Notices{db},
 		Members:       ml,
+		Pages:         Pages{db},
+		News:          News{db},
+		Peers:         Peers{db},
 		PinnedNotices: PinnedNotices{db},
+		Presence:      Presence{db},
 	}
 
 	return roomdb, nil
 }
 
-func cleanup(db boil.ContextExecutor) error {
+func cleanup(db boil.ContextExecutor, o options) error {
 	if err := deleteExpiredAuthWithSSBSessions(db); err != nil {
 		return err
 	}
@@ -131,6 +170,14 @@ func cleanup(db boil.ContextExecutor) error {
 	if err := deleteConsumedResetTokens(db); err != nil {
 		return err
 	}
+
+	if err := deleteOldJoinRequests(db); err != nil {
+		return err
+	}
+
+	if err := deleteOldSessions(db, o.sessionRetention); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -5,14 +5,18 @@
 package sqlite
 
 import (
+	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	_ "modernc.org/sqlite"
 
 	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite/models"
 )
 
 // verify the database opens and migrates successfully from zero state
@@ -28,3 +32,60 @@ func TestSchema(t *testing.T) {
 	err = db.Close()
 	require.NoError(t, err)
 }
+
+// the models are generated by sqlboiler from the migrated schema (see generate_models.sh).
+// this catches migrations that were added without regenerating them.
+func TestModelsMatchSchema(t *testing.T) {
+	r := require.New(t)
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	db, err := Open(repo.New(testRepo))
+	r.NoError(err)
+	defer db.Close()
+
+	for table, columns := range map[string]interface{}{
+		models.TableNames.SIWSSBSessions:           models.SIWSSBSessionColumns,
+		models.TableNames.Aliases:                  models.AliasColumns,
+		models.TableNames.CodeOfConductAcceptances: models.CodeOfConductAcceptanceColumns,
+		models.TableNames.CodeOfConductVersions:    models.CodeOfConductVersionColumns,
+		models.TableNames.Config:                   models.ConfigColumns,
+		models.TableNames.DeniedKeys:               models.DeniedKeyColumns,
+		models.TableNames.FallbackPasswords:        models.FallbackPasswordColumns,
+		models.TableNames.FallbackResetTokens:      models.FallbackResetTokenColumns,
+		models.TableNames.Invites:                  models.InviteColumns,
+		models.TableNames.JoinRequests:             models.JoinRequestColumns,
+		models.TableNames.MemberPresence:           models.MemberPresenceColumns,
+		models.TableNames.MemberSessions:           models.MemberSessionColumns,
+		models.TableNames.Members:                  models.MemberColumns,
+		models.TableNames.NewsPosts:                models.NewsPostColumns,
+		models.TableNames.NoticeRevisions:          models.NoticeRevisionColumns,
+		models.TableNames.Notices:                  models.NoticeColumns,
+		models.TableNames.Peers:                    models.PeerColumns,
+		models.TableNames.Pins:                     models.PinColumns,
+	} {
+		var want []string
+		v := reflect.ValueOf(columns)
+		for i := 0; i < v.NumField(); i++ {
+			want = append(want, v.Field(i).String())
+		}
+
+		rows, err := db.db.Query(fmt.Sprintf("PRAGMA table_info(%q)", table))
+		r.NoError(err)
+		var got []string
+		for rows.Next() {
+			var (
+				cid, notNull, pk int
+				name, typ        string
+				dflt             sql.NullString
+			)
+			r.NoError(rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk))
+			got = append(got, name)
+		}
+		r.NoError(rows.Err())
+		rows.Close()
+
+		r.Equal(want, got, "columns of table %s", table)
+	}
+}
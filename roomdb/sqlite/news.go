@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite/models"
+)
+
+// compiler assertion to ensure the struct fullfills the interface
+var _ roomdb.NewsService = (*News)(nil)
+
+// News are the dated posts of the room, each one pointing to a notice
+type News struct {
+	db *sql.DB
+}
+
+// List returns the published posts in that language, the newest first
+func (n News) List(ctx context.Context, language string) ([]roomdb.NewsPost, error) {
+	mods := []qm.QueryMod{
+		qm.InnerJoin("notices ON notices.id = news_posts.notice_id"),
+		qm.Where("notices.published = ?", true),
+		qm.OrderBy("news_posts.published_at DESC, news_posts.id DESC"),
+	}
+	if language != "" {
+		mods = append(mods, qm.Where("notices.language = ?", language))
+	}
+
+	entries, err := models.NewsPosts(mods...).All(ctx, n.db)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return []roomdb.NewsPost{}, nil
+	}
+
+	noticeIDs := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		noticeIDs[i] = entry.NoticeID
+	}
+
+	notices, err := models.Notices(qm.WhereIn("id IN ?", noticeIDs...)).All(ctx, n.db)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*models.Notice, len(notices))
+	for _, notice := range notices {
+		byID[notice.ID] = notice
+	}
+
+	lst := make([]roomdb.NewsPost, 0, len(entries))
+	for _, entry := range entries {
+		notice, has := byID[entry.NoticeID]
+		if !has {
+			continue
+		}
+		lst = append(lst, convertNewsPost(entry, notice))
+	}
+	return lst, nil
+}
+
+// GetByID returns the post with that ID
+func (n News) GetByID(ctx context.Context, id int64) (roomdb.NewsPost, error) {
+	entry, err := models.FindNewsPost(ctx, n.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.NewsPost{}, roomdb.ErrNotFound
+		}
+		return roomdb.NewsPost{}, err
+	}
+
+	notice, err := models.FindNotice(ctx, n.db, entry.NoticeID)
+	if err != nil {
+		return roomdb.NewsPost{}, err
+	}
+
+	return convertNewsPost(entry, notice), nil
+}
+
+// Add publishes the notice as a new post, dated now
+func (n News) Add(ctx context.Context, noticeID int64) (roomdb.NewsPost, error) {
+	var post roomdb.NewsPost
+	err := transact(n.db, func(tx *sql.Tx) error {
+		var err error
+		post, err = addNewsPost(ctx, tx, noticeID)
+		return err
+	})
+	if err != nil {
+		return roomdb.NewsPost{}, err
+	}
+	return post, nil
+}
+
+// Create saves the new notice and publishes it as a post, in one transaction
+func (n News) Create(ctx context.Context, notice *roomdb.Notice, author int64) (roomdb.NewsPost, error) {
+	if notice.ID != 0 {
+		return roomdb.NewsPost{}, fmt.Errorf("news: notice %d already exists", notice.ID)
+	}
+
+	var post roomdb.NewsPost
+	err := transact(n.db, func(tx *sql.Tx) error {
+		if err := saveNotice(ctx, tx, notice, author); err != nil {
+			return err
+		}
+
+		var err error
+		post, err = addNewsPost(ctx, tx, notice.ID)
+		return err
+	})
+	if err != nil {
+		// nothing was stored
+		notice.ID = 0
+		return roomdb.NewsPost{}, err
+	}
+	return post, nil
+}
+
+func addNewsPost(ctx context.Context, tx *sql.Tx, noticeID int64) (roomdb.NewsPost, error) {
+	notice, err := models.FindNotice(ctx, tx, noticeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.NewsPost{}, roomdb.ErrNotFound
+		}
+		return roomdb.NewsPost{}, err
+	}
+
+	var entry models.NewsPost
+	entry.NoticeID = notice.ID
+	entry.PublishedAt = time.Now()
+
+	err = entry.Insert(ctx, tx, boil.Whitelist("notice_id", "published_at"))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return roomdb.NewsPost{}, fmt.Errorf("news: notice %d is already posted", noticeID)
+		}
+		return roomdb.NewsPost{}, err
+	}
+
+	return convertNewsPost(&entry, notice), nil
+}
+
+// Remove deletes the post together with its notice and the revisions of it
+func (n News) Remove(ctx context.Context, id int64) error {
+	return transact(n.db, func(tx *sql.Tx) error {
+		entry, err := models.FindNewsPost(ctx, tx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return roomdb.ErrNotFound
+			}
+			return err
+		}
+
+		// the post goes with it, the foreign key cascades
+		_, err = models.Notices(qm.Where("id = ?", entry.NoticeID)).DeleteAll(ctx, tx)
+		return err
+	})
+}
+
+func convertNewsPost(entry *models.NewsPost, notice *models.Notice) roomdb.NewsPost {
+	return roomdb.NewsPost{
+		ID: entry.ID,
+		Notice: roomdb.Notice{
+			ID:        notice.ID,
+			Title:     notice.Title,
+			Content:   notice.Content,
+			Language:  notice.Language,
+			Published: notice.Published,
+		},
+		PublishedAt: entry.PublishedAt,
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNews(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	r.NoError(err)
+	defer db.Close()
+
+	lst, err := db.News.List(ctx, "")
+	r.NoError(err)
+	r.Len(lst, 0)
+
+	_, err = db.News.Add(ctx, 9999)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	// two posts in english and one in german
+	var noticeIDs []int64
+	for _, n := range []roomdb.Notice{
+		{Title: "Hello", Content: "we are open", Language: "en"},
+		{Title: "Hallo", Content: "wir sind offen", Language: "de"},
+		{Title: "Maintenance", Content: "down on sunday", Language: "en"},
+	} {
+		notice := n
+		r.NoError(db.Notices.Save(ctx, &notice, 1))
+
+		post, err := db.News.Add(ctx, notice.ID)
+		r.NoError(err)
+		r.Equal(notice.Title, post.Notice.Title)
+		r.False(post.PublishedAt.IsZero())
+
+		noticeIDs = append(noticeIDs, notice.ID)
+	}
+
+	_, err = db.News.Add(ctx, noticeIDs[0])
+	r.Error(err, "a notice can only be posted once")
+
+	// the notice and the post in one go
+	created := roomdb.Notice{Title: "Closed", Content: "see you next year", Language: "en"}
+	post, err := db.News.Create(ctx, &created, 1)
+	r.NoError(err)
+	r.NotEqual(int64(0), created.ID)
+	r.Equal(created.ID, post.Notice.ID)
+	r.True(post.Notice.Published)
+
+	revs, err := db.Notices.ListRevisions(ctx, created.ID)
+	r.NoError(err)
+	r.Len(revs, 1)
+
+	existing := roomdb.Notice{ID: noticeIDs[1], Title: "Hallo", Content: "wir sind offen", Language: "de"}
+	_, err = db.News.Create(ctx, &existing, 1)
+	r.Error(err, "only new notices can be created")
+
+	r.NoError(db.News.Remove(ctx, post.ID))
+
+	// drafts are not listed
+	draft := roomdb.Notice{Title: "Soon", Content: "tbd", Language: "en"}
+	_, err = db.Notices.SaveDraft(ctx, &draft, 1)
+	r.NoError(err)
+	_, err = db.News.Add(ctx, draft.ID)
+	r.NoError(err)
+
+	all, err := db.News.List(ctx, "")
+	r.NoError(err)
+	r.Len(all, 3)
+	r.Equal("Maintenance", all[0].Notice.Title, "not the newest first")
+	r.Equal("Hallo", all[1].Notice.Title)
+	r.Equal("Hello", all[2].Notice.Title)
+
+	lst, err = db.News.List(ctx, "en")
+	r.NoError(err)
+	r.Len(lst, 2)
+	r.Equal("Maintenance", lst[0].Notice.Title)
+	r.Equal("Hello", lst[1].Notice.Title)
+
+	lst, err = db.News.List(ctx, "fr")
+	r.NoError(err)
+	r.Len(lst, 0)
+
+	got, err := db.News.GetByID(ctx, all[1].ID)
+	r.NoError(err)
+	r.Equal("de", got.Notice.Language)
+
+	// removing a post also removes the notice
+	r.NoError(db.News.Remove(ctx, got.ID))
+
+	_, err = db.News.GetByID(ctx, got.ID)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	_, err = db.Notices.GetByID(ctx, got.Notice.ID)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	r.ErrorIs(db.News.Remove(ctx, got.ID), roomdb.ErrNotFound)
+
+	lst, err = db.News.List(ctx, "")
+	r.NoError(err)
+	r.Len(lst, 2)
+}
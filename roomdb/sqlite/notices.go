@@ -8,6 +8,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/friendsofgo/errors"
 	"github.com/volatiletech/sqlboiler/v4/boil"
@@ -28,7 +29,11 @@ type PinnedNotices struct {
 func (pn PinnedNotices) List(ctx context.Context) (roomdb.PinnedNotices, error) {
 
 	// get all the pins and eager-load the related notices
-	lst, err := models.Pins(qm.Load("Notices")).All(ctx, pn.db)
+	// the pages that admins added are not pinned notices, see Pages
+	lst, err := models.Pins(
+		qm.WhereIn("name IN ?", pinnedNames()...),
+		qm.Load("Notices"),
+	).All(ctx, pn.db)
 	if err != nil {
 		return nil, err
 	}
@@ -55,10 +60,11 @@ func (pn PinnedNotices) List(ctx context.Context) (roomdb.PinnedNotices, error)
 		// add all the related notice to the slice
 		for _, n := range entry.R.Notices {
 			relatedNotice := roomdb.Notice{
-				ID:       n.ID,
-				Title:    n.Title,
-				Content:  n.Content,
-				Language: n.Language,
+				ID:        n.ID,
+				Title:     n.Title,
+				Content:   n.Content,
+				Language:  n.Language,
+				Published: n.Published,
 			}
 			notices = append(notices, relatedNotice)
 		}
@@ -73,7 +79,7 @@ func (pn PinnedNotices) List(ctx context.Context) (roomdb.PinnedNotices, error)
 func (pn PinnedNotices) Get(ctx context.Context, name roomdb.PinnedNoticeName, lang string) (*roomdb.Notice, error) {
 	p, err := models.Pins(
 		qm.Where("name = ?", name),
-		qm.Load("Notices", qm.Where("language = ?", lang)),
+		qm.Load("Notices", qm.Where("language = ? AND published = ?", lang, true)),
 	).One(ctx, pn.db)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -82,6 +88,11 @@ func (pn PinnedNotices) Get(ctx context.Context, name roomdb.PinnedNoticeName, l
 		return nil, err
 	}
 
+	// the notice might only exist as a draft
+	if len(p.R.Notices) == 0 {
+		return nil, roomdb.ErrNotFound
+	}
+
 	if n := len(p.R.Notices); n != 1 {
 		return nil, fmt.Errorf("pinnedNotice: expected 1 notice but got %d", n)
 	}
@@ -89,10 +100,11 @@ func (pn PinnedNotices) Get(ctx context.Context, name roomdb.PinnedNoticeName, l
 	modelNotice := p.R.Notices[0]
 
 	return &roomdb.Notice{
-		ID:       modelNotice.ID,
-		Title:    modelNotice.Title,
-		Content:  modelNotice.Content,
-		Language: modelNotice.Language,
+		ID:        modelNotice.ID,
+		Title:     modelNotice.Title,
+		Content:   modelNotice.Content,
+		Language:  modelNotice.Language,
+		Published: modelNotice.Published,
 	}, nil
 }
 
@@ -145,6 +157,7 @@ func (n Notices) GetByID(ctx context.Context, id int64) (roomdb.Notice, error) {
 	notice.Title = dbEntry.Title
 	notice.Language = dbEntry.Language
 	notice.Content = dbEntry.Content
+	notice.Published = dbEntry.Published
 
 	return notice, nil
 }
@@ -166,32 +179,161 @@ func (n Notices) RemoveID(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (n Notices) Save(ctx context.Context, p *roomdb.Notice) error {
+func (n Notices) Save(ctx context.Context, p *roomdb.Notice, author int64) error {
+	return transact(n.db, func(tx *sql.Tx) error {
+		return saveNotice(ctx, tx, p, author)
+	})
+}
+
+// saveNotice inserts or updates p as a published notice, together with a new revision
+func saveNotice(ctx context.Context, tx *sql.Tx, p *roomdb.Notice, author int64) error {
+	p.Published = true
+
+	var entry models.Notice
+	entry.ID = p.ID
+	entry.Title = p.Title
+	entry.Content = p.Content
+	entry.Language = p.Language
+	entry.Published = true
+
 	if p.ID == 0 {
-		var newEntry models.Notice
-		newEntry.Title = p.Title
-		newEntry.Content = p.Content
-		newEntry.Language = p.Language
-		err := newEntry.Insert(ctx, n.db, boil.Whitelist("title", "content", "language"))
+		err := entry.Insert(ctx, tx, boil.Whitelist("title", "content", "language", "published"))
 		if err != nil {
 			return err
 		}
-		p.ID = newEntry.ID
-		return nil
+		p.ID = entry.ID
+	} else {
+		updated, err := entry.Update(ctx, tx, boil.Whitelist("title", "content", "language", "published"))
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return roomdb.ErrNotFound
+		}
+	}
+
+	_, err := insertRevision(ctx, tx, p.ID, p.Title, p.Content, author, false)
+	return err
+}
+
+func (n Notices) SaveDraft(ctx context.Context, p *roomdb.Notice, author int64) (roomdb.NoticeRevision, error) {
+	var rev roomdb.NoticeRevision
+	err := transact(n.db, func(tx *sql.Tx) error {
+		if p.ID == 0 {
+			// the new notice is not shown until one of its revisions is published
+			var entry models.Notice
+			entry.Title = p.Title
+			entry.Content = p.Content
+			entry.Language = p.Language
+			entry.Published = false
+			err := entry.Insert(ctx, tx, boil.Whitelist("title", "content", "language", "published"))
+			if err != nil {
+				return err
+			}
+			p.ID = entry.ID
+			p.Published = false
+		} else {
+			existing, err := models.FindNotice(ctx, tx, p.ID, "id", "published")
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return roomdb.ErrNotFound
+				}
+				return err
+			}
+			p.Published = existing.Published
+		}
+
+		var err error
+		rev, err = insertRevision(ctx, tx, p.ID, p.Title, p.Content, author, true)
+		return err
+	})
+	return rev, err
+}
+
+func (n Notices) ListRevisions(ctx context.Context, noticeID int64) ([]roomdb.NoticeRevision, error) {
+	exists, err := models.NoticeExists(ctx, n.db, noticeID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, roomdb.ErrNotFound
+	}
+
+	entries, err := models.NoticeRevisions(
+		qm.Where("notice_id = ?", noticeID),
+		qm.OrderBy("id DESC"),
+	).All(ctx, n.db)
+	if err != nil {
+		return nil, err
 	}
 
-	var existing models.Notice
-	existing.ID = p.ID
-	existing.Title = p.Title
-	existing.Content = p.Content
-	existing.Language = p.Language
-	_, err := existing.Update(ctx, n.db, boil.Whitelist("title", "content", "language"))
+	revs := make([]roomdb.NoticeRevision, len(entries))
+	for i, entry := range entries {
+		revs[i] = convertRevision(entry)
+	}
+	return revs, nil
+}
+
+func (n Notices) GetRevision(ctx context.Context, id int64) (roomdb.NoticeRevision, error) {
+	entry, err := models.FindNoticeRevision(ctx, n.db, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return roomdb.ErrNotFound
+			return roomdb.NoticeRevision{}, roomdb.ErrNotFound
+		}
+		return roomdb.NoticeRevision{}, err
+	}
+	return convertRevision(entry), nil
+}
+
+func (n Notices) Revert(ctx context.Context, revisionID int64, author int64) error {
+	return transact(n.db, func(tx *sql.Tx) error {
+		old, err := models.FindNoticeRevision(ctx, tx, revisionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return roomdb.ErrNotFound
+			}
+			return err
 		}
+
+		var entry models.Notice
+		entry.ID = old.NoticeID
+		entry.Title = old.Title
+		entry.Content = old.Content
+		entry.Published = true
+		_, err = entry.Update(ctx, tx, boil.Whitelist("title", "content", "published"))
+		if err != nil {
+			return err
+		}
+
+		_, err = insertRevision(ctx, tx, old.NoticeID, old.Title, old.Content, author, false)
 		return err
+	})
+}
+
+func insertRevision(ctx context.Context, tx *sql.Tx, noticeID int64, title, content string, author int64, draft bool) (roomdb.NoticeRevision, error) {
+	var entry models.NoticeRevision
+	entry.NoticeID = noticeID
+	entry.Title = title
+	entry.Content = content
+	entry.Author = author
+	entry.Draft = draft
+	entry.CreatedAt = time.Now()
+
+	err := entry.Insert(ctx, tx, boil.Whitelist("notice_id", "title", "content", "author", "draft", "created_at"))
+	if err != nil {
+		return roomdb.NoticeRevision{}, err
 	}
+	return convertRevision(&entry), nil
+}
 
-	return nil
+func convertRevision(entry *models.NoticeRevision) roomdb.NoticeRevision {
+	return roomdb.NoticeRevision{
+		ID:        entry.ID,
+		NoticeID:  entry.NoticeID,
+		Title:     entry.Title,
+		Content:   entry.Content,
+		Author:    entry.Author,
+		Draft:     entry.Draft,
+		CreatedAt: entry.CreatedAt,
+	}
 }
@@ -52,7 +52,7 @@ func TestNoticesCRUD(t *testing.T) {
 		n.Content = `# This is **not** a test!`
 		n.Language = "en-GB"
 
-		err := db.Notices.Save(ctx, &n)
+		err := db.Notices.Save(ctx, &n, 0)
 		r.NoError(err, "failed to save")
 		r.NotEqual(0, n.ID, "should have a fresh id")
 
@@ -64,7 +64,7 @@ func TestNoticesCRUD(t *testing.T) {
 
 		oldID := n.ID
 		n.Title = fmt.Sprintf("Updated test notice %d", rand.Int())
-		err = db.Notices.Save(ctx, &n)
+		err = db.Notices.Save(ctx, &n, 0)
 		r.NoError(err, "failed to save")
 		r.Equal(oldID, n.ID, "should have the same ID")
 
@@ -135,7 +135,7 @@ func TestPinnedNotices(t *testing.T) {
 		notice.Content = "solo una prueba"
 		notice.Language = "es"
 		// save the new notice
-		err = db.Notices.Save(ctx, &notice)
+		err = db.Notices.Save(ctx, &notice, 0)
 		r.NoError(err)
 
 		// set it
@@ -166,3 +166,106 @@ func TestPinnedNotices(t *testing.T) {
 	})
 
 }
+
+func TestNoticeRevisions(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	r.NoError(err)
+	defer db.Close()
+
+	// the default notices start with one revision each
+	pinned, err := db.PinnedNotices.List(ctx)
+	r.NoError(err)
+	description := pinned[roomdb.NoticeDescription][0]
+	r.True(description.Published)
+
+	revs, err := db.Notices.ListRevisions(ctx, description.ID)
+	r.NoError(err)
+	r.Len(revs, 1)
+	r.Equal(description.Title, revs[0].Title)
+	r.Equal(description.Content, revs[0].Content)
+	r.False(revs[0].Draft)
+
+	_, err = db.Notices.ListRevisions(ctx, 9999)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	_, err = db.Notices.GetRevision(ctx, 9999)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	err = db.Notices.Revert(ctx, 9999, 1)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	// a new notice
+	n := roomdb.Notice{Title: "Rules", Content: "be nice", Language: "en-GB"}
+	r.NoError(db.Notices.Save(ctx, &n, 1))
+	r.True(n.Published)
+
+	// drafts don't change what is shown
+	n.Content = "be **very** nice"
+	draft, err := db.Notices.SaveDraft(ctx, &n, 2)
+	r.NoError(err)
+	r.True(draft.Draft)
+	r.EqualValues(2, draft.Author)
+	r.Equal(n.ID, draft.NoticeID)
+
+	got, err := db.Notices.GetByID(ctx, n.ID)
+	r.NoError(err)
+	r.Equal("be nice", got.Content)
+	r.True(got.Published)
+
+	revs, err = db.Notices.ListRevisions(ctx, n.ID)
+	r.NoError(err)
+	r.Len(revs, 2)
+	r.Equal(draft.ID, revs[0].ID, "newest first")
+	r.EqualValues(1, revs[1].Author)
+	r.False(revs[1].Draft)
+	firstID := revs[1].ID
+
+	// publishing the draft
+	r.NoError(db.Notices.Revert(ctx, draft.ID, 1))
+	got, err = db.Notices.GetByID(ctx, n.ID)
+	r.NoError(err)
+	r.Equal("be **very** nice", got.Content)
+
+	// and going back to the first one
+	r.NoError(db.Notices.Revert(ctx, firstID, 3))
+	got, err = db.Notices.GetByID(ctx, n.ID)
+	r.NoError(err)
+	r.Equal("be nice", got.Content)
+
+	revs, err = db.Notices.ListRevisions(ctx, n.ID)
+	r.NoError(err)
+	r.Len(revs, 4)
+	r.EqualValues(3, revs[0].Author)
+	r.False(revs[0].Draft)
+
+	rev, err := db.Notices.GetRevision(ctx, firstID)
+	r.NoError(err)
+	r.Equal("be nice", rev.Content)
+
+	// new notices that are only drafts are not published
+	var fresh roomdb.Notice
+	fresh.Title = "Code of conduct"
+	fresh.Content = "tbd"
+	fresh.Language = "es"
+	_, err = db.Notices.SaveDraft(ctx, &fresh, 1)
+	r.NoError(err)
+	r.NotEqual(int64(0), fresh.ID)
+	r.False(fresh.Published)
+
+	r.NoError(db.PinnedNotices.Set(ctx, roomdb.NoticeCodeOfConduct, fresh.ID))
+	_, err = db.PinnedNotices.Get(ctx, roomdb.NoticeCodeOfConduct, "es")
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	// the revisions go away with the notice
+	r.NoError(db.Notices.RemoveID(ctx, n.ID))
+	_, err = db.Notices.GetRevision(ctx, firstID)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+}
@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite/models"
+)
+
+// compiler assertion to ensure the struct fullfills the interface
+var _ roomdb.PagesService = (*Pages)(nil)
+
+// Pages are the rows of the pins table that are not one of the well known pinned notices
+type Pages struct {
+	db *sql.DB
+}
+
+// pinnedNames returns the names of the well known pins as query arguments
+func pinnedNames() []interface{} {
+	return []interface{}{
+		roomdb.NoticeDescription.String(),
+		roomdb.NoticeNews.String(),
+		roomdb.NoticePrivacyPolicy.String(),
+		roomdb.NoticeCodeOfConduct.String(),
+	}
+}
+
+// List returns the added pages, ordered by their menu position and then by name
+func (p Pages) List(ctx context.Context) ([]roomdb.Page, error) {
+	all, err := models.Pins(
+		qm.WhereNotIn("name NOT IN ?", pinnedNames()...),
+		qm.Load("Notices", qm.OrderBy("language")),
+		qm.OrderBy("menu_position, name"),
+	).All(ctx, p.db)
+	if err != nil {
+		return nil, err
+	}
+
+	lst := make([]roomdb.Page, len(all))
+	for i, entry := range all {
+		lst[i] = convertPage(entry)
+	}
+	return lst, nil
+}
+
+// GetByID returns the added page with that ID
+func (p Pages) GetByID(ctx context.Context, id int64) (roomdb.Page, error) {
+	entry, err := models.Pins(
+		qm.Where("id = ?", id),
+		qm.WhereNotIn("name NOT IN ?", pinnedNames()...),
+		qm.Load("Notices", qm.OrderBy("language")),
+	).One(ctx, p.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.Page{}, roomdb.ErrNotFound
+		}
+		return roomdb.Page{}, err
+	}
+	return convertPage(entry), nil
+}
+
+// GetBySlug returns the page with that slug, pinned notices included
+func (p Pages) GetBySlug(ctx context.Context, slug string) (roomdb.Page, error) {
+	entry, err := models.Pins(
+		qm.Where("slug = ?", slug),
+		qm.Load("Notices", qm.OrderBy("language")),
+	).One(ctx, p.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.Page{}, roomdb.ErrNotFound
+		}
+		return roomdb.Page{}, err
+	}
+	return convertPage(entry), nil
+}
+
+// Create adds a new page without translations
+func (p Pages) Create(ctx context.Context, page roomdb.Page) (int64, error) {
+	if err := page.Validate(); err != nil {
+		return -1, fmt.Errorf("pages: %w", err)
+	}
+
+	var entry models.Pin
+	entry.Name = page.Name
+	entry.Slug = page.Slug
+	entry.MenuPosition = page.MenuPosition
+
+	err := entry.Insert(ctx, p.db, boil.Whitelist("name", "slug", "menu_position"))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return -1, roomdb.ErrPageSlugTaken{Slug: page.Slug}
+		}
+		return -1, fmt.Errorf("pages: failed to insert new page %s: %w", page.Slug, err)
+	}
+
+	return entry.ID, nil
+}
+
+// Update changes the name, slug and menu position of an added page
+func (p Pages) Update(ctx context.Context, page roomdb.Page) error {
+	if err := page.Validate(); err != nil {
+		return fmt.Errorf("pages: %w", err)
+	}
+
+	n, err := models.Pins(
+		qm.Where("id = ?", page.ID),
+		qm.WhereNotIn("name NOT IN ?", pinnedNames()...),
+	).UpdateAll(ctx, p.db, models.M{
+		"name":          page.Name,
+		"slug":          page.Slug,
+		"menu_position": page.MenuPosition,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return roomdb.ErrPageSlugTaken{Slug: page.Slug}
+		}
+		return err
+	}
+	if n == 0 {
+		return roomdb.ErrNotFound
+	}
+	return nil
+}
+
+// AddTranslation makes the notice a translation of the page
+func (p Pages) AddTranslation(ctx context.Context, pageID, noticeID int64) error {
+	return transact(p.db, func(tx *sql.Tx) error {
+		page, err := models.Pins(
+			qm.Where("id = ?", pageID),
+			qm.WhereNotIn("name NOT IN ?", pinnedNames()...),
+		).One(ctx, tx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return roomdb.ErrNotFound
+			}
+			return err
+		}
+
+		n, err := models.FindNotice(ctx, tx, noticeID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return roomdb.ErrNotFound
+			}
+			return err
+		}
+
+		return page.AddNotices(ctx, tx, false, n)
+	})
+}
+
+// Remove deletes an added page, its translations and their revisions
+func (p Pages) Remove(ctx context.Context, id int64) error {
+	return transact(p.db, func(tx *sql.Tx) error {
+		page, err := models.Pins(
+			qm.Where("id = ?", id),
+			qm.WhereNotIn("name NOT IN ?", pinnedNames()...),
+			qm.Load("Notices"),
+		).One(ctx, tx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return roomdb.ErrNotFound
+			}
+			return err
+		}
+
+		// RemoveNotices reorders R.Notices, so keep a copy for deleting them afterwards
+		translations := append(models.NoticeSlice{}, page.R.Notices...)
+		if err := page.RemoveNotices(ctx, tx, translations...); err != nil {
+			return err
+		}
+
+		if _, err := translations.DeleteAll(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err = page.Delete(ctx, tx)
+		return err
+	})
+}
+
+func convertPage(entry *models.Pin) roomdb.Page {
+	page := roomdb.Page{
+		ID:           entry.ID,
+		Name:         entry.Name,
+		Slug:         entry.Slug,
+		MenuPosition: entry.MenuPosition,
+	}
+
+	if entry.R != nil {
+		for _, n := range entry.R.Notices {
+			page.Translations = append(page.Translations, roomdb.Notice{
+				ID:        n.ID,
+				Title:     n.Title,
+				Content:   n.Content,
+				Language:  n.Language,
+				Published: n.Published,
+			})
+		}
+	}
+
+	return page
+}
+
+func isUniqueViolation(err error) bool {
+	var sqlErr *sqlite.Error
+	return errors.As(err, &sqlErr) && sqlErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
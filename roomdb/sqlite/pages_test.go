@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite/models"
+)
+
+func TestPages(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	r.NoError(err)
+	defer db.Close()
+
+	// the pinned notices have slugs but are not in the list
+	lst, err := db.Pages.List(ctx)
+	r.NoError(err)
+	r.Len(lst, 0)
+
+	coc, err := db.Pages.GetBySlug(ctx, "code-of-conduct")
+	r.NoError(err)
+	r.Equal(roomdb.NoticeCodeOfConduct.String(), coc.Name)
+	r.Len(coc.Translations, 1)
+
+	_, err = db.Pages.GetByID(ctx, coc.ID)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	err = db.Pages.Update(ctx, roomdb.Page{ID: coc.ID, Name: "Rules", Slug: "rules"})
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	err = db.Pages.Remove(ctx, coc.ID)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	_, err = db.Pages.GetBySlug(ctx, "faq")
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	// invalid pages
+	invalid := []roomdb.Page{
+		{Name: "", Slug: "faq"},
+		{Name: "FAQ", Slug: ""},
+		{Name: "FAQ", Slug: "Frequently Asked"},
+		{Name: "FAQ", Slug: "faq-"},
+		{Name: roomdb.NoticeNews.String(), Slug: "faq"},
+	}
+	for i, p := range invalid {
+		_, err = db.Pages.Create(ctx, p)
+		r.Error(err, "case %d", i)
+	}
+
+	// new pages
+	faqID, err := db.Pages.Create(ctx, roomdb.Page{Name: "FAQ", Slug: "faq", MenuPosition: 2})
+	r.NoError(err)
+
+	guideID, err := db.Pages.Create(ctx, roomdb.Page{Name: "Getting started", Slug: "getting-started", MenuPosition: 1})
+	r.NoError(err)
+
+	_, err = db.Pages.Create(ctx, roomdb.Page{Name: "Questions", Slug: "faq"})
+	var taken roomdb.ErrPageSlugTaken
+	r.True(errors.As(err, &taken), "wrong error: %v", err)
+	r.Equal("faq", taken.Slug)
+
+	_, err = db.Pages.Create(ctx, roomdb.Page{Name: "FAQ", Slug: "questions"})
+	r.True(errors.As(err, &taken), "wrong error: %v", err)
+
+	_, err = db.Pages.Create(ctx, roomdb.Page{Name: "News", Slug: "news"})
+	r.True(errors.As(err, &taken), "wrong error: %v", err)
+
+	lst, err = db.Pages.List(ctx)
+	r.NoError(err)
+	r.Len(lst, 2)
+	r.Equal(guideID, lst[0].ID, "lowest position first")
+	r.Equal(faqID, lst[1].ID)
+
+	// translations
+	en := roomdb.Notice{Title: "FAQ", Content: "ask away", Language: "en"}
+	r.NoError(db.Notices.Save(ctx, &en, 1))
+	r.NoError(db.Pages.AddTranslation(ctx, faqID, en.ID))
+
+	de := roomdb.Notice{Title: "Häufige Fragen", Content: "tbd", Language: "de"}
+	_, err = db.Notices.SaveDraft(ctx, &de, 1)
+	r.NoError(err)
+	r.NoError(db.Pages.AddTranslation(ctx, faqID, de.ID))
+
+	err = db.Pages.AddTranslation(ctx, faqID, 9999)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+	err = db.Pages.AddTranslation(ctx, coc.ID, en.ID)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	// the eager loading of the models knows the new columns
+	notice, err := models.Notices(qm.Where("id = ?", en.ID), qm.Load(models.NoticeRels.Pins)).One(ctx, db.db)
+	r.NoError(err)
+	r.Len(notice.R.Pins, 1)
+	r.Equal("faq", notice.R.Pins[0].Slug)
+	r.EqualValues(2, notice.R.Pins[0].MenuPosition)
+
+	pin, err := models.Pins(qm.Where("id = ?", faqID), qm.Load(models.PinRels.Notices)).One(ctx, db.db)
+	r.NoError(err)
+	r.Len(pin.R.Notices, 2)
+	for _, n := range pin.R.Notices {
+		r.Equal(n.ID == en.ID, n.Published, "only the english one is published")
+	}
+
+	faq, err := db.Pages.GetBySlug(ctx, "faq")
+	r.NoError(err)
+	r.Equal(faqID, faq.ID)
+	r.Len(faq.Translations, 2)
+
+	// the draft is skipped
+	tr1, has := faq.Translation("de")
+	r.True(has)
+	r.Equal(en.ID, tr1.ID)
+
+	_, has = lst[0].Translation("en")
+	r.False(has)
+
+	// the pinned notices are not pages
+	pinned, err := db.PinnedNotices.List(ctx)
+	r.NoError(err)
+	r.Len(pinned, 4)
+
+	// renaming
+	r.NoError(db.Pages.Update(ctx, roomdb.Page{ID: faqID, Name: "Questions", Slug: "questions", MenuPosition: 0}))
+	_, err = db.Pages.GetBySlug(ctx, "faq")
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	faq, err = db.Pages.GetByID(ctx, faqID)
+	r.NoError(err)
+	r.Equal("Questions", faq.Name)
+	r.Equal("questions", faq.Slug)
+
+	err = db.Pages.Update(ctx, roomdb.Page{ID: guideID, Name: "Getting started", Slug: "questions"})
+	r.True(errors.As(err, &taken), "wrong error: %v", err)
+
+	// removing takes the translations with it
+	r.NoError(db.Pages.Remove(ctx, faqID))
+
+	_, err = db.Pages.GetByID(ctx, faqID)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	_, err = db.Notices.GetByID(ctx, en.ID)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	lst, err = db.Pages.List(ctx)
+	r.NoError(err)
+	r.Len(lst, 1)
+}
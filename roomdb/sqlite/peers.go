@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite/models"
+)
+
+// compiler assertion to ensure the struct fullfills the interface
+var _ roomdb.PeersService = (*Peers)(nil)
+
+// Peers is backed by the peers table
+type Peers struct {
+	db *sql.DB
+}
+
+// Add adds the address to the list.
+func (p Peers) Add(ctx context.Context, address, comment string) (int64, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return -1, fmt.Errorf("peers: address can't be empty")
+	}
+
+	var entry models.Peer
+	entry.Address = address
+	entry.Comment = comment
+
+	err := entry.Insert(ctx, p.db, boil.Whitelist("address", "comment"))
+	if err != nil {
+		var sqlErr *sqlite.Error
+		if errors.As(err, &sqlErr) && sqlErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return -1, fmt.Errorf("peers: address %q is already on the list", address)
+		}
+
+		return -1, fmt.Errorf("peers: failed to insert new entry %s: %w", address, err)
+	}
+
+	return entry.ID, nil
+}
+
+// GetByID returns the entry if a peer with that ID is on the list.
+func (p Peers) GetByID(ctx context.Context, id int64) (roomdb.Peer, error) {
+	var entry roomdb.Peer
+	found, err := models.FindPeer(ctx, p.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entry, roomdb.ErrNotFound
+		}
+		return entry, err
+	}
+
+	entry.ID = found.ID
+	entry.Address = found.Address
+	entry.Comment = found.Comment
+	entry.CreatedAt = found.CreatedAt
+	return entry, nil
+}
+
+// List returns a list of all the peers.
+func (p Peers) List(ctx context.Context) ([]roomdb.Peer, error) {
+	all, err := models.Peers().All(ctx, p.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var lst = make([]roomdb.Peer, len(all))
+	for i, entry := range all {
+		lst[i].ID = entry.ID
+		lst[i].Address = entry.Address
+		lst[i].Comment = entry.Comment
+		lst[i].CreatedAt = entry.CreatedAt
+	}
+
+	return lst, nil
+}
+
+// RemoveID removes the peer from the list.
+func (p Peers) RemoveID(ctx context.Context, id int64) error {
+	entry, err := models.FindPeer(ctx, p.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return roomdb.ErrNotFound
+		}
+		return err
+	}
+
+	_, err = entry.Delete(ctx, p.db)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+)
+
+func TestPeers(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	require.NoError(t, err)
+
+	_, err = db.Peers.Add(ctx, "   ", "empty")
+	r.Error(err)
+
+	const addr = "net:pub.example:8008~shs:Bp5Z5TQKv6E/Y+QZn/3LiDWMPi63EP8MHsXZ4tiIb2w="
+
+	id, err := db.Peers.Add(ctx, addr, "our pub")
+	r.NoError(err)
+
+	_, err = db.Peers.Add(ctx, addr, "again")
+	r.Error(err, "should not add the same address twice")
+
+	lst, err := db.Peers.List(ctx)
+	r.NoError(err)
+	r.Len(lst, 1)
+	r.Equal(id, lst[0].ID)
+	r.Equal(addr, lst[0].Address)
+	r.Equal("our pub", lst[0].Comment)
+	r.False(lst[0].CreatedAt.IsZero())
+
+	p, err := db.Peers.GetByID(ctx, id)
+	r.NoError(err)
+	r.Equal(addr, p.Address)
+
+	_, err = db.Peers.GetByID(ctx, 666)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	err = db.Peers.RemoveID(ctx, 666)
+	r.EqualError(err, roomdb.ErrNotFound.Error())
+
+	err = db.Peers.RemoveID(ctx, id)
+	r.NoError(err)
+
+	lst, err = db.Peers.List(ctx)
+	r.NoError(err)
+	r.Len(lst, 0)
+
+	r.NoError(db.Close())
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomdb/sqlite/models"
+)
+
+// compiler assertion to ensure the struct fullfills the interface
+var _ roomdb.PresenceService = (*Presence)(nil)
+
+// maxSessionsPerMember is how many sessions are kept for each member, older ones are deleted when a new one is recorded
+const maxSessionsPerMember = 50
+
+// Presence is backed by the member_presence and member_sessions tables
+type Presence struct {
+	db *sql.DB
+}
+
+// RecordSession stores a closed connection of the member and adds it to the totals.
+func (p Presence) RecordSession(ctx context.Context, member refs.FeedRef, s roomdb.Session) error {
+	start, end := s.Start.UTC(), s.End.UTC()
+	if end.Before(start) {
+		return fmt.Errorf("presence: session ends before it started")
+	}
+
+	return transact(p.db, func(tx *sql.Tx) error {
+		m, err := models.Members(qm.Where("pub_key = ?", member.String())).One(ctx, tx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return roomdb.ErrNotFound
+			}
+			return err
+		}
+
+		seconds := int64(end.Sub(start) / time.Second)
+
+		totals, err := models.MemberPresences(qm.Where("member_id = ?", m.ID)).One(ctx, tx)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			totals = &models.MemberPresence{
+				MemberID:         m.ID,
+				FirstSeen:        start,
+				LastSeen:         end,
+				ConnectedSeconds: seconds,
+			}
+			err = totals.Insert(ctx, tx, boil.Infer())
+		case err == nil:
+			if start.Before(totals.FirstSeen) {
+				totals.FirstSeen = start
+			}
+			if end.After(totals.LastSeen) {
+				totals.LastSeen = end
+			}
+			totals.ConnectedSeconds += seconds
+			_, err = totals.Update(ctx, tx, boil.Whitelist("first_seen", "last_seen", "connected_seconds"))
+		}
+		if err != nil {
+			return fmt.Errorf("presence: failed to update totals: %w", err)
+		}
+
+		var entry models.MemberSession
+		entry.MemberID = m.ID
+		entry.StartedAt = start
+		entry.EndedAt = end
+		entry.Transport = s.Transport
+		if err := entry.Insert(ctx, tx, boil.Infer()); err != nil {
+			return fmt.Errorf("presence: failed to insert session: %w", err)
+		}
+
+		// only keep the most recent ones
+		sessions, err := models.MemberSessions(
+			qm.Where("member_id = ?", m.ID),
+			qm.OrderBy("ended_at DESC"),
+		).All(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if len(sessions) > maxSessionsPerMember {
+			if _, err := sessions[maxSessionsPerMember:].DeleteAll(ctx, tx); err != nil {
+				return fmt.Errorf("presence: failed to delete old sessions: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetByMemberID returns the totals and the most recent sessions of the member, the newest first.
+func (p Presence) GetByMemberID(ctx context.Context, id int64) (roomdb.Presence, error) {
+	var pr roomdb.Presence
+
+	totals, err := models.MemberPresences(qm.Where("member_id = ?", id)).One(ctx, p.db)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pr, roomdb.ErrNotFound
+		}
+		return pr, err
+	}
+	pr.FirstSeen = totals.FirstSeen
+	pr.LastSeen = totals.LastSeen
+	pr.Connected = time.Duration(totals.ConnectedSeconds) * time.Second
+
+	sessions, err := models.MemberSessions(
+		qm.Where("member_id = ?", id),
+		qm.OrderBy("ended_at DESC"),
+		qm.Limit(maxSessionsPerMember),
+	).All(ctx, p.db)
+	if err != nil {
+		return pr, err
+	}
+
+	pr.Sessions = make([]roomdb.Session, len(sessions))
+	for i, s := range sessions {
+		pr.Sessions[i] = roomdb.Session{
+			Start:     s.StartedAt,
+			End:       s.EndedAt,
+			Transport: s.Transport,
+		}
+	}
+
+	return pr, nil
+}
+
+// deleteOldSessions removes the sessions that ended longer than retention ago. The totals are kept.
+func deleteOldSessions(tx boil.ContextExecutor, retention time.Duration) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	cutoff := fmt.Sprintf("-%d seconds", int64(retention/time.Second))
+	_, err := models.MemberSessions(qm.Where("ended_at < datetime('now', ?)", cutoff)).DeleteAll(context.Background(), tx)
+	if err != nil {
+		return fmt.Errorf("roomdb: failed to delete old member sessions: %w", err)
+	}
+	return nil
+}
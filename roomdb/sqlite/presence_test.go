@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+)
+
+func TestPresence(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	require.NoError(t, err)
+
+	feed, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("1"), 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	id, err := db.Members.Add(ctx, feed, roomdb.RoleMember)
+	r.NoError(err)
+
+	_, err = db.Presence.GetByMemberID(ctx, id)
+	r.ErrorIs(err, roomdb.ErrNotFound, "never connected")
+
+	start := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+	err = db.Presence.RecordSession(ctx, feed, roomdb.Session{
+		Start:     start,
+		End:       start.Add(30 * time.Minute),
+		Transport: roomdb.TransportTCP,
+	})
+	r.NoError(err)
+
+	err = db.Presence.RecordSession(ctx, feed, roomdb.Session{
+		Start:     start.Add(time.Hour),
+		End:       start.Add(2 * time.Hour),
+		Transport: roomdb.TransportWebsocket,
+	})
+	r.NoError(err)
+
+	pr, err := db.Presence.GetByMemberID(ctx, id)
+	r.NoError(err)
+	r.True(pr.FirstSeen.Equal(start), "first seen: %s", pr.FirstSeen)
+	r.True(pr.LastSeen.Equal(start.Add(2*time.Hour)), "last seen: %s", pr.LastSeen)
+	r.Equal(90*time.Minute, pr.Connected)
+
+	r.Len(pr.Sessions, 2)
+	r.Equal(roomdb.TransportWebsocket, pr.Sessions[0].Transport, "newest first")
+	r.Equal(time.Hour, pr.Sessions[0].Duration())
+	r.Equal(roomdb.TransportTCP, pr.Sessions[1].Transport)
+
+	// strangers are not recorded
+	stranger, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("2"), 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	err = db.Presence.RecordSession(ctx, stranger, roomdb.Session{Start: start, End: start.Add(time.Minute)})
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	// removing the member drops the history
+	r.NoError(db.Members.RemoveID(ctx, id))
+	_, err = db.Presence.GetByMemberID(ctx, id)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	r.NoError(db.Close())
+}
+
+func TestPresenceKeepsRecentSessions(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	tr := repo.New(testRepo)
+
+	db, err := Open(tr)
+	require.NoError(t, err)
+
+	feed, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte("1"), 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	id, err := db.Members.Add(ctx, feed, roomdb.RoleMember)
+	r.NoError(err)
+
+	start := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+	n := maxSessionsPerMember + 5
+	for i := 0; i < n; i++ {
+		begin := start.Add(time.Duration(i) * time.Hour)
+		err = db.Presence.RecordSession(ctx, feed, roomdb.Session{
+			Start:     begin,
+			End:       begin.Add(time.Minute),
+			Transport: roomdb.TransportTCP,
+		})
+		r.NoError(err)
+	}
+
+	pr, err := db.Presence.GetByMemberID(ctx, id)
+	r.NoError(err)
+	r.Len(pr.Sessions, maxSessionsPerMember)
+	r.True(pr.Sessions[0].Start.Equal(start.Add(time.Duration(n-1)*time.Hour)), "newest session first")
+
+	// the totals still count the deleted ones
+	r.True(pr.FirstSeen.Equal(start))
+	r.Equal(time.Duration(n)*time.Minute, pr.Connected)
+
+	r.NoError(db.Close())
+}
@@ -19,15 +19,16 @@ var _ roomdb.RoomConfig = (*Config)(nil)
 // the database will only ever store one row, which contains all the room settings
 const configRowID = 0
 
-/* Config basically enables long-term memory for the server when it comes to storing settings. Currently, the only
-* stored settings is the privacy mode of the room.
+/* Config basically enables long-term memory for the server when it comes to storing settings. Currently, the
+* stored settings are the privacy mode, the default language and the branding of the room.
  */
 type Config struct {
 	db *sql.DB
 }
 
 func (c Config) GetPrivacyMode(ctx context.Context) (roomdb.PrivacyMode, error) {
-	config, err := models.FindConfig(ctx, c.db, configRowID)
+	// only select the needed column, to not load the logo every time
+	config, err := models.FindConfig(ctx, c.db, configRowID, models.ConfigColumns.PrivacyMode)
 	if err != nil {
 		return roomdb.ModeUnknown, err
 	}
@@ -71,7 +72,7 @@ func (c Config) SetPrivacyMode(ctx context.Context, pm roomdb.PrivacyMode) error
 }
 
 func (c Config) GetDefaultLanguage(ctx context.Context) (string, error) {
-	config, err := models.FindConfig(ctx, c.db, configRowID)
+	config, err := models.FindConfig(ctx, c.db, configRowID, models.ConfigColumns.DefaultLanguage)
 	if err != nil {
 		return "", err
 	}
@@ -111,3 +112,122 @@ func (c Config) SetDefaultLanguage(ctx context.Context, langTag string) error {
 
 	return nil // alles gut!!
 }
+
+func (c Config) GetBranding(ctx context.Context) (roomdb.Branding, error) {
+	config, err := models.FindConfig(ctx, c.db, configRowID,
+		models.ConfigColumns.Name,
+		models.ConfigColumns.Tagline,
+		models.ConfigColumns.AccentColor,
+		models.ConfigColumns.LogoType,
+	)
+	if err != nil {
+		return roomdb.Branding{}, err
+	}
+
+	return roomdb.Branding{
+		Name:        config.Name,
+		Tagline:     config.Tagline,
+		AccentColor: config.AccentColor,
+		HasLogo:     config.LogoType != "",
+	}, nil
+}
+
+func (c Config) SetBranding(ctx context.Context, b roomdb.Branding) error {
+	if err := b.Validate(); err != nil {
+		return err
+	}
+
+	config := models.Config{
+		ID:          configRowID,
+		Name:        b.Name,
+		Tagline:     b.Tagline,
+		AccentColor: b.AccentColor,
+	}
+	return c.updateColumns(ctx, "branding", &config,
+		models.ConfigColumns.Name,
+		models.ConfigColumns.Tagline,
+		models.ConfigColumns.AccentColor,
+	)
+}
+
+func (c Config) GetLogo(ctx context.Context) (roomdb.Logo, error) {
+	config, err := models.FindConfig(ctx, c.db, configRowID, models.ConfigColumns.Logo, models.ConfigColumns.LogoType)
+	if err != nil {
+		return roomdb.Logo{}, err
+	}
+
+	if config.LogoType == "" {
+		return roomdb.Logo{}, roomdb.ErrNotFound
+	}
+
+	return roomdb.Logo{
+		ContentType: config.LogoType,
+		Data:        config.Logo,
+	}, nil
+}
+
+func (c Config) SetLogo(ctx context.Context, logo roomdb.Logo) error {
+	if err := logo.Validate(); err != nil {
+		return err
+	}
+
+	config := models.Config{
+		ID:       configRowID,
+		Logo:     logo.Data,
+		LogoType: logo.ContentType,
+	}
+	return c.updateColumns(ctx, "logo", &config, models.ConfigColumns.Logo, models.ConfigColumns.LogoType)
+}
+
+func (c Config) RemoveLogo(ctx context.Context) error {
+	config := models.Config{
+		ID:   configRowID,
+		Logo: []byte{},
+	}
+	return c.updateColumns(ctx, "logo", &config, models.ConfigColumns.Logo, models.ConfigColumns.LogoType)
+}
+
+func (c Config) GetOpenInviteLimits(ctx context.Context) (roomdb.OpenInviteLimits, error) {
+	config, err := models.FindConfig(ctx, c.db, configRowID,
+		models.ConfigColumns.OpenInviteDifficulty,
+		models.ConfigColumns.OpenInviteDailyLimit,
+	)
+	if err != nil {
+		return roomdb.OpenInviteLimits{}, err
+	}
+
+	return roomdb.OpenInviteLimits{
+		Difficulty:      uint(config.OpenInviteDifficulty),
+		DailyPerAddress: uint(config.OpenInviteDailyLimit),
+	}, nil
+}
+
+func (c Config) SetOpenInviteLimits(ctx context.Context, l roomdb.OpenInviteLimits) error {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+
+	config := models.Config{
+		ID:                   configRowID,
+		OpenInviteDifficulty: int64(l.Difficulty),
+		OpenInviteDailyLimit: int64(l.DailyPerAddress),
+	}
+	return c.updateColumns(ctx, "open invite limits", &config,
+		models.ConfigColumns.OpenInviteDifficulty,
+		models.ConfigColumns.OpenInviteDailyLimit,
+	)
+}
+
+// updateColumns writes only the passed columns of the settings row, so that the others don't need to be loaded first
+func (c Config) updateColumns(ctx context.Context, what string, config *models.Config, columns ...string) error {
+	return transact(c.db, func(tx *sql.Tx) error {
+		rowsAffected, err := config.Update(ctx, tx, boil.Whitelist(columns...))
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("setting %s should have update the settings row, instead 0 rows were updated", what)
+		}
+		return nil
+	})
+}
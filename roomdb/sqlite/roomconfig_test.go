@@ -8,6 +8,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
@@ -46,3 +47,106 @@ func TestRoomConfig(t *testing.T) {
 	err = db.Config.SetPrivacyMode(ctx, 1337)
 	r.Error(err)
 }
+
+func TestRoomConfigBranding(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	db, err := Open(repo.New(testRepo))
+	r.NoError(err)
+
+	// empty by default
+	b, err := db.Config.GetBranding(ctx)
+	r.NoError(err)
+	r.Equal(roomdb.Branding{}, b)
+
+	_, err = db.Config.GetLogo(ctx)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	want := roomdb.Branding{
+		Name:        "Hermies Club",
+		Tagline:     "a cozy room for hermit crabs",
+		AccentColor: "#6d28d9",
+	}
+	r.NoError(db.Config.SetBranding(ctx, want))
+
+	b, err = db.Config.GetBranding(ctx)
+	r.NoError(err)
+	r.Equal(want, b)
+
+	// invalid values are not stored
+	r.Error(db.Config.SetBranding(ctx, roomdb.Branding{AccentColor: "red"}))
+	r.Error(db.Config.SetBranding(ctx, roomdb.Branding{Name: strings.Repeat("x", roomdb.MaxBrandingNameLength+1)}))
+
+	// the logo
+	logo := roomdb.Logo{ContentType: "image/png", Data: []byte("\x89PNG not really")}
+	r.NoError(db.Config.SetLogo(ctx, logo))
+
+	got, err := db.Config.GetLogo(ctx)
+	r.NoError(err)
+	r.Equal(logo, got)
+
+	b, err = db.Config.GetBranding(ctx)
+	r.NoError(err)
+	r.True(b.HasLogo)
+	r.Equal(want.Name, b.Name, "the logo doesn't change the other fields")
+
+	r.Error(db.Config.SetLogo(ctx, roomdb.Logo{ContentType: "image/svg+xml", Data: []byte("<svg/>")}))
+	r.Error(db.Config.SetLogo(ctx, roomdb.Logo{ContentType: "image/png", Data: make([]byte, roomdb.MaxLogoSize+1)}))
+
+	// the other settings are kept
+	r.NoError(db.Config.SetPrivacyMode(ctx, roomdb.ModeOpen))
+	got, err = db.Config.GetLogo(ctx)
+	r.NoError(err)
+	r.Equal(logo, got)
+
+	r.NoError(db.Config.RemoveLogo(ctx))
+	_, err = db.Config.GetLogo(ctx)
+	r.ErrorIs(err, roomdb.ErrNotFound)
+
+	b, err = db.Config.GetBranding(ctx)
+	r.NoError(err)
+	r.False(b.HasLogo)
+	r.Equal(want.Tagline, b.Tagline)
+
+	pm, err := db.Config.GetPrivacyMode(ctx)
+	r.NoError(err)
+	r.Equal(roomdb.ModeOpen, pm)
+
+	r.NoError(db.Close())
+}
+
+func TestRoomConfigOpenInviteLimits(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	testRepo := filepath.Join("testrun", t.Name())
+	os.RemoveAll(testRepo)
+
+	db, err := Open(repo.New(testRepo))
+	r.NoError(err)
+
+	// the defaults of the migration
+	l, err := db.Config.GetOpenInviteLimits(ctx)
+	r.NoError(err)
+	r.Equal(roomdb.OpenInviteLimits{Difficulty: 0, DailyPerAddress: 10}, l)
+
+	want := roomdb.OpenInviteLimits{Difficulty: 20, DailyPerAddress: 0}
+	r.NoError(db.Config.SetOpenInviteLimits(ctx, want))
+
+	l, err = db.Config.GetOpenInviteLimits(ctx)
+	r.NoError(err)
+	r.Equal(want, l)
+
+	// nobody could solve this
+	r.Error(db.Config.SetOpenInviteLimits(ctx, roomdb.OpenInviteLimits{Difficulty: roomdb.MaxOpenInviteDifficulty + 1}))
+
+	l, err = db.Config.GetOpenInviteLimits(ctx)
+	r.NoError(err)
+	r.Equal(want, l)
+
+	r.NoError(db.Close())
+}
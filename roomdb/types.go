@@ -8,8 +8,10 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"time"
+	"unicode/utf8"
 
 	refs "github.com/ssbc/go-ssb-refs"
 )
@@ -17,6 +19,12 @@ import (
 // ErrNotFound is returned by the admin db if an object couldn't be found.
 var ErrNotFound = errors.New("roomdb: object not found")
 
+// ErrDeniedKey is returned if a feed on the list of denied keys would be let in
+var ErrDeniedKey = errors.New("roomdb: the feed is on the list of denied keys")
+
+// ErrInviteForOtherFeed is returned if the invite of a join request is used by another feed than the one that asked
+var ErrInviteForOtherFeed = errors.New("roomdb: the invite is for another feed")
+
 // Alias is how the roomdb stores an alias.
 type Alias struct {
 	ID int64
@@ -42,6 +50,9 @@ type Member struct {
 	Role    Role
 	PubKey  refs.FeedRef
 	Aliases []Alias
+
+	// Hidden members are not listed as attendants but can still be reached over tunnel.connect
+	Hidden bool
 }
 
 //go:generate go run golang.org/x/tools/cmd/stringer -type=PrivacyMode
@@ -181,6 +192,50 @@ type Invite struct {
 	CreatedAt time.Time
 }
 
+// JoinRequestStatus is what moderators decided on a request to join the room.
+type JoinRequestStatus uint
+
+// A request starts as pending until it is approved or declined
+const (
+	JoinRequestPending JoinRequestStatus = iota
+	JoinRequestApproved
+	JoinRequestDeclined
+)
+
+func (s JoinRequestStatus) String() string {
+	switch s {
+	case JoinRequestPending:
+		return "pending"
+	case JoinRequestApproved:
+		return "approved"
+	case JoinRequestDeclined:
+		return "declined"
+	default:
+		return "unknown"
+	}
+}
+
+// JoinRequest is a request of someone who isn't a member yet to join the room.
+// The token to check its status is only visible from the db.Create function and stored hashed in the database.
+type JoinRequest struct {
+	ID      int64
+	PubKey  refs.FeedRef
+	Message string
+
+	Status    JoinRequestStatus
+	CreatedAt time.Time
+
+	// DecidedBy is the ID of the moderator who approved or declined it, DecidedAt when they did.
+	// Both are empty while it is pending.
+	DecidedBy int64
+	DecidedAt time.Time
+
+	// WithInvite is set if it was approved with a personal invite instead of adding the member directly.
+	// InviteID is that invite, once the requester got it. Like the status token, the token of the invite is only handed out once.
+	WithInvite bool
+	InviteID   int64
+}
+
 // ListEntry values are returned by the DenyListServices
 type ListEntry struct {
 	ID     int64
@@ -190,6 +245,45 @@ type ListEntry struct {
 	Comment   string
 }
 
+// Peer is a multiserver address the room keeps an outgoing connection to.
+type Peer struct {
+	ID      int64
+	Address string // like net:host:port~shs:key
+
+	CreatedAt time.Time
+	Comment   string
+}
+
+// The transports a member can use to connect to the room
+const (
+	TransportTCP       = "tcp"
+	TransportWebsocket = "websocket"
+)
+
+// Session is a single connection of a member to the room
+type Session struct {
+	Start, End time.Time
+
+	Transport string // TransportTCP or TransportWebsocket
+}
+
+// Duration returns how long the connection was open
+func (s Session) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Presence sums up when a member was connected to the room
+type Presence struct {
+	FirstSeen time.Time
+	LastSeen  time.Time
+
+	// Connected is the total time of all the sessions, including the ones that are not in Sessions anymore
+	Connected time.Duration
+
+	// Sessions holds the recent sessions, the newest first
+	Sessions []Session
+}
+
 // DBFeedRef wraps a feed reference and implements the SQL marshaling interfaces.
 type DBFeedRef struct{ refs.FeedRef }
 
@@ -215,6 +309,88 @@ func (r DBFeedRef) Value() (driver.Value, error) {
 	return driver.Value(r.String()), nil
 }
 
+// Branding is how the room presents itself, on the web pages and in room.metadata.
+// Empty fields fall back to the defaults, like the domain instead of the name.
+type Branding struct {
+	Name    string
+	Tagline string
+
+	// AccentColor is a CSS hex color like #6d28d9
+	AccentColor string
+
+	// HasLogo is true if a logo was uploaded, see RoomConfig.GetLogo
+	HasLogo bool
+}
+
+// Limits of the branding fields
+const (
+	MaxBrandingNameLength    = 64
+	MaxBrandingTaglineLength = 160
+
+	MaxLogoSize = 256 * 1024
+)
+
+var accentColorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Validate checks the lengths of the texts and the format of the accent color
+func (b Branding) Validate() error {
+	if utf8.RuneCountInString(b.Name) > MaxBrandingNameLength {
+		return fmt.Errorf("room name is longer than %d characters", MaxBrandingNameLength)
+	}
+	if utf8.RuneCountInString(b.Tagline) > MaxBrandingTaglineLength {
+		return fmt.Errorf("tagline is longer than %d characters", MaxBrandingTaglineLength)
+	}
+	if b.AccentColor != "" && !accentColorRegexp.MatchString(b.AccentColor) {
+		return fmt.Errorf("accent color %q is not a hex color like #6d28d9", b.AccentColor)
+	}
+	return nil
+}
+
+// OpenInviteLimits keep bots from creating all the invites of an open room.
+type OpenInviteLimits struct {
+	// Difficulty is how many leading zero bits the proof-of-work needs. 0 turns it off.
+	Difficulty uint
+
+	// DailyPerAddress is how many invites one IP address can create each day. 0 means no limit.
+	DailyPerAddress uint
+}
+
+// MaxOpenInviteDifficulty keeps the proof-of-work solvable in a browser within a few minutes
+const MaxOpenInviteDifficulty = 24
+
+// Validate checks that the difficulty can still be solved
+func (l OpenInviteLimits) Validate() error {
+	if l.Difficulty > MaxOpenInviteDifficulty {
+		return fmt.Errorf("difficulty %d is higher than %d", l.Difficulty, MaxOpenInviteDifficulty)
+	}
+	return nil
+}
+
+// Logo is the image of the room
+type Logo struct {
+	ContentType string
+	Data        []byte
+}
+
+// LogoContentTypes are the allowed image types. SVG is left out since it can contain scripts.
+var LogoContentTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+// Validate checks the size and the type of the logo
+func (l Logo) Validate() error {
+	if len(l.Data) == 0 {
+		return fmt.Errorf("logo is empty")
+	}
+	if len(l.Data) > MaxLogoSize {
+		return fmt.Errorf("logo is bigger than %d KiB", MaxLogoSize/1024)
+	}
+	for _, ct := range LogoContentTypes {
+		if l.ContentType == ct {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported logo type %q", l.ContentType)
+}
+
 // PinnedNoticeName holds a name of a well known part of the page with a fixed location.
 // These also double as the i18n labels.
 type PinnedNoticeName string
@@ -241,12 +417,132 @@ func (n PinnedNoticeName) Valid() bool {
 
 type PinnedNotices map[PinnedNoticeName][]Notice
 
+// Page is a named page that admins added next to the pinned notices, like a FAQ or an onboarding guide.
+// It is served under /page/{slug} and has one notice per language as its translations.
+type Page struct {
+	ID   int64
+	Name string
+	Slug string
+
+	// MenuPosition orders the pages in the menu, the lowest first
+	MenuPosition int64
+
+	Translations []Notice
+}
+
+// Translation returns the notice for the language or, if there is none, the first published one
+func (p Page) Translation(language string) (Notice, bool) {
+	for _, n := range p.Translations {
+		if n.Language == language && n.Published {
+			return n, true
+		}
+	}
+	for _, n := range p.Translations {
+		if n.Published {
+			return n, true
+		}
+	}
+	return Notice{}, false
+}
+
+// Limits of the page fields
+const (
+	MaxPageNameLength = 64
+	MaxPageSlugLength = 64
+)
+
+var pageSlugRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Validate checks the name and that the slug can be used in URLs, like faq or moderation-policy
+func (p Page) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("page name can't be empty")
+	}
+	if utf8.RuneCountInString(p.Name) > MaxPageNameLength {
+		return fmt.Errorf("page name is longer than %d characters", MaxPageNameLength)
+	}
+	if PinnedNoticeName(p.Name).Valid() {
+		return fmt.Errorf("page name %q is reserved for a pinned notice", p.Name)
+	}
+	if len(p.Slug) > MaxPageSlugLength {
+		return fmt.Errorf("page slug is longer than %d characters", MaxPageSlugLength)
+	}
+	if !pageSlugRegexp.MatchString(p.Slug) {
+		return fmt.Errorf("page slug %q can only have lower case letters, digits and dashes between them", p.Slug)
+	}
+	return nil
+}
+
+// ErrPageSlugTaken is returned if another page already uses the slug or the name
+type ErrPageSlugTaken struct {
+	Slug string
+}
+
+func (e ErrPageSlugTaken) Error() string {
+	return fmt.Sprintf("roomdb: the page name or slug (%q) is already taken", e.Slug)
+}
+
 // Notice holds the title and content of a page that is user generated
 type Notice struct {
 	ID       int64
 	Title    string
 	Content  string
 	Language string
+
+	// Published is false for notices that were only saved as drafts so far. They are not shown to the public.
+	Published bool
+}
+
+// NoticeRevision is one saved version of a notice
+type NoticeRevision struct {
+	ID       int64
+	NoticeID int64
+
+	Title   string
+	Content string
+
+	// Author is the ID of the member that saved it, zero if it is not known
+	Author int64
+
+	// Draft revisions are not shown to the public
+	Draft bool
+
+	CreatedAt time.Time
+}
+
+// NewsPost is one dated announcement of the room.
+// Each post is a notice in a single language, so a translated announcement is a post per language.
+type NewsPost struct {
+	ID     int64
+	Notice Notice
+
+	PublishedAt time.Time
+}
+
+// CodeOfConductVersion is a version of the code of conduct, together with all the translations of the NoticeCodeOfConduct pinned notice.
+// A new version asks all the members to accept the code of conduct again.
+type CodeOfConductVersion struct {
+	// Version counts up from one. Zero means that the room doesn't ask to accept a code of conduct.
+	Version int64
+
+	CreatedAt time.Time
+}
+
+// CodeOfConductAcceptance records that a member accepted a version of the code of conduct
+type CodeOfConductAcceptance struct {
+	MemberID int64
+	Version  int64
+
+	AcceptedAt time.Time
+}
+
+// ErrCodeOfConductNotAccepted is returned if the current version of the code of conduct wasn't accepted
+type ErrCodeOfConductNotAccepted struct {
+	Current int64
+}
+
+func (e ErrCodeOfConductNotAccepted) Error() string {
+	return fmt.Sprintf("roomdb: version %d of the code of conduct has to be accepted", e.Current)
 }
 
 type PinnedNotice struct {
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package roomsrv
+
+import (
+	"bytes"
+	"fmt"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+)
+
+type federationPeer struct {
+	feed   refs.FeedRef
+	msaddr string
+}
+
+// WithFederationPeers configures trusted sibling rooms, as multiserver addresses (net:host:port~shs:key).
+// Sibling rooms share their attendant lists and relay tunnel.connect calls to the peers connected to them.
+// Both rooms need to list each other.
+func WithFederationPeers(msaddrs ...string) Option {
+	return func(s *Server) error {
+		for _, msaddr := range msaddrs {
+			feed, _, err := network.ParseMultiserverAddress(msaddr)
+			if err != nil {
+				return fmt.Errorf("invalid federation peer: %w", err)
+			}
+			s.federationPeers = append(s.federationPeers, federationPeer{feed: feed, msaddr: msaddr})
+		}
+		return nil
+	}
+}
+
+func (s *Server) isFederationPeer(ref refs.FeedRef) bool {
+	for _, p := range s.federationPeers {
+		if p.feed.Equal(ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// keeps the connections to the sibling rooms open.
+// To not end up with two connections, only the room with the smaller public key dials.
+func (s *Server) initFederation() error {
+	for _, p := range s.federationPeers {
+		if p.feed.Equal(s.keyPair.Feed) {
+			continue
+		}
+		if bytes.Compare(s.keyPair.Feed.PubKey(), p.feed.PubKey()) > 0 {
+			continue
+		}
+		if err := s.Peers.AddStatic(p.msaddr, "sibling room"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -26,8 +26,14 @@ func (s *Server) initHandlers() {
 		s.netInfo,
 		s.StateManager,
 		s.Members,
+		s.DeniedKeys,
+		s.Aliases,
 		s.Config,
+		s.newsDB,
 	)
+	for _, p := range s.federationPeers {
+		tunnelHandler.TrustFederationPeers(p.feed)
+	}
 
 	aliasHandler := alias.New(
 		kitlog.With(s.logger, "unit", "aliases"),
@@ -58,6 +64,9 @@ func (s *Server) initHandlers() {
 		// register new room v2 commands
 		tunnelHandler.RegisterRoom(mux)
 
+		// register room-to-room commands, only usable by trusted sibling rooms
+		tunnelHandler.RegisterFederation(mux)
+
 		var method = muxrpc.Method{"room"}
 		mux.RegisterAsync(append(method, "registerAlias"), typemux.AsyncFunc(aliasHandler.Register))
 		mux.RegisterAsync(append(method, "revokeAlias"), typemux.AsyncFunc(aliasHandler.Revoke))
@@ -5,10 +5,15 @@
 package roomsrv
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/ssbc/go-muxrpc/v2"
+	kitlog "go.mindeco.de/log"
+	"go.mindeco.de/log/level"
 
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
@@ -30,6 +35,17 @@ func (s *Server) initNetwork() error {
 			return &s.master, nil
 		}
 
+		// if feed is in the deny list, deny their connection
+		if s.DeniedKeys.HasFeed(s.rootCtx, remote) {
+			return nil, fmt.Errorf("this key has been banned")
+		}
+
+		// trusted sibling rooms are let in regardless of the privacy mode.
+		// being one of the peers the room dials does not grant inbound access.
+		if s.isFederationPeer(remote) {
+			return &s.public, nil
+		}
+
 		pm, err := s.Config.GetPrivacyMode(s.rootCtx)
 		if err != nil {
 			return nil, fmt.Errorf("running with unknown privacy mode")
@@ -42,27 +58,38 @@ func (s *Server) initNetwork() error {
 			}
 		}
 
-		// if feed is in the deny list, deny their connection
-		if s.DeniedKeys.HasFeed(s.rootCtx, remote) {
-			return nil, fmt.Errorf("this key has been banned")
-		}
-
 		// for community + open modes, allow all connections
 		return &s.public, nil
 	}
 
+	s.ConnLimiter = network.NewConnLimiter(kitlog.With(s.logger, "unit", "connlimits"), s.connLimits)
+
+	wsOpts := s.websocketOpts
+	if len(wsOpts.AllowedOrigins) == 0 && s.netInfo.Domain != "" {
+		wsOpts.AllowedOrigins = []string{s.netInfo.Domain, "*." + s.netInfo.Domain}
+	}
+
 	// tcp+shs
 	opts := network.Options{
 		Logger:              s.logger,
 		Dialer:              s.dialer,
 		ListenAddr:          s.listenAddr,
+		AdditionalListeners: s.additionalListeners,
+		OnionListenAddr:     s.onionAddr,
 		KeyPair:             s.keyPair,
 		AppKey:              s.appKey[:],
 		MakeHandler:         mkHandler,
 		ConnTracker:         s.networkConnTracker,
+		ConnLimiter:         s.ConnLimiter,
+		ProxyProtocol:       s.proxyProtocol,
+		TrustedProxies:      s.trustedProxies,
+		Websocket:           wsOpts,
 		BefreCryptoWrappers: s.preSecureWrappers,
 		AfterSecureWrappers: s.postSecureWrappers,
 	}
+	if s.presenceDB != nil {
+		opts.OnSessionEnd = s.recordSession
+	}
 
 	var err error
 	s.Network, err = network.New(opts)
@@ -72,3 +99,24 @@ func (s *Server) initNetwork() error {
 
 	return nil
 }
+
+// recordSession adds a closed connection to the presence history. Connections of non-members are ignored.
+func (s *Server) recordSession(evt network.SessionEnd) {
+	sess := roomdb.Session{
+		Start:     evt.Started,
+		End:       evt.Ended,
+		Transport: roomdb.TransportTCP,
+	}
+	if evt.Websocket {
+		sess.Transport = roomdb.TransportWebsocket
+	}
+
+	// the root context might already be canceled when the connections are closed on shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := s.presenceDB.RecordSession(ctx, evt.Remote, sess)
+	if err != nil && !errors.Is(err, roomdb.ErrNotFound) {
+		level.Warn(s.logger).Log("event", "failed to record session", "peer", evt.Remote.ShortSigil(), "err", err)
+	}
+}
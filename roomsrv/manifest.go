@@ -46,8 +46,10 @@ const manifest manifestHandler = `
 		"connect": "duplex",
 		"attendants": "source",
 		"members": "source",
+		"notices": "source",
 		"metadata": "async",
-		"ping": "sync"
+		"ping": "sync",
+		"setHidden": "async"
 	},
 
 	"tunnel": {
@@ -57,5 +59,10 @@ const manifest manifestHandler = `
 		"endpoints": "source",
 		"isRoom": "async",
 		"ping": "sync"
+	},
+
+	"federation": {
+		"attendants": "source",
+		"connect": "duplex"
 	}
 }`
@@ -8,11 +8,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ssbc/go-netwrap"
 	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/keys"
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
 	kitlog "go.mindeco.de/log"
 )
 
@@ -109,6 +111,92 @@ func WithNetworkConnTracker(ct network.ConnTracker) Option {
 	}
 }
 
+// WithConnLimits caps the incoming connections, in total and per IP address. Zero values disable a limit.
+// The rejected connections are counted by Server.ConnLimiter.
+func WithConnLimits(limits network.ConnLimits) Option {
+	return func(s *Server) error {
+		if limits.MaxConns < 0 || limits.MaxConnsPerIP < 0 || limits.AcceptsPerMinutePerIP < 0 {
+			return fmt.Errorf("connection limits: negative limit")
+		}
+		s.connLimits = limits
+		return nil
+	}
+}
+
+// WithProxyProtocol makes the muxrpc listeners read the PROXY protocol header on connections from the trusted proxies and unix sockets.
+// The client address from the header is used for the connection limits, logging and the connection tracking.
+func WithProxyProtocol(trusted network.TrustedProxies) Option {
+	return func(s *Server) error {
+		s.proxyProtocol = true
+		s.trustedProxies = trusted
+		return nil
+	}
+}
+
+// WithWebsocketOptions changes the origin policy, message size limit and timeouts of the websocket transport.
+// Without AllowedOrigins, browsers can connect from pages on the domain of the room and its alias subdomains.
+func WithWebsocketOptions(opts network.WebsocketOptions) Option {
+	return func(s *Server) error {
+		if opts.ReadBufferSize < 0 || opts.WriteBufferSize < 0 {
+			return fmt.Errorf("websocket: negative buffer size")
+		}
+		s.websocketOpts = opts
+		return nil
+	}
+}
+
+// WithAdditionalListener opens another listener next to the main one, with it's own connection wrappers.
+// Listeners from ServerEndpointDetails.AdditionalListenAddressesMUXRPC are added after these.
+func WithAdditionalListener(lis network.ListenerOptions) Option {
+	return func(s *Server) error {
+		if lis.Addr == nil {
+			return fmt.Errorf("additional listener without an address")
+		}
+		s.additionalListeners = append(s.additionalListeners, lis)
+		return nil
+	}
+}
+
+// WithOutboundPeers sets the list of peers the room keeps an outgoing connection to.
+// Changes to the list are picked up by calling Server.Peers.Reload().
+func WithOutboundPeers(db roomdb.PeersService) Option {
+	return func(s *Server) error {
+		s.peersDB = db
+		return nil
+	}
+}
+
+// WithPresence records every closed connection of a member, for the presence history on the dashboard.
+func WithPresence(db roomdb.PresenceService) Option {
+	return func(s *Server) error {
+		s.presenceDB = db
+		return nil
+	}
+}
+
+// WithNews enables room.notices, which streams the news posts of the room to the clients.
+func WithNews(db roomdb.NewsService) Option {
+	return func(s *Server) error {
+		s.newsDB = db
+		return nil
+	}
+}
+
+// WithKeepAlive changes how often the attendants are pinged and after how many missed pings they are dropped.
+// An interval of zero disables the pings.
+func WithKeepAlive(interval time.Duration, maxMissed int) Option {
+	return func(s *Server) error {
+		if interval < 0 || maxMissed < 0 {
+			return fmt.Errorf("keepalive: negative interval or missed count")
+		}
+		s.keepAlive.Interval = interval
+		if maxMissed > 0 {
+			s.keepAlive.MaxMissed = maxMissed
+		}
+		return nil
+	}
+}
+
 // WithPreSecureConnWrapper wrapps the connection after it is encrypted.
 // Usefull for debugging and measuring traffic.
 func WithPreSecureConnWrapper(cw netwrap.ConnWrapper) Option {
@@ -25,6 +25,7 @@ import (
 	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/keys"
 	"github.com/ssbc/go-ssb-room/v2/internal/maybemod/multicloser"
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/internal/outbound"
 	"github.com/ssbc/go-ssb-room/v2/internal/repo"
 	"github.com/ssbc/go-ssb-room/v2/internal/signinwithssb"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
@@ -45,9 +46,24 @@ type Server struct {
 	Network    network.Network
 	appKey     []byte
 	listenAddr net.Addr
+	onionAddr  net.Addr
 	wsAddr     string
 	dialer     netwrap.Dialer
 
+	additionalListeners []network.ListenerOptions
+
+	federationPeers []federationPeer
+
+	// Peers keeps the outgoing connections to other peers open
+	Peers   *outbound.Manager
+	peersDB roomdb.PeersService
+
+	// presenceDB records the connections of the members, if set
+	presenceDB roomdb.PresenceService
+
+	// newsDB is used by room.notices, if set
+	newsDB roomdb.NewsService
+
 	netInfo network.ServerEndpointDetails
 
 	loadUnixSock bool
@@ -57,13 +73,21 @@ type Server struct {
 	keyPair  *keys.KeyPair
 
 	networkConnTracker network.ConnTracker
+	connLimits         network.ConnLimits
+	trustedProxies     network.TrustedProxies
+	proxyProtocol      bool
+	websocketOpts      network.WebsocketOptions
 	preSecureWrappers  []netwrap.ConnWrapper
 	postSecureWrappers []netwrap.ConnWrapper
 
+	// ConnLimiter counts the incoming connections and the ones that were rejected
+	ConnLimiter *network.ConnLimiter
+
 	public typemux.HandlerMux
 	master typemux.HandlerMux
 
 	StateManager *roomstate.Manager
+	keepAlive    roomstate.KeepAliveOptions
 
 	Members    roomdb.MembersService
 	DeniedKeys roomdb.DeniedKeysService
@@ -101,6 +125,11 @@ func New(
 
 	s.netInfo = netInfo
 
+	s.keepAlive = roomstate.KeepAliveOptions{
+		Interval:  roomstate.DefaultKeepAliveInterval,
+		MaxMissed: roomstate.DefaultKeepAliveMaxMissed,
+	}
+
 	for i, opt := range opts {
 		err := opt(&s)
 		if err != nil {
@@ -135,6 +164,21 @@ func New(
 		return nil, err
 	}
 
+	for _, listenAddr := range s.netInfo.AdditionalListenAddressesMUXRPC {
+		addr, err := network.ParseListenAddr(listenAddr)
+		if err != nil {
+			return nil, fmt.Errorf("roomsrv: invalid additional listener: %w", err)
+		}
+		s.additionalListeners = append(s.additionalListeners, network.ListenerOptions{Addr: addr})
+	}
+
+	if s.netInfo.ListenAddressOnion != "" {
+		s.onionAddr, err = network.ParseListenAddr(s.netInfo.ListenAddressOnion)
+		if err != nil {
+			return nil, fmt.Errorf("roomsrv: invalid onion listener: %w", err)
+		}
+	}
+
 	if s.logger == nil {
 		logger := kitlog.NewLogfmtLogger(kitlog.NewSyncWriter(os.Stdout))
 		logger = kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC, "caller", kitlog.DefaultCaller)
@@ -161,6 +205,11 @@ func New(
 	s.netInfo.RoomID = s.keyPair.Feed
 
 	s.StateManager = roomstate.NewManager(s.logger)
+	go s.StateManager.KeepAlive(s.rootCtx, s.keepAlive)
+
+	if err := s.loadHiddenMembers(); err != nil {
+		return nil, err
+	}
 
 	s.initHandlers()
 
@@ -168,15 +217,39 @@ func New(
 		return nil, err
 	}
 
+	s.Peers = outbound.NewManager(s.rootCtx, s.logger, s.Network, s.peersDB)
+
 	if s.loadUnixSock {
 		if err := s.initUnixSock(); err != nil {
 			return nil, err
 		}
 	}
 
+	if err := s.initFederation(); err != nil {
+		return nil, err
+	}
+
+	if err := s.Peers.Reload(s.rootCtx); err != nil {
+		return nil, err
+	}
+
 	return &s, nil
 }
 
+// loadHiddenMembers tells the room state which members don't want to be listed
+func (s *Server) loadHiddenMembers() error {
+	lst, err := s.Members.List(s.rootCtx)
+	if err != nil {
+		return fmt.Errorf("roomsrv: failed to list members: %w", err)
+	}
+	for _, m := range lst {
+		if m.Hidden {
+			s.StateManager.SetHidden(m.PubKey, true)
+		}
+	}
+	return nil
+}
+
 // Close closes the bot by stopping network connections and closing the internal databases
 func (s *Server) Close() error {
 	s.closedMu.Lock()
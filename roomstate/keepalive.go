@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package roomstate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ssbc/go-muxrpc/v2"
+	"go.mindeco.de/encodedTime"
+	"go.mindeco.de/log/level"
+
+	refs "github.com/ssbc/go-ssb-refs"
+)
+
+const (
+	// DefaultKeepAliveInterval is how often the attendants are pinged
+	DefaultKeepAliveInterval = time.Minute
+
+	// DefaultKeepAliveMaxMissed is how many pings in a row can go unanswered before a connection is dropped
+	DefaultKeepAliveMaxMissed = 3
+)
+
+// KeepAliveOptions configure Manager.KeepAlive
+type KeepAliveOptions struct {
+	// Interval between two pings of the same connection. It is also how long the room waits for an answer.
+	Interval time.Duration
+
+	// MaxMissed is the number of unanswered pings after which the connection is closed and removed from the room
+	MaxMissed int
+}
+
+// AttendantInfo describes a peer in the room
+type AttendantInfo struct {
+	ID refs.FeedRef
+
+	Connections int
+
+	// LastSeen is the last time one of the connections was used or answered a ping
+	LastSeen time.Time
+
+	// RTT is the round-trip time of the last answered ping, zero if there wasn't one yet
+	RTT time.Duration
+
+	// Hidden attendants are not listed by room.attendants and tunnel.endpoints
+	Hidden bool
+}
+
+// Attendants returns the local attendants with their connection details, sorted like List.
+// Unlike List, it includes the hidden attendants.
+func (m *Manager) Attendants() []AttendantInfo {
+	m.roomMu.Lock()
+	defer m.roomMu.Unlock()
+
+	lst := make([]AttendantInfo, 0, len(m.room))
+	for _, a := range m.room {
+		_, hidden := m.hidden[a.who.String()]
+		info := AttendantInfo{
+			ID:          a.who,
+			Connections: len(a.conns),
+			Hidden:      hidden,
+		}
+		for _, c := range a.conns {
+			if c.lastSeen.After(info.LastSeen) {
+				info.LastSeen = c.lastSeen
+				info.RTT = c.rtt
+			}
+		}
+		lst = append(lst, info)
+	}
+	sort.Slice(lst, func(i, j int) bool { return lst[i].ID.String() < lst[j].ID.String() })
+	return lst
+}
+
+// KeepAlive pings all the connections of the attendants every interval until ctx is canceled.
+// Connections that miss opts.MaxMissed pings in a row are closed and removed from the room, which emits left for their feed if it was the last one.
+func (m *Manager) KeepAlive(ctx context.Context, opts KeepAliveOptions) {
+	if opts.Interval <= 0 {
+		return
+	}
+	if opts.MaxMissed < 1 {
+		opts.MaxMissed = DefaultKeepAliveMaxMissed
+	}
+
+	tick := time.NewTicker(opts.Interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+
+		m.pingAll(ctx, opts)
+	}
+}
+
+type pingTarget struct {
+	who        refs.FeedRef
+	edp        muxrpc.Endpoint
+	gossipPing bool
+}
+
+func (m *Manager) pingAll(ctx context.Context, opts KeepAliveOptions) {
+	m.roomMu.Lock()
+	var targets []pingTarget
+	for _, a := range m.room {
+		for _, c := range a.conns {
+			if c.edp == nil {
+				continue
+			}
+			targets = append(targets, pingTarget{who: a.who, edp: c.edp, gossipPing: c.gossipPing})
+		}
+	}
+	m.roomMu.Unlock()
+
+	var wg sync.WaitGroup
+	wg.Add(len(targets))
+	for _, t := range targets {
+		go func(t pingTarget) {
+			defer wg.Done()
+			m.pingOne(ctx, t, opts)
+		}(t)
+	}
+	wg.Wait()
+}
+
+func (m *Manager) pingOne(ctx context.Context, t pingTarget, opts KeepAliveOptions) {
+	ctx, cancel := context.WithTimeout(ctx, opts.Interval)
+	defer cancel()
+
+	start := time.Now()
+
+	var err error
+	gossipPing := t.gossipPing
+	if !gossipPing {
+		err = tunnelPing(ctx, t.edp)
+		if err != nil && ctx.Err() == nil {
+			// not a timeout, maybe the peer doesn't know tunnel.ping
+			gossipPing = true
+		}
+	}
+	if gossipPing {
+		err = sendGossipPing(ctx, t.edp, opts.Interval)
+	}
+	rtt := time.Since(start)
+
+	// any answer proves that the peer is still there, even an error like "method not found".
+	// only timeouts and streams that closed without an answer count as missed.
+	var callErr *muxrpc.CallError
+	answered := err == nil || errors.As(err, &callErr)
+
+	m.roomMu.Lock()
+	var missed int
+	if a, has := m.room[t.who.String()]; has {
+		if i, has := a.find(t.edp); has {
+			c := a.conns[i]
+			if answered {
+				c.lastSeen = time.Now()
+				c.rtt = rtt
+				c.missed = 0
+				c.gossipPing = gossipPing
+			} else {
+				c.missed++
+				missed = c.missed
+			}
+		}
+	}
+	m.roomMu.Unlock()
+
+	if answered || missed < opts.MaxMissed {
+		return
+	}
+
+	level.Info(m.logger).Log("event", "evicting stale connection", "peer", t.who.ShortSigil(), "missed", missed, "err", err)
+	m.RemoveEndpoint(t.who, t.edp)
+	if err := t.edp.Terminate(); err != nil {
+		level.Debug(m.logger).Log("event", "failed to terminate stale connection", "peer", t.who.ShortSigil(), "err", err)
+	}
+}
+
+// tunnelPing uses the ping of the room client plugins, which returns the current time
+func tunnelPing(ctx context.Context, edp muxrpc.Endpoint) error {
+	var ret interface{}
+	return edp.Async(ctx, &ret, muxrpc.TypeJSON, muxrpc.Method{"tunnel", "ping"})
+}
+
+// sendGossipPing does one round of gossip.ping, see muxrpc/handlers/gossip for the other side
+func sendGossipPing(ctx context.Context, edp muxrpc.Endpoint, interval time.Duration) error {
+	type arg struct {
+		Timeout int64 `json:"timeout"`
+	}
+
+	src, snk, err := edp.Duplex(ctx, muxrpc.TypeJSON, muxrpc.Method{"gossip", "ping"}, arg{Timeout: interval.Milliseconds()})
+	if err != nil {
+		return err
+	}
+	defer snk.Close()
+
+	snk.SetEncoding(muxrpc.TypeJSON)
+	if err := json.NewEncoder(snk).Encode(encodedTime.Millisecs(time.Now())); err != nil {
+		return err
+	}
+
+	if !src.Next(ctx) {
+		if err := src.Err(); err != nil {
+			return err
+		}
+		return errors.New("gossip.ping: stream closed without an answer")
+	}
+	return nil
+}
@@ -8,25 +8,47 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/ssbc/go-muxrpc/v2"
 	kitlog "go.mindeco.de/log"
 
 	refs "github.com/ssbc/go-ssb-refs"
 	"github.com/ssbc/go-ssb-room/v2/internal/broadcasts"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
 )
 
+// EndpointsDebounce is how long changes to the room are collected before the tunnel.endpoints subscribers are updated.
+const EndpointsDebounce = 100 * time.Millisecond
+
 type Manager struct {
 	logger kitlog.Logger
 
 	endpointsUpdater     broadcasts.EndpointsEmitter
 	endpointsbroadcaster *broadcasts.EndpointsBroadcast
 
+	// batches the changes for the endpoints broadcast
+	endpointsMu       sync.Mutex
+	endpointsTimer    *time.Timer
+	endpointsDebounce time.Duration
+
 	attendantsUpdater     broadcasts.AttendantsEmitter
 	attendantsbroadcaster *broadcasts.AttendantsBroadcast
 
+	// only peers connected to this room, for federated sibling rooms
+	localAttendantsUpdater     broadcasts.AttendantsEmitter
+	localAttendantsbroadcaster *broadcasts.AttendantsBroadcast
+
+	// newly published news posts, for room.notices
+	noticesUpdater     broadcasts.NoticesEmitter
+	noticesbroadcaster *broadcasts.NoticesBroadcast
+
 	roomMu *sync.Mutex
 	room   roomStateMap
+	remote remoteStateMap
+
+	// feeds that don't want to be listed, whether they are in the room or not
+	hidden map[string]struct{}
 }
 
 func NewManager(log kitlog.Logger) *Manager {
@@ -34,23 +56,150 @@ func NewManager(log kitlog.Logger) *Manager {
 	m.logger = log
 	m.endpointsUpdater, m.endpointsbroadcaster = broadcasts.NewEndpointsEmitter()
 	m.attendantsUpdater, m.attendantsbroadcaster = broadcasts.NewAttendantsEmitter()
+	m.localAttendantsUpdater, m.localAttendantsbroadcaster = broadcasts.NewAttendantsEmitter()
+	m.noticesUpdater, m.noticesbroadcaster = broadcasts.NewNoticesEmitter()
+	m.endpointsDebounce = EndpointsDebounce
 	m.roomMu = new(sync.Mutex)
 	m.room = make(roomStateMap)
+	m.remote = make(remoteStateMap)
+	m.hidden = make(map[string]struct{})
 
 	return &m
 }
 
 // roomStateMap is a single room
-type roomStateMap map[string]muxrpc.Endpoint
+type roomStateMap map[string]*attendant
 
-// copy map entries to list for broadcast update
-func (rsm roomStateMap) AsList() []string {
-	memberList := make([]string, 0, len(rsm))
-	for m := range rsm {
-		memberList = append(memberList, m)
+// attendant holds all the open connections of one feed, for instance from different devices
+type attendant struct {
+	who    refs.FeedRef
+	joined time.Time
+	conns  []*attendantConn
+}
+
+type attendantConn struct {
+	edp        muxrpc.Endpoint
+	lastActive time.Time
+	failed     bool // the last call to it failed
+
+	// updated by the keepalive
+	lastSeen   time.Time
+	rtt        time.Duration
+	missed     int  // pings in a row that didn't get an answer
+	gossipPing bool // the peer doesn't know tunnel.ping
+}
+
+func (a *attendant) find(edp muxrpc.Endpoint) (int, bool) {
+	for i, c := range a.conns {
+		if c.edp == edp {
+			return i, true
+		}
 	}
-	sort.Strings(memberList)
-	return memberList
+	return -1, false
+}
+
+// add returns false if the endpoint was already known, in which case it is only marked as active
+func (a *attendant) add(edp muxrpc.Endpoint) bool {
+	now := time.Now()
+	if i, has := a.find(edp); has {
+		a.conns[i].lastActive = now
+		a.conns[i].lastSeen = now
+		a.conns[i].failed = false
+		return false
+	}
+	a.conns = append(a.conns, &attendantConn{edp: edp, lastActive: now, lastSeen: now})
+	return true
+}
+
+func (a *attendant) remove(edp muxrpc.Endpoint) {
+	i, has := a.find(edp)
+	if !has {
+		return
+	}
+	a.conns = append(a.conns[:i], a.conns[i+1:]...)
+}
+
+// best returns the most recently active endpoint, preferring the ones that didn't fail
+func (a *attendant) best() muxrpc.Endpoint {
+	var best *attendantConn
+	for _, c := range a.conns {
+		switch {
+		case best == nil:
+			best = c
+		case best.failed != c.failed:
+			if best.failed {
+				best = c
+			}
+		case c.lastActive.After(best.lastActive):
+			best = c
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.edp
+}
+
+// copy map entries to list for broadcast update, leaving out the hidden attendants
+func (m *Manager) localAsList() []string {
+	lst := make([]string, 0, len(m.room))
+	for who := range m.room {
+		if _, hidden := m.hidden[who]; !hidden {
+			lst = append(lst, who)
+		}
+	}
+	sort.Strings(lst)
+	return lst
+}
+
+// remoteAttendant is a peer in a federated sibling room
+type remoteAttendant struct {
+	via    refs.FeedRef    // the sibling room
+	viaEdp muxrpc.Endpoint // our connection to the sibling room
+	joined time.Time       // when we learned about them
+}
+
+// remoteStateMap holds the attendants of all the federated sibling rooms
+type remoteStateMap map[string]remoteAttendant
+
+// allAsList returns the local and remote attendants, sorted and without duplicates or hidden ones
+func (m *Manager) allAsList() []string {
+	all := make([]string, 0, len(m.room)+len(m.remote))
+	for who := range m.room {
+		if _, hidden := m.hidden[who]; !hidden {
+			all = append(all, who)
+		}
+	}
+	for who := range m.remote {
+		_, local := m.room[who]
+		_, hidden := m.hidden[who]
+		if !local && !hidden {
+			all = append(all, who)
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// endpointsChanged schedules an update for the tunnel.endpoints subscribers.
+// All the changes that happen until it is sent are combined into one update.
+func (m *Manager) endpointsChanged() {
+	m.endpointsMu.Lock()
+	defer m.endpointsMu.Unlock()
+
+	if m.endpointsTimer != nil {
+		return // already scheduled
+	}
+	m.endpointsTimer = time.AfterFunc(m.endpointsDebounce, m.flushEndpoints)
+}
+
+func (m *Manager) flushEndpoints() {
+	// held during the update so that the lists can't overtake each other
+	m.endpointsMu.Lock()
+	defer m.endpointsMu.Unlock()
+
+	m.endpointsTimer = nil
+	m.endpointsUpdater.Update(m.ListAll())
 }
 
 func (m *Manager) RegisterLegacyEndpoints(sink broadcasts.EndpointsEmitter) {
@@ -61,18 +210,67 @@ func (m *Manager) RegisterAttendantsUpdates(sink broadcasts.AttendantsEmitter) {
 	m.attendantsbroadcaster.Register(sink)
 }
 
-// List just returns a list of feed references as strings
+// RegisterLocalAttendantsUpdates only receives updates for peers connected to this room, not the ones of sibling rooms.
+func (m *Manager) RegisterLocalAttendantsUpdates(sink broadcasts.AttendantsEmitter) {
+	m.localAttendantsbroadcaster.Register(sink)
+}
+
+// RegisterNoticesUpdates receives the news posts that are published from now on.
+// The returned function unregisters the sink again.
+func (m *Manager) RegisterNoticesUpdates(sink broadcasts.NoticesEmitter) func() {
+	return m.noticesbroadcaster.Register(sink)
+}
+
+// NoticePublished sends the new post to the room.notices subscribers.
+func (m *Manager) NoticePublished(post roomdb.NewsPost) {
+	m.noticesUpdater.Published(NoticeFromPost(post))
+}
+
+// NoticeFromPost turns a news post into what the room.notices subscribers get
+func NoticeFromPost(post roomdb.NewsPost) broadcasts.Notice {
+	return broadcasts.Notice{
+		ID:        post.ID,
+		NoticeID:  post.Notice.ID,
+		Title:     post.Notice.Title,
+		Content:   post.Notice.Content,
+		Language:  post.Notice.Language,
+		Published: post.PublishedAt,
+	}
+}
+
+// List just returns a list of feed references as strings.
+// Hidden attendants are not included, here and in the other lists.
 func (m *Manager) List() []string {
 	m.roomMu.Lock()
 	defer m.roomMu.Unlock()
-	return m.room.AsList()
+	return m.localAsList()
+}
+
+// ListAll is like List but also includes the attendants of federated sibling rooms
+func (m *Manager) ListAll() []string {
+	m.roomMu.Lock()
+	defer m.roomMu.Unlock()
+	return m.allAsList()
 }
 
 func (m *Manager) ListAsRefs() []refs.FeedRef {
 	m.roomMu.Lock()
-	lst := m.room.AsList()
+	lst := m.localAsList()
+	m.roomMu.Unlock()
+
+	return toRefs(lst)
+}
+
+// ListAllAsRefs is like ListAsRefs but also includes the attendants of federated sibling rooms
+func (m *Manager) ListAllAsRefs() []refs.FeedRef {
+	m.roomMu.Lock()
+	lst := m.allAsList()
 	m.roomMu.Unlock()
 
+	return toRefs(lst)
+}
+
+func toRefs(lst []string) []refs.FeedRef {
 	rlst := make([]refs.FeedRef, len(lst))
 	for i, s := range lst {
 		fr, err := refs.ParseFeedRef(s)
@@ -84,61 +282,277 @@ func (m *Manager) ListAsRefs() []refs.FeedRef {
 	return rlst
 }
 
-// AddEndpoint adds the endpoint to the room
+// AddEndpoint adds the endpoint to the room.
+// A feed can be in the room with more than one connection, the others are only notified about the first one.
 func (m *Manager) AddEndpoint(who refs.FeedRef, edp muxrpc.Endpoint) {
+	m.AlreadyAdded(who, edp)
+}
+
+// RemoveEndpoint removes one connection of the peer.
+// The peer leaves the room once the last of its connections is removed.
+func (m *Manager) RemoveEndpoint(who refs.FeedRef, edp muxrpc.Endpoint) {
 	m.roomMu.Lock()
-	// add ref to to the room map
-	m.room[who.String()] = edp
-	currentMembers := m.room.AsList()
+	a, has := m.room[who.String()]
+	if !has {
+		m.roomMu.Unlock()
+		return
+	}
+	a.remove(edp)
+	if len(a.conns) > 0 {
+		m.roomMu.Unlock()
+		return
+	}
+	delete(m.room, who.String())
+	_, stillRemote := m.remote[who.String()]
+	_, hidden := m.hidden[who.String()]
 	m.roomMu.Unlock()
-	// update all the connected tunnel.endpoints calls
-	m.endpointsUpdater.Update(currentMembers)
-	// update all the connected room.attendants calls
-	m.attendantsUpdater.Joined(who)
+
+	if !hidden {
+		m.left(who, stillRemote)
+	}
 }
 
-// Remove removes the peer from the room
+// Remove removes the peer from the room, with all of its connections
 func (m *Manager) Remove(who refs.FeedRef) {
 	m.roomMu.Lock()
 	// remove ref from lobby
+	_, had := m.room[who.String()]
 	delete(m.room, who.String())
-	currentMembers := m.room.AsList()
+	_, stillRemote := m.remote[who.String()]
+	_, hidden := m.hidden[who.String()]
 	m.roomMu.Unlock()
+
+	if had && !hidden {
+		m.left(who, stillRemote)
+	}
+}
+
+func (m *Manager) left(who refs.FeedRef, stillRemote bool) {
 	// update all the connected tunnel.endpoints calls
-	m.endpointsUpdater.Update(currentMembers)
+	m.endpointsChanged()
 	// update all the connected room.attendants calls
-	m.attendantsUpdater.Left(who)
+	if !stillRemote {
+		m.attendantsUpdater.Left(who)
+	}
+	m.localAttendantsUpdater.Left(who)
 }
 
 // AlreadyAdded returns true if the peer was already added to the room.
-// if it isn't it will be added.
+// The endpoint is added to the connections of the peer, if it isn't already.
 func (m *Manager) AlreadyAdded(who refs.FeedRef, edp muxrpc.Endpoint) bool {
 	m.roomMu.Lock()
 
-	var currentMembers []string
 	// if the peer didn't call tunnel.announce()
-	_, has := m.room[who.String()]
+	a, has := m.room[who.String()]
+	_, wasRemote := m.remote[who.String()]
+	_, hidden := m.hidden[who.String()]
 	if !has {
 		// register them as if they didnt
-		m.room[who.String()] = edp
-		currentMembers = m.room.AsList()
+		a = &attendant{who: who, joined: time.Now()}
+		m.room[who.String()] = a
 	}
+	a.add(edp)
 	m.roomMu.Unlock()
 
-	if !has {
+	if !has && !hidden {
 		// update everyone
-		m.endpointsUpdater.Update(currentMembers)
-		m.attendantsUpdater.Joined(who)
+		m.endpointsChanged()
+		if !wasRemote {
+			m.attendantsUpdater.Joined(who)
+		}
+		m.localAttendantsUpdater.Joined(who)
 	}
 
 	return has
 }
 
-// Has returns true and the endpoint if the peer is in the room
+// MarkActive notes that the peer just used this connection, which makes it the first choice for Has.
+func (m *Manager) MarkActive(who refs.FeedRef, edp muxrpc.Endpoint) {
+	m.roomMu.Lock()
+	defer m.roomMu.Unlock()
+
+	a, has := m.room[who.String()]
+	if !has {
+		return
+	}
+	if i, has := a.find(edp); has {
+		now := time.Now()
+		a.conns[i].lastActive = now
+		a.conns[i].lastSeen = now
+		a.conns[i].failed = false
+	}
+}
+
+// MarkFailed notes that a call over this connection failed. Has only picks it if there is no other.
+func (m *Manager) MarkFailed(who refs.FeedRef, edp muxrpc.Endpoint) {
+	m.roomMu.Lock()
+	defer m.roomMu.Unlock()
+
+	a, has := m.room[who.String()]
+	if !has {
+		return
+	}
+	if i, has := a.find(edp); has {
+		a.conns[i].failed = true
+	}
+}
+
+// Connections returns how many connections the peer has to the room
+func (m *Manager) Connections(who refs.FeedRef) int {
+	m.roomMu.Lock()
+	defer m.roomMu.Unlock()
+
+	a, has := m.room[who.String()]
+	if !has {
+		return 0
+	}
+	return len(a.conns)
+}
+
+// AddRemote adds an attendant of the federated sibling room via, which we are connected to over viaEdp.
+func (m *Manager) AddRemote(who, via refs.FeedRef, viaEdp muxrpc.Endpoint) {
+	m.roomMu.Lock()
+	_, isLocal := m.room[who.String()]
+	prev, wasRemote := m.remote[who.String()]
+	joined := prev.joined
+	if !wasRemote {
+		joined = time.Now()
+	}
+	m.remote[who.String()] = remoteAttendant{via: via, viaEdp: viaEdp, joined: joined}
+	_, hidden := m.hidden[who.String()]
+	m.roomMu.Unlock()
+
+	if isLocal || wasRemote || hidden {
+		return
+	}
+	m.endpointsChanged()
+	m.attendantsUpdater.Joined(who)
+}
+
+// RemoveRemote removes an attendant of the sibling room via.
+// Nothing happens if the attendant is known through a different room.
+func (m *Manager) RemoveRemote(who, via refs.FeedRef) {
+	m.roomMu.Lock()
+	ra, has := m.remote[who.String()]
+	if !has || !ra.via.Equal(via) {
+		m.roomMu.Unlock()
+		return
+	}
+	delete(m.remote, who.String())
+	_, isLocal := m.room[who.String()]
+	_, hidden := m.hidden[who.String()]
+	m.roomMu.Unlock()
+
+	if isLocal || hidden {
+		return
+	}
+	m.endpointsChanged()
+	m.attendantsUpdater.Left(who)
+}
+
+// ClearRemote removes all the attendants of the sibling room via, for instance once the connection to it is lost.
+func (m *Manager) ClearRemote(via refs.FeedRef) {
+	m.roomMu.Lock()
+	var left []string
+	for who, ra := range m.remote {
+		if !ra.via.Equal(via) {
+			continue
+		}
+		delete(m.remote, who)
+		_, isLocal := m.room[who]
+		_, hidden := m.hidden[who]
+		if !isLocal && !hidden {
+			left = append(left, who)
+		}
+	}
+	m.roomMu.Unlock()
+
+	if len(left) == 0 {
+		return
+	}
+	m.endpointsChanged()
+	for _, who := range toRefs(left) {
+		m.attendantsUpdater.Left(who)
+	}
+}
+
+// HasRemote returns the sibling room the peer is attending and our endpoint for that room.
+func (m *Manager) HasRemote(who refs.FeedRef) (refs.FeedRef, muxrpc.Endpoint, bool) {
+	m.roomMu.Lock()
+	ra, has := m.remote[who.String()]
+	m.roomMu.Unlock()
+	return ra.via, ra.viaEdp, has
+}
+
+// Has returns true and the endpoint if the peer is in the room.
+// If the peer has more than one connection, it returns the one that was active most recently and didn't fail.
 func (m *Manager) Has(who refs.FeedRef) (muxrpc.Endpoint, bool) {
 	m.roomMu.Lock()
-	// add ref to to the room map
-	edp, has := m.room[who.String()]
+	defer m.roomMu.Unlock()
+
+	a, has := m.room[who.String()]
+	if !has {
+		return nil, false
+	}
+	return a.best(), true
+}
+
+// JoinedAt returns when the peer joined the room, either directly or through a sibling room.
+func (m *Manager) JoinedAt(who refs.FeedRef) (time.Time, bool) {
+	m.roomMu.Lock()
+	defer m.roomMu.Unlock()
+
+	if a, has := m.room[who.String()]; has {
+		return a.joined, true
+	}
+	if ra, has := m.remote[who.String()]; has {
+		return ra.joined, true
+	}
+	return time.Time{}, false
+}
+
+// SetHidden changes whether the feed is listed as an attendant. Hidden peers can still be reached with Has and HasRemote.
+// If the peer is in the room, the others see it leave or join.
+func (m *Manager) SetHidden(who refs.FeedRef, hidden bool) {
+	key := who.String()
+
+	m.roomMu.Lock()
+	_, wasHidden := m.hidden[key]
+	if hidden == wasHidden {
+		m.roomMu.Unlock()
+		return
+	}
+	if hidden {
+		m.hidden[key] = struct{}{}
+	} else {
+		delete(m.hidden, key)
+	}
+	_, isLocal := m.room[key]
+	_, isRemote := m.remote[key]
 	m.roomMu.Unlock()
-	return edp, has
+
+	if !isLocal && !isRemote {
+		return
+	}
+
+	m.endpointsChanged()
+	if hidden {
+		m.attendantsUpdater.Left(who)
+		if isLocal {
+			m.localAttendantsUpdater.Left(who)
+		}
+	} else {
+		m.attendantsUpdater.Joined(who)
+		if isLocal {
+			m.localAttendantsUpdater.Joined(who)
+		}
+	}
+}
+
+// Hidden returns true if the feed doesn't want to be listed
+func (m *Manager) Hidden(who refs.FeedRef) bool {
+	m.roomMu.Lock()
+	defer m.roomMu.Unlock()
+	_, hidden := m.hidden[who.String()]
+	return hidden
 }
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package roomstate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ssbc/go-muxrpc/v2"
+	"github.com/stretchr/testify/require"
+	kitlog "go.mindeco.de/log"
+
+	refs "github.com/ssbc/go-ssb-refs"
+)
+
+// testEndpoint only needs to be distinguishable from the others
+type testEndpoint struct {
+	muxrpc.Endpoint
+	name string
+}
+
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (er *eventRecorder) Joined(member refs.FeedRef) error {
+	return er.add("joined " + member.String())
+}
+
+func (er *eventRecorder) Left(member refs.FeedRef) error {
+	return er.add("left " + member.String())
+}
+
+func (er *eventRecorder) Close() error { return nil }
+
+func (er *eventRecorder) add(evt string) error {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+	er.events = append(er.events, evt)
+	return nil
+}
+
+func (er *eventRecorder) list() []string {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+	return append([]string(nil), er.events...)
+}
+
+func TestMultipleConnections(t *testing.T) {
+	r := require.New(t)
+
+	m := NewManager(kitlog.NewNopLogger())
+
+	var rec eventRecorder
+	m.RegisterAttendantsUpdates(&rec)
+
+	feed, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{1}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	phone := &testEndpoint{name: "phone"}
+	laptop := &testEndpoint{name: "laptop"}
+
+	m.AddEndpoint(feed, phone)
+	m.AddEndpoint(feed, phone) // announce and endpoints on the same connection
+	r.True(m.AlreadyAdded(feed, laptop))
+	r.Equal(2, m.Connections(feed))
+
+	// the laptop was added last
+	edp, has := m.Has(feed)
+	r.True(has)
+	r.Equal(laptop, edp)
+
+	time.Sleep(10 * time.Millisecond) // make sure the timestamps differ
+	m.MarkActive(feed, phone)
+	edp, _ = m.Has(feed)
+	r.Equal(phone, edp)
+
+	// failed connections are only used if there is no other
+	m.MarkFailed(feed, phone)
+	edp, _ = m.Has(feed)
+	r.Equal(laptop, edp)
+
+	// closing one connection keeps the feed in the room
+	m.RemoveEndpoint(feed, laptop)
+	r.Equal(1, m.Connections(feed))
+	edp, has = m.Has(feed)
+	r.True(has)
+	r.Equal(phone, edp)
+
+	m.RemoveEndpoint(feed, phone)
+	_, has = m.Has(feed)
+	r.False(has)
+	r.Equal(0, m.Connections(feed))
+
+	// removing an unknown connection doesn't do anything
+	m.RemoveEndpoint(feed, phone)
+
+	r.Eventually(func() bool { return len(rec.list()) == 2 }, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	r.Equal([]string{"joined " + feed.String(), "left " + feed.String()}, rec.list())
+}
+
+func TestKeepAliveEvictsStaleConnections(t *testing.T) {
+	r := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := NewManager(kitlog.NewNopLogger())
+
+	var rec eventRecorder
+	m.RegisterAttendantsUpdates(&rec)
+
+	alive, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{1}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	stale, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{2}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	aliveEdp := new(muxrpc.FakeEndpoint)
+	aliveEdp.AsyncCalls(func(_ context.Context, _ interface{}, _ muxrpc.RequestEncoding, method muxrpc.Method, _ ...interface{}) error {
+		if method.String() != "tunnel.ping" {
+			return fmt.Errorf("unexpected call: %s", method)
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+
+	// never answers
+	staleEdp := new(muxrpc.FakeEndpoint)
+	staleEdp.AsyncCalls(func(ctx context.Context, _ interface{}, _ muxrpc.RequestEncoding, _ muxrpc.Method, _ ...interface{}) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	// knows neither tunnel.ping nor gossip.ping but answers with errors, which still shows it's there
+	erroring, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{3}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	erroringEdp := new(muxrpc.FakeEndpoint)
+	erroringEdp.AsyncCalls(func(_ context.Context, _ interface{}, _ muxrpc.RequestEncoding, method muxrpc.Method, _ ...interface{}) error {
+		return &muxrpc.CallError{Name: "Error", Message: "no such method: " + method.String()}
+	})
+	erroringEdp.DuplexReturns(nil, nil, &muxrpc.CallError{Name: "Error", Message: "no such method: gossip.ping"})
+
+	m.AddEndpoint(alive, aliveEdp)
+	m.AddEndpoint(stale, staleEdp)
+	m.AddEndpoint(erroring, erroringEdp)
+
+	go m.KeepAlive(ctx, KeepAliveOptions{Interval: 20 * time.Millisecond, MaxMissed: 2})
+
+	r.Eventually(func() bool {
+		_, has := m.Has(stale)
+		return !has
+	}, 5*time.Second, 10*time.Millisecond)
+	r.Equal(1, staleEdp.TerminateCallCount())
+
+	_, has := m.Has(alive)
+	r.True(has)
+	r.Equal(0, aliveEdp.TerminateCallCount())
+
+	_, has = m.Has(erroring)
+	r.True(has, "peers that answer with errors are not stale")
+	r.Equal(0, erroringEdp.TerminateCallCount())
+	r.True(erroringEdp.DuplexCallCount() > 0, "should fall back to gossip.ping")
+	m.RemoveEndpoint(erroring, erroringEdp)
+
+	lst := m.Attendants()
+	r.Len(lst, 1)
+	r.True(lst[0].ID.Equal(alive))
+	r.Equal(1, lst[0].Connections)
+	r.True(lst[0].RTT >= time.Millisecond, "rtt: %s", lst[0].RTT)
+	r.WithinDuration(time.Now(), lst[0].LastSeen, time.Second)
+
+	r.Eventually(func() bool {
+		for _, evt := range rec.list() {
+			if evt == "left "+stale.String() {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHiddenAttendants(t *testing.T) {
+	r := require.New(t)
+
+	m := NewManager(kitlog.NewNopLogger())
+
+	var rec eventRecorder
+	m.RegisterAttendantsUpdates(&rec)
+
+	shy, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{1}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+	open, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{2}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	m.SetHidden(shy, true)
+	r.True(m.Hidden(shy))
+
+	shyEdp := &testEndpoint{name: "shy"}
+	m.AddEndpoint(shy, shyEdp)
+	m.AddEndpoint(open, &testEndpoint{name: "open"})
+
+	r.Equal([]string{open.String()}, m.List())
+	r.Len(m.ListAllAsRefs(), 1)
+
+	// still reachable for tunnel.connect
+	edp, has := m.Has(shy)
+	r.True(has)
+	r.Equal(shyEdp, edp)
+
+	// showing up again is like joining
+	m.SetHidden(shy, false)
+	r.Len(m.List(), 2)
+	r.Eventually(func() bool { return len(rec.list()) == 2 }, time.Second, 10*time.Millisecond)
+
+	m.SetHidden(shy, true)
+	m.RemoveEndpoint(shy, shyEdp)
+
+	r.Eventually(func() bool { return len(rec.list()) == 3 }, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	r.Equal([]string{
+		"joined " + open.String(),
+		"joined " + shy.String(),
+		"left " + shy.String(),
+	}, rec.list())
+}
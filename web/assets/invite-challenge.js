@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+// finds a solution for the proof-of-work of internal/hashcash:
+// the SHA-256 hash of "challenge:solution" has to start with difficulty zero bits
+
+const formElem = document.getElementById('invite-challenge');
+const waitingElem = document.getElementById('waiting');
+const encoder = new TextEncoder();
+
+function leadingZeros(bytes) {
+  let n = 0;
+  for (const b of bytes) {
+    if (b !== 0) return n + Math.clz32(b) - 24;
+    n += 8;
+  }
+  return n;
+}
+
+async function solve(challenge, difficulty) {
+  for (let n = 0; ; n++) {
+    const solution = String(n);
+    const digest = await crypto.subtle.digest('SHA-256', encoder.encode(challenge + ':' + solution));
+    if (leadingZeros(new Uint8Array(digest)) >= difficulty) return solution;
+  }
+}
+
+formElem.onsubmit = async function handleSubmit(ev) {
+  ev.preventDefault();
+  formElem.querySelector('input[type=submit]').disabled = true;
+  waitingElem.classList.remove('hidden');
+
+  const difficulty = parseInt(formElem.dataset.difficulty, 10);
+  formElem.elements.solution.value = await solve(formElem.elements.challenge.value, difficulty);
+  formElem.submit();
+};
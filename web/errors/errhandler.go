@@ -95,6 +95,8 @@ func localizeError(ih *i18n.Localizer, err error) (int, template.HTML) {
 	// localize some specific error messages
 	var (
 		aa  roomdb.ErrAlreadyAdded
+		pst roomdb.ErrPageSlugTaken
+		coc roomdb.ErrCodeOfConductNotAccepted
 		pnf PageNotFound
 		br  ErrBadRequest
 		f   ErrForbidden
@@ -122,6 +124,14 @@ func localizeError(ih *i18n.Localizer, err error) (int, template.HTML) {
 	case errors.As(err, &aa):
 		msg = ih.LocalizeWithData("ErrorAlreadyAdded", "Feed", aa.Ref.String())
 
+	case errors.As(err, &pst):
+		code = http.StatusBadRequest
+		msg = ih.LocalizeWithData("ErrorPageSlugTaken", "Slug", pst.Slug)
+
+	case errors.As(err, &coc):
+		code = http.StatusForbidden
+		msg = ih.LocalizeSimple("ErrorCodeOfConductNotAccepted")
+
 	case errors.As(err, &pnf):
 		code = http.StatusNotFound
 		msg = ih.LocalizeWithData("ErrorPageNotFound", "Path", pnf.Path)
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+)
+
+type brandingHandler struct {
+	flashes *weberrors.FlashHelper
+
+	// where to go after every change
+	redirect string
+
+	db roomdb.RoomConfig
+}
+
+func (h brandingHandler) setBranding(w http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(w, req, h.redirect, http.StatusSeeOther)
+
+	if !checkPost(h.flashes, w, req) {
+		return
+	}
+
+	ctx := req.Context()
+	current, err := h.db.GetBranding(ctx)
+	if err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	b := roomdb.Branding{
+		Name:        strings.TrimSpace(req.Form.Get("name")),
+		Tagline:     strings.TrimSpace(req.Form.Get("tagline")),
+		AccentColor: strings.ToLower(strings.TrimSpace(req.Form.Get("accent_color"))),
+
+		// the logo has its own form
+		HasLogo: current.HasLogo,
+	}
+	if err := b.Validate(); err != nil {
+		err = weberrors.ErrBadRequest{Where: "Branding", Details: err}
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	if err := h.db.SetBranding(ctx, b); err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(w, req, "AdminBrandingUpdated")
+}
+
+func (h brandingHandler) setLogo(w http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(w, req, h.redirect, http.StatusSeeOther)
+
+	if !checkAdminPost(h.flashes, w, req) {
+		return
+	}
+
+	// leave some room for the other parts of the form
+	req.Body = http.MaxBytesReader(w, req.Body, roomdb.MaxLogoSize+64*1024)
+	if err := req.ParseMultipartForm(roomdb.MaxLogoSize + 64*1024); err != nil {
+		err = weberrors.ErrBadRequest{Where: "Form data", Details: err}
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	file, _, err := req.FormFile("logo")
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "Logo", Details: err}
+		h.flashes.AddError(w, req, err)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, roomdb.MaxLogoSize+1))
+	if err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	// don't trust the type the browser sent
+	logo := roomdb.Logo{
+		ContentType: http.DetectContentType(data),
+		Data:        data,
+	}
+	if err := logo.Validate(); err != nil {
+		err = weberrors.ErrBadRequest{Where: "Logo", Details: err}
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	if err := h.db.SetLogo(req.Context(), logo); err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(w, req, "AdminBrandingLogoUpdated")
+}
+
+func (h brandingHandler) removeLogo(w http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(w, req, h.redirect, http.StatusSeeOther)
+
+	if !checkPost(h.flashes, w, req) {
+		return
+	}
+
+	if err := h.db.RemoveLogo(req.Context()); err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(w, req, "AdminBrandingLogoRemoved")
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+	"github.com/ssbc/go-ssb-room/v2/web/webassert"
+)
+
+func TestBrandingSet(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	ts.User = roomdb.Member{ID: 1234, Role: roomdb.RoleAdmin}
+	ts.ConfigDB.GetBrandingReturns(roomdb.Branding{Name: "Hermies Club", HasLogo: true}, nil)
+
+	settingsURL := ts.URLTo(router.AdminSettings)
+	setURL := ts.URLTo(router.AdminSettingsSetBranding)
+
+	html, resp := ts.Client.GetHTML(settingsURL)
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	formSelection := html.Find("form#set-branding")
+	a.Equal(1, formSelection.Length())
+	webassert.ElementsInForm(t, formSelection, []webassert.FormElement{
+		{Name: "name", Type: "text", Value: "Hermies Club"},
+		{Name: "tagline", Type: "text"},
+		{Name: "accent_color", Type: "text"},
+	})
+	a.Equal(1, html.Find("form#set-logo").Length())
+	a.Equal(1, html.Find("form#remove-logo").Length())
+
+	// the name is added to the page titles
+	a.Equal("Settings · Hermies Club", html.Find("title").Text())
+
+	// invalid colors are not stored
+	rec := ts.Client.PostForm(setURL, url.Values{"name": []string{"Hermies"}, "accent_color": []string{"red"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(settingsURL.Path, rec.Header().Get("Location"))
+	r.Equal(0, ts.ConfigDB.SetBrandingCallCount())
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "ErrorBadRequest")
+
+	rec = ts.Client.PostForm(setURL, url.Values{
+		"name":         []string{" Hermies "},
+		"tagline":      []string{"a place for crabs"},
+		"accent_color": []string{"#6D28D9"},
+	})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.ConfigDB.SetBrandingCallCount())
+	_, b := ts.ConfigDB.SetBrandingArgsForCall(0)
+	a.Equal(roomdb.Branding{Name: "Hermies", Tagline: "a place for crabs", AccentColor: "#6d28d9", HasLogo: true}, b)
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "AdminBrandingUpdated")
+
+	// only admins can change the branding
+	ts.User = roomdb.Member{ID: 7331, Role: roomdb.RoleModerator}
+	rec = ts.Client.PostForm(setURL, url.Values{"name": []string{"Evil"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.ConfigDB.SetBrandingCallCount())
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "ErrorNotAuthorized")
+
+	html, _ = ts.Client.GetHTML(settingsURL)
+	a.Equal(0, html.Find("form#set-branding").Length(), "moderators should not see the form")
+	a.Equal(1, html.Find("#branding-values").Length())
+}
+
+func TestBrandingLogo(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	ts.User = roomdb.Member{ID: 1234, Role: roomdb.RoleAdmin}
+
+	settingsURL := ts.URLTo(router.AdminSettings)
+
+	var img bytes.Buffer
+	r.NoError(png.Encode(&img, image.NewGray(image.Rect(0, 0, 4, 4))))
+
+	upload := func(data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, err := mw.CreateFormFile("logo", "logo.svg") // the name and the type of the browser are ignored
+		r.NoError(err)
+		fw.Write(data)
+		r.NoError(mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, ts.URLTo(router.AdminSettingsSetLogo).String(), &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		rec := httptest.NewRecorder()
+		ts.Mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := upload(img.Bytes())
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(settingsURL.Path, rec.Header().Get("Location"))
+	r.Equal(1, ts.ConfigDB.SetLogoCallCount())
+	_, logo := ts.ConfigDB.SetLogoArgsForCall(0)
+	a.Equal("image/png", logo.ContentType)
+	a.Equal(img.Bytes(), logo.Data)
+
+	// scripts are not images
+	rec = upload([]byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`))
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.ConfigDB.SetLogoCallCount())
+
+	// too big
+	rec = upload(append(img.Bytes(), make([]byte, roomdb.MaxLogoSize)...))
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.ConfigDB.SetLogoCallCount())
+
+	rec = ts.Client.PostForm(ts.URLTo(router.AdminSettingsRemoveLogo), url.Values{})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.ConfigDB.RemoveLogoCallCount())
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "AdminBrandingLogoRemoved")
+
+	// only admins can change the logo
+	ts.User = roomdb.Member{ID: 7331, Role: roomdb.RoleMember}
+	rec = upload(img.Bytes())
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.ConfigDB.SetLogoCallCount())
+}
@@ -10,7 +10,6 @@ import (
 	"net/http"
 	"time"
 
-	refs "github.com/ssbc/go-ssb-refs"
 	"go.mindeco.de/http/render"
 	"go.mindeco.de/log/level"
 	"go.mindeco.de/logging"
@@ -21,11 +20,18 @@ import (
 	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
 )
 
+// ConnectionStats is implemented by network.ConnLimiter
+type ConnectionStats interface {
+	Stats() network.ConnLimiterStats
+}
+
 type dashboardHandler struct {
 	r       *render.Renderer
 	flashes *weberrors.FlashHelper
 
 	roomState *roomstate.Manager
+	peerConns PeerConnections
+	connStats ConnectionStats
 	netInfo   network.ServerEndpointDetails
 	dbs       Databases
 }
@@ -36,15 +42,15 @@ func (h dashboardHandler) overview(w http.ResponseWriter, req *http.Request) (in
 		ctx     = req.Context()
 		roomRef = h.netInfo.RoomID.String()
 
-		onlineRefs   []refs.FeedRef
-		refsUpdateCh = make(chan []refs.FeedRef)
+		online       []roomstate.AttendantInfo
+		refsUpdateCh = make(chan []roomstate.AttendantInfo)
 		onlineCount  = -1
 	)
 
 	// this is an attempt to sidestep the _dashboard doesn't render_ bug (issue #210)
 	// first we retreive the member state via a goroutine in the background
 	go func() {
-		refsUpdateCh <- h.roomState.ListAsRefs()
+		refsUpdateCh <- h.roomState.Attendants()
 	}()
 
 	// if it doesn't complete in 10 seconds the slice stays empty and onlineCount remains -1 (to indicate a problem)
@@ -53,13 +59,18 @@ func (h dashboardHandler) overview(w http.ResponseWriter, req *http.Request) (in
 		logger := logging.FromContext(ctx)
 		level.Warn(logger).Log("event", "didnt retreive room state in time")
 
-	case onlineRefs = <-refsUpdateCh:
-		onlineCount = len(onlineRefs)
+	case online = <-refsUpdateCh:
+		onlineCount = len(online)
 	}
 
-	// in the timeout case, nothing will happen here since the onlineRefs slice is empty
-	onlineUsers := make([]connectedUser, len(onlineRefs))
-	for i, ref := range onlineRefs {
+	// in the timeout case, nothing will happen here since the online slice is empty
+	onlineUsers := make([]connectedUser, len(online))
+	for i, info := range online {
+		ref := info.ID
+		onlineUsers[i].LastSeen = info.LastSeen
+		onlineUsers[i].RTT = info.RTT
+		onlineUsers[i].Connections = info.Connections
+
 		// try to get the member
 		onlineUsers[i].Member, err = h.dbs.Members.GetByFeed(ctx, ref)
 		if err != nil {
@@ -98,6 +109,14 @@ func (h dashboardHandler) overview(w http.ResponseWriter, req *http.Request) (in
 		"DeniedCount": deniedCount,
 	}
 
+	// both are optional, the template leaves their sections out if they are missing
+	if h.peerConns != nil {
+		pageData["Peers"] = h.peerConns.Status()
+	}
+	if h.connStats != nil {
+		pageData["Connections"] = h.connStats.Stats()
+	}
+
 	pageData["Flashes"], err = h.flashes.GetAll(w, req)
 	if err != nil {
 		return nil, err
@@ -109,6 +128,18 @@ func (h dashboardHandler) overview(w http.ResponseWriter, req *http.Request) (in
 // connectedUser defines how we want to present a connected user
 type connectedUser struct {
 	roomdb.Member
+
+	LastSeen    time.Time
+	RTT         time.Duration
+	Connections int
+}
+
+// RoundTrip returns the ping time in milliseconds or an empty string if the peer wasn't pinged yet
+func (dm connectedUser) RoundTrip() string {
+	if dm.RTT == 0 {
+		return ""
+	}
+	return dm.RTT.Round(time.Millisecond).String()
 }
 
 // if the member has an alias, use the first one. Otherwise use the public key
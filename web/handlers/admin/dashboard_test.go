@@ -10,7 +10,9 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/ssbc/go-muxrpc/v2"
 	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
 	"github.com/ssbc/go-ssb-room/v2/web/router"
 	"github.com/ssbc/go-ssb-room/v2/web/webassert"
@@ -93,3 +95,52 @@ func TestDashboardWithVisitors(t *testing.T) {
 	wantLink := ts.URLTo(router.AdminMemberDetails, "id", 23)
 	a.Equal(wantLink.String(), gotLink)
 }
+
+func TestDashboardAttendantLiveness(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	testRef, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{0}, 32), refs.RefAlgoFeedSSB1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// two devices of the same feed
+	ts.RoomState.AddEndpoint(testRef, new(muxrpc.FakeEndpoint))
+	ts.RoomState.AddEndpoint(testRef, new(muxrpc.FakeEndpoint))
+
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminDashboard))
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	a.Equal("1", html.Find("#online-count").Text())
+
+	liveness := html.Find("#connected-list .attendant-liveness")
+	a.Equal(1, liveness.Length())
+	a.Contains(liveness.Text(), "AdminDashboardLastSeen")
+	a.Contains(liveness.Text(), "AdminDashboardConnectionsPlural")
+	a.NotContains(liveness.Text(), "AdminDashboardRoundTrip", "not pinged yet")
+}
+
+func TestDashboardConnectionStats(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	dashURL := ts.URLTo(router.AdminDashboard)
+
+	html, resp := ts.Client.GetHTML(dashURL)
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+	a.Equal("0", html.Find("#open-connections").Text())
+	a.Equal(0, html.Find("#rejected-connections").Length(), "nothing rejected yet")
+
+	ts.ConnStats.stats = network.ConnLimiterStats{
+		Open:          7,
+		RejectedTotal: 1,
+		RejectedPerIP: 2,
+		RejectedRate:  40,
+	}
+
+	html, resp = ts.Client.GetHTML(dashURL)
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+	a.Equal("7", html.Find("#open-connections").Text())
+	a.Equal("43", html.Find("#rejected-connections").Text())
+}
@@ -43,7 +43,16 @@ var HTMLTemplates = []string{
 	"admin/invite-revoke-confirm.tmpl",
 	"admin/invite-created.tmpl",
 
+	"admin/join-requests.tmpl",
+
 	"admin/notice-edit.tmpl",
+	"admin/notice-revision.tmpl",
+
+	"admin/pages.tmpl",
+	"admin/pages-remove-confirm.tmpl",
+
+	"admin/news.tmpl",
+	"admin/news-remove-confirm.tmpl",
 
 	"admin/member.tmpl",
 	"admin/member-list.tmpl",
@@ -55,12 +64,18 @@ var HTMLTemplates = []string{
 type Databases struct {
 	Aliases       roomdb.AliasesService
 	AuthFallback  roomdb.AuthFallbackService
+	CodeOfConduct roomdb.CodeOfConductService
 	Config        roomdb.RoomConfig
 	DeniedKeys    roomdb.DeniedKeysService
 	Invites       roomdb.InvitesService
+	JoinRequests  roomdb.JoinRequestsService
 	Notices       roomdb.NoticesService
 	Members       roomdb.MembersService
+	News          roomdb.NewsService
+	Pages         roomdb.PagesService
+	Peers         roomdb.PeersService
 	PinnedNotices roomdb.PinnedNoticesService
+	Presence      roomdb.PresenceService
 }
 
 // Handler supplies the elevated access pages to known users.
@@ -69,6 +84,8 @@ func Handler(
 	netInfo network.ServerEndpointDetails,
 	r *render.Renderer,
 	roomState *roomstate.Manager,
+	peerConns PeerConnections,
+	connStats ConnectionStats,
 	fh *weberrors.FlashHelper,
 	locHelper *i18n.Helper,
 	dbs Databases,
@@ -84,19 +101,52 @@ func Handler(
 
 		dbs:       dbs,
 		roomState: roomState,
+		peerConns: peerConns,
+		connStats: connStats,
 	}
 	mux.HandleFunc("/dashboard", r.HTML("admin/dashboard.tmpl", dashboardHandler.overview))
 
 	var sh = settingsHandler{
-		r:     r,
-		urlTo: urlTo,
-		db:    dbs.Config,
-		loc:   locHelper,
+		r:       r,
+		urlTo:   urlTo,
+		flashes: fh,
+		db:      dbs.Config,
+		loc:     locHelper,
+
+		peerConns: peerConns,
 	}
 	mux.HandleFunc("/settings", r.HTML("admin/settings.tmpl", sh.overview))
 	mux.HandleFunc("/settings/set-privacy", sh.setPrivacy)
 	mux.HandleFunc("/settings/set-language", sh.setLanguage)
 
+	var ph = peersHandler{
+		flashes:  fh,
+		redirect: urlTo(router.AdminSettings).String(),
+
+		db:    dbs.Peers,
+		conns: peerConns,
+	}
+	mux.HandleFunc("/settings/peers/add", ph.add)
+	mux.HandleFunc("/settings/peers/remove", ph.remove)
+
+	var bh = brandingHandler{
+		flashes:  fh,
+		redirect: urlTo(router.AdminSettings).String(),
+
+		db: dbs.Config,
+	}
+	mux.HandleFunc("/settings/branding", bh.setBranding)
+	mux.HandleFunc("/settings/logo", bh.setLogo)
+	mux.HandleFunc("/settings/logo/remove", bh.removeLogo)
+
+	var oih = openInvitesHandler{
+		flashes:  fh,
+		redirect: urlTo(router.AdminSettings).String(),
+
+		db: dbs.Config,
+	}
+	mux.HandleFunc("/settings/open-invites", oih.setLimits)
+
 	mux.HandleFunc("/menu", r.HTML("admin/menu.tmpl", func(w http.ResponseWriter, req *http.Request) (interface{}, error) {
 		return map[string]interface{}{}, nil
 	}))
@@ -131,13 +181,18 @@ func Handler(
 
 		db: dbs.Members,
 
-		fallbackAuthDB: dbs.AuthFallback,
-		roomCfgDB:      dbs.Config,
+		fallbackAuthDB:  dbs.AuthFallback,
+		roomCfgDB:       dbs.Config,
+		presenceDB:      dbs.Presence,
+		codeOfConductDB: dbs.CodeOfConduct,
+
+		roomState: roomState,
 	}
 	mux.HandleFunc("/member", r.HTML("admin/member.tmpl", mh.details))
 	mux.HandleFunc("/members", r.HTML("admin/member-list.tmpl", mh.overview))
 	mux.HandleFunc("/members/add", mh.add)
 	mux.HandleFunc("/members/change-role", mh.changeRole)
+	mux.HandleFunc("/members/set-hidden", mh.setHidden)
 	mux.HandleFunc("/members/remove/confirm", r.HTML("admin/members-remove-confirm.tmpl", mh.removeConfirm))
 	mux.HandleFunc("/members/remove", mh.remove)
 	mux.HandleFunc("/members/create-fallback-reset-link", r.HTML("admin/members-show-password-reset-token.tmpl", mh.createPasswordResetToken))
@@ -156,19 +211,64 @@ func Handler(
 	mux.HandleFunc("/invites/revoke/confirm", r.HTML("admin/invite-revoke-confirm.tmpl", ih.revokeConfirm))
 	mux.HandleFunc("/invites/revoke", ih.revoke)
 
+	var jrh = joinRequestsHandler{
+		r:       r,
+		flashes: fh,
+
+		db:      dbs.JoinRequests,
+		roomCfg: dbs.Config,
+	}
+	mux.HandleFunc("/join-requests", r.HTML("admin/join-requests.tmpl", jrh.overview))
+	mux.HandleFunc("/join-requests/approve", jrh.approve)
+	mux.HandleFunc("/join-requests/decline", jrh.decline)
+
 	var nh = noticeHandler{
 		r:       r,
 		urlTo:   urlTo,
 		flashes: fh,
 
-		noticeDB: dbs.Notices,
-		pinnedDB: dbs.PinnedNotices,
-		roomCfg:  dbs.Config,
+		noticeDB:  dbs.Notices,
+		pinnedDB:  dbs.PinnedNotices,
+		pagesDB:   dbs.Pages,
+		roomCfg:   dbs.Config,
+		membersDB: dbs.Members,
+
+		codeOfConductDB: dbs.CodeOfConduct,
 	}
 	mux.Handle("/notice/edit", r.HTML("admin/notice-edit.tmpl", nh.edit))
 	mux.Handle("/notice/translation/draft", r.HTML("admin/notice-edit.tmpl", nh.draftTranslation))
 	mux.Handle("/notice/translation/add", http.HandlerFunc(nh.addTranslation))
 	mux.Handle("/notice/save", http.HandlerFunc(nh.save))
+	mux.Handle("/notice/revision", r.HTML("admin/notice-revision.tmpl", nh.revision))
+	mux.Handle("/notice/revert", http.HandlerFunc(nh.revert))
+
+	var pgh = pagesHandler{
+		r:       r,
+		flashes: fh,
+
+		db:      dbs.Pages,
+		roomCfg: dbs.Config,
+	}
+	mux.HandleFunc("/pages", r.HTML("admin/pages.tmpl", pgh.overview))
+	mux.HandleFunc("/pages/create", pgh.create)
+	mux.HandleFunc("/pages/update", pgh.update)
+	mux.HandleFunc("/pages/remove/confirm", r.HTML("admin/pages-remove-confirm.tmpl", pgh.removeConfirm))
+	mux.HandleFunc("/pages/remove", pgh.remove)
+
+	var nwh = newsHandler{
+		r:       r,
+		flashes: fh,
+
+		db:       dbs.News,
+		pinnedDB: dbs.PinnedNotices,
+		roomCfg:  dbs.Config,
+
+		roomState: roomState,
+	}
+	mux.HandleFunc("/news", r.HTML("admin/news.tmpl", nwh.overview))
+	mux.HandleFunc("/news/create", nwh.create)
+	mux.HandleFunc("/news/remove/confirm", r.HTML("admin/news-remove-confirm.tmpl", nwh.removeConfirm))
+	mux.HandleFunc("/news/remove", nwh.remove)
 
 	// path:/ matches everything that isn't registerd (ie. its the "Not Found handler")
 	mux.HandleFunc("/", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
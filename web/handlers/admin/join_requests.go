@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/csrf"
+	"go.mindeco.de/http/render"
+	"go.mindeco.de/log/level"
+	"go.mindeco.de/logging"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/members"
+)
+
+type joinRequestsHandler struct {
+	r       *render.Renderer
+	flashes *weberrors.FlashHelper
+
+	db      roomdb.JoinRequestsService
+	roomCfg roomdb.RoomConfig
+}
+
+const redirectToJoinRequests = "/admin/join-requests"
+
+// how a request is approved
+const (
+	approveAsMember = "member"
+	approveAsInvite = "invite"
+)
+
+func (h joinRequestsHandler) overview(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	ctx := req.Context()
+
+	lst, err := h.db.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := h.db.CountPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pageData, err := paginate(lst, len(lst), req.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	pageData["Pending"] = pending
+	pageData[csrf.TemplateTag] = csrf.TemplateField(req)
+	pageData["Flashes"], err = h.flashes.GetAll(rw, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pageData, nil
+}
+
+// approve either adds the feed as a member right away or lets the requester pick up a personal invite.
+// The requester finds the invite on the status page of their request.
+// The database does both in one transaction, so a request is never approved without its member or the other way around.
+func (h joinRequestsHandler) approve(rw http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(rw, req, redirectToJoinRequests, http.StatusSeeOther)
+
+	ctx := req.Context()
+
+	moderator, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionDecideJoinRequests)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	jr, err := h.pendingRequest(req)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	as := req.FormValue("as")
+	if as != approveAsMember && as != approveAsInvite {
+		err = weberrors.ErrBadRequest{Where: "as", Details: fmt.Errorf("unknown way to approve: %q", as)}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+	withInvite := as == approveAsInvite
+
+	err = h.db.Approve(ctx, jr.ID, moderator.ID, withInvite)
+	if err != nil {
+		if errors.Is(err, roomdb.ErrNotFound) {
+			// decided by someone else in the meantime
+			err = weberrors.ErrGenericLocalized{Label: "ErrorJoinRequestDecided"}
+		} else if errors.Is(err, roomdb.ErrDeniedKey) {
+			err = weberrors.ErrGenericLocalized{Label: "ErrorJoinRequestDenied"}
+		}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	logger := logging.FromContext(ctx)
+	level.Info(logger).Log("event", "join request approved", "id", jr.ID, "ref", jr.PubKey.ShortSigil(), "invite", withInvite)
+
+	h.flashes.AddMessage(rw, req, "AdminJoinRequestApproved")
+}
+
+func (h joinRequestsHandler) decline(rw http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(rw, req, redirectToJoinRequests, http.StatusSeeOther)
+
+	ctx := req.Context()
+
+	moderator, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionDecideJoinRequests)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	jr, err := h.pendingRequest(req)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	err = h.db.Decline(ctx, jr.ID, moderator.ID)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(rw, req, "AdminJoinRequestDeclined")
+}
+
+// pendingRequest returns the request of the id in the form, if it wasn't decided yet
+func (h joinRequestsHandler) pendingRequest(req *http.Request) (roomdb.JoinRequest, error) {
+	if req.Method != "POST" {
+		return roomdb.JoinRequest{}, weberrors.ErrBadRequest{Where: "HTTP Method", Details: fmt.Errorf("expected POST not %s", req.Method)}
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return roomdb.JoinRequest{}, weberrors.ErrBadRequest{Where: "Form data", Details: err}
+	}
+
+	id, err := strconv.ParseInt(req.FormValue("id"), 10, 64)
+	if err != nil {
+		return roomdb.JoinRequest{}, weberrors.ErrBadRequest{Where: "ID", Details: err}
+	}
+
+	jr, err := h.db.GetByID(req.Context(), id)
+	if err != nil {
+		if errors.Is(err, roomdb.ErrNotFound) {
+			return roomdb.JoinRequest{}, weberrors.ErrNotFound{What: "join request"}
+		}
+		return roomdb.JoinRequest{}, err
+	}
+
+	if jr.Status != roomdb.JoinRequestPending {
+		return roomdb.JoinRequest{}, weberrors.ErrGenericLocalized{Label: "ErrorJoinRequestDecided"}
+	}
+
+	return jr, nil
+}
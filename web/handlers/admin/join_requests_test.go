@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+	"github.com/ssbc/go-ssb-room/v2/web/webassert"
+)
+
+func TestJoinRequestsOverview(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	ts.User = roomdb.Member{ID: 1, Role: roomdb.RoleModerator}
+
+	pending, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{1}, 32), refs.RefAlgoFeedSSB1)
+	a.NoError(err)
+	declined, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{2}, 32), refs.RefAlgoFeedSSB1)
+	a.NoError(err)
+
+	ts.JoinRequestsDB.ListReturns([]roomdb.JoinRequest{
+		{ID: 2, PubKey: pending, Message: "hi, i know alice", Status: roomdb.JoinRequestPending, CreatedAt: time.Now()},
+		{ID: 1, PubKey: declined, Status: roomdb.JoinRequestDeclined, CreatedAt: time.Now().Add(-time.Hour), DecidedBy: 1, DecidedAt: time.Now()},
+	}, nil)
+	ts.JoinRequestsDB.CountPendingReturns(1, nil)
+
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminJoinRequestsOverview))
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	webassert.Localized(t, html, []webassert.LocalizedElement{
+		{"#welcome", "AdminJoinRequestsWelcome"},
+		{"title", "AdminJoinRequestsTitle"},
+		{"#join-requests-pending", "AdminJoinRequestsPendingSingular"},
+	})
+
+	a.Equal(2, html.Find("#theList li").Length())
+
+	pendingItem := html.Find("#theList li[data-join-request='2']")
+	a.Contains(pendingItem.Text(), "hi, i know alice")
+	a.Equal(2, pendingItem.Find("form button").Length(), "should have both ways to approve and the decline button")
+
+	declinedItem := html.Find("#theList li[data-join-request='1']")
+	a.Equal(0, declinedItem.Find("form").Length())
+	decidedByURL := ts.URLTo(router.AdminMemberDetails, "id", 1)
+	a.Equal(1, declinedItem.Find("a[href='"+decidedByURL.String()+"']").Length())
+
+	// the menu links to the requests
+	a.Equal(1, html.Find("a[href='"+ts.URLTo(router.AdminJoinRequestsOverview).String()+"']").Length())
+}
+
+func TestJoinRequestsApprove(t *testing.T) {
+	ts := newSession(t)
+	a, r := assert.New(t), require.New(t)
+
+	listURL := ts.URLTo(router.AdminJoinRequestsOverview)
+	approveURL := ts.URLTo(router.AdminJoinRequestsApprove)
+
+	ref, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{1}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	ts.JoinRequestsDB.GetByIDReturns(roomdb.JoinRequest{ID: 23, PubKey: ref, Status: roomdb.JoinRequestPending}, nil)
+
+	// members can't decide
+	ts.User = roomdb.Member{ID: 7331, Role: roomdb.RoleMember}
+	rec := ts.Client.PostForm(approveURL, url.Values{"id": []string{"23"}, "as": []string{"member"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(listURL.Path, rec.Header().Get("Location"))
+	a.Equal(0, ts.JoinRequestsDB.ApproveCallCount())
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorNotAuthorized")
+
+	ts.User = roomdb.Member{ID: 9001, Role: roomdb.RoleModerator}
+
+	// as a member
+	rec = ts.Client.PostForm(approveURL, url.Values{"id": []string{"23"}, "as": []string{"member"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminJoinRequestApproved")
+
+	// the database adds the member together with the decision
+	r.Equal(1, ts.JoinRequestsDB.ApproveCallCount())
+	_, id, decidedBy, withInvite := ts.JoinRequestsDB.ApproveArgsForCall(0)
+	a.EqualValues(23, id)
+	a.EqualValues(9001, decidedBy)
+	a.False(withInvite)
+	a.Equal(0, ts.MembersDB.AddCallCount())
+	a.Equal(0, ts.InvitesDB.CreateCallCount())
+
+	// with an invite, which the requester picks up from the status page
+	rec = ts.Client.PostForm(approveURL, url.Values{"id": []string{"23"}, "as": []string{"invite"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminJoinRequestApproved")
+
+	r.Equal(2, ts.JoinRequestsDB.ApproveCallCount())
+	_, id, decidedBy, withInvite = ts.JoinRequestsDB.ApproveArgsForCall(1)
+	a.EqualValues(23, id)
+	a.EqualValues(9001, decidedBy)
+	a.True(withInvite)
+	a.Equal(0, ts.InvitesDB.CreateCallCount())
+
+	// unknown ways to approve
+	rec = ts.Client.PostForm(approveURL, url.Values{"id": []string{"23"}, "as": []string{"guest"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorBadRequest")
+	a.Equal(2, ts.JoinRequestsDB.ApproveCallCount())
+
+	// already decided
+	ts.JoinRequestsDB.GetByIDReturns(roomdb.JoinRequest{ID: 23, PubKey: ref, Status: roomdb.JoinRequestDeclined}, nil)
+
+	rec = ts.Client.PostForm(approveURL, url.Values{"id": []string{"23"}, "as": []string{"member"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorJoinRequestDecided")
+	a.Equal(2, ts.JoinRequestsDB.ApproveCallCount())
+
+	// decided by someone else in the meantime
+	ts.JoinRequestsDB.GetByIDReturns(roomdb.JoinRequest{ID: 23, PubKey: ref, Status: roomdb.JoinRequestPending}, nil)
+	ts.JoinRequestsDB.ApproveReturns(roomdb.ErrNotFound)
+
+	rec = ts.Client.PostForm(approveURL, url.Values{"id": []string{"23"}, "as": []string{"member"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorJoinRequestDecided")
+	a.Equal(3, ts.JoinRequestsDB.ApproveCallCount())
+
+	// banned after it asked
+	ts.JoinRequestsDB.ApproveReturns(roomdb.ErrDeniedKey)
+
+	rec = ts.Client.PostForm(approveURL, url.Values{"id": []string{"23"}, "as": []string{"invite"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorJoinRequestDenied")
+	a.Equal(4, ts.JoinRequestsDB.ApproveCallCount())
+}
+
+func TestJoinRequestsDecline(t *testing.T) {
+	ts := newSession(t)
+	a, r := assert.New(t), require.New(t)
+
+	listURL := ts.URLTo(router.AdminJoinRequestsOverview)
+	declineURL := ts.URLTo(router.AdminJoinRequestsDecline)
+
+	ref, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{1}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	ts.User = roomdb.Member{ID: 1, Role: roomdb.RoleAdmin}
+	ts.JoinRequestsDB.GetByIDReturns(roomdb.JoinRequest{ID: 5, PubKey: ref, Status: roomdb.JoinRequestPending}, nil)
+
+	rec := ts.Client.PostForm(declineURL, url.Values{"id": []string{"5"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(listURL.Path, rec.Header().Get("Location"))
+	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminJoinRequestDeclined")
+
+	r.Equal(1, ts.JoinRequestsDB.DeclineCallCount())
+	_, id, decidedBy := ts.JoinRequestsDB.DeclineArgsForCall(0)
+	a.EqualValues(5, id)
+	a.EqualValues(1, decidedBy)
+
+	// declining doesn't add anyone
+	a.Equal(0, ts.MembersDB.AddCallCount())
+	a.Equal(0, ts.InvitesDB.CreateCallCount())
+	a.Equal(0, ts.JoinRequestsDB.ApproveCallCount())
+}
@@ -17,6 +17,7 @@ import (
 	refs "github.com/ssbc/go-ssb-refs"
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomstate"
 	"github.com/ssbc/go-ssb-room/v2/web"
 	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
 	"github.com/ssbc/go-ssb-room/v2/web/members"
@@ -32,6 +33,11 @@ type membersHandler struct {
 	db             roomdb.MembersService
 	fallbackAuthDB roomdb.AuthFallbackService
 	roomCfgDB      roomdb.RoomConfig
+	presenceDB     roomdb.PresenceService
+
+	codeOfConductDB roomdb.CodeOfConductService
+
+	roomState *roomstate.Manager
 }
 
 const redirectToMembers = "/admin/members"
@@ -114,6 +120,54 @@ func (h membersHandler) changeRole(w http.ResponseWriter, req *http.Request) {
 	http.Redirect(w, req, memberDetailsURL, http.StatusSeeOther)
 }
 
+// setHidden lets members choose if they are listed as attendants of the room. Only the member itself can change it.
+func (h membersHandler) setHidden(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		err := weberrors.ErrBadRequest{Where: "HTTP Method", Details: fmt.Errorf("expected POST not %s", req.Method)}
+		h.r.Error(w, req, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		err = weberrors.ErrBadRequest{Where: "Form data", Details: err}
+		h.r.Error(w, req, http.StatusBadRequest, err)
+		return
+	}
+
+	memberID, err := strconv.ParseInt(req.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "id", Details: err}
+		h.r.Error(w, req, http.StatusBadRequest, err)
+		return
+	}
+
+	currentMember := members.FromContext(req.Context())
+	if currentMember == nil || currentMember.ID != memberID {
+		err := weberrors.ErrForbidden{Details: fmt.Errorf("members can only change their own visibility")}
+		h.r.Error(w, req, http.StatusForbidden, err)
+		return
+	}
+
+	hidden, err := strconv.ParseBool(req.Form.Get("hidden"))
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "hidden", Details: err}
+		h.r.Error(w, req, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.db.SetHidden(req.Context(), memberID, hidden); err != nil {
+		err = weberrors.DatabaseError{Reason: err}
+		h.r.Error(w, req, http.StatusInternalServerError, err)
+		return
+	}
+	h.roomState.SetHidden(currentMember.PubKey, hidden)
+
+	h.flashes.AddMessage(w, req, "AdminMemberUpdated")
+
+	memberDetailsURL := h.urlTo(router.AdminMemberDetails, "id", memberID).String()
+	http.Redirect(w, req, memberDetailsURL, http.StatusSeeOther)
+}
+
 func (h membersHandler) overview(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
 	lst, err := h.db.List(req.Context())
 	if err != nil {
@@ -133,6 +187,16 @@ func (h membersHandler) overview(rw http.ResponseWriter, req *http.Request) (int
 
 	pageData["AllRoles"] = []roomdb.Role{roomdb.RoleMember, roomdb.RoleModerator, roomdb.RoleAdmin}
 
+	// which version of the code of conduct each member accepted
+	pageData["CodeOfConduct"], err = h.codeOfConductDB.Current(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	pageData["CodeOfConductAccepted"], err = h.codeOfConductDB.ListAccepted(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
 	pageData["Flashes"], err = h.flashes.GetAll(rw, req)
 	if err != nil {
 		return nil, err
@@ -161,10 +225,19 @@ func (h membersHandler) details(rw http.ResponseWriter, req *http.Request) (inte
 		aliasURLs[a.Name] = template.URL(h.netInfo.URLForAlias(a.Name))
 	}
 
+	// members that never connected don't have a presence yet
+	presence, err := h.presenceDB.GetByMemberID(req.Context(), member.ID)
+	hasPresence := err == nil
+	if err != nil && !errors.Is(err, roomdb.ErrNotFound) {
+		return nil, err
+	}
+
 	return map[string]interface{}{
 		"Member":         member,
 		"AllRoles":       roles,
 		"AliasURLs":      aliasURLs,
+		"Presence":       presence,
+		"HasPresence":    hasPresence,
 		csrf.TemplateTag: csrf.TemplateField(req),
 	}, nil
 }
@@ -222,10 +295,18 @@ func (h membersHandler) remove(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	entry, err := h.db.GetByID(ctx, id)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
 	err = h.db.RemoveID(ctx, id)
 	if err != nil {
 		h.flashes.AddError(rw, req, err)
 	} else {
+		// the preference goes away with the membership
+		h.roomState.SetHidden(entry.PubKey, false)
 		h.flashes.AddMessage(rw, req, "AdminMemberRemoved")
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/assert"
@@ -442,6 +443,14 @@ func TestMembersRemove(t *testing.T) {
 
 	urlRemove := ts.URLTo(router.AdminMembersRemove)
 
+	// the member is attending the room but hid from the lists
+	testKey, err := refs.ParseFeedRef("@x7iOLUcq3o+sjGeAnipvWeGzfuYgrXl8L4LYlxIhwDc=.ed25519")
+	a.NoError(err)
+	ts.RoomState.AddEndpoint(testKey, nil)
+	ts.RoomState.SetHidden(testKey, true)
+	a.NotContains(ts.RoomState.List(), testKey.String())
+
+	ts.MembersDB.GetByIDReturns(roomdb.Member{ID: 666, PubKey: testKey}, nil)
 	ts.MembersDB.RemoveIDReturns(nil)
 
 	addVals := url.Values{"id": []string{"666"}}
@@ -460,7 +469,12 @@ func TestMembersRemove(t *testing.T) {
 
 	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminMemberRemoved")
 
+	// the hidden preference went away with the membership
+	a.False(ts.RoomState.Hidden(testKey))
+	a.Contains(ts.RoomState.List(), testKey.String(), "removed member should be listed again")
+
 	// now for unknown ID
+	ts.MembersDB.GetByIDReturns(roomdb.Member{}, roomdb.ErrNotFound)
 	ts.MembersDB.RemoveIDReturns(roomdb.ErrNotFound)
 	addVals = url.Values{"id": []string{"667"}}
 	rec = ts.Client.PostForm(urlRemove, addVals)
@@ -535,3 +549,95 @@ func TestMembersCreateResetToken(t *testing.T) {
 	wantResetURL := ts.URLTo(router.MembersChangePassword, "token", testToken)
 	a.Equal(wantResetURL.String(), gotResetURL)
 }
+
+func TestMembersSetHidden(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	feedRef, err := generatePubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts.User = roomdb.Member{
+		ID:     1234,
+		Role:   roomdb.RoleMember,
+		PubKey: feedRef,
+	}
+	ts.MembersDB.GetByIDReturns(ts.User, nil)
+
+	// the toggle is on the own details page
+	doc, resp := ts.Client.GetHTML(ts.URLTo(router.AdminMemberDetails, "id", "1234"))
+	a.Equal(http.StatusOK, resp.Code)
+	form := doc.Find("#set-hidden")
+	a.Equal(1, form.Length(), "form missing from page")
+	action, _ := form.Attr("action")
+	a.Equal(ts.URLTo(router.AdminMembersSetHidden, "id", 1234).String(), action)
+
+	rec := ts.Client.PostForm(ts.URLTo(router.AdminMembersSetHidden, "id", 1234), url.Values{"hidden": []string{"true"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+
+	a.Equal(1, ts.MembersDB.SetHiddenCallCount())
+	_, id, hidden := ts.MembersDB.SetHiddenArgsForCall(0)
+	a.EqualValues(1234, id)
+	a.True(hidden)
+	a.True(ts.RoomState.Hidden(ts.User.PubKey))
+
+	// not for other members
+	rec = ts.Client.PostForm(ts.URLTo(router.AdminMembersSetHidden, "id", 666), url.Values{"hidden": []string{"false"}})
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(1, ts.MembersDB.SetHiddenCallCount())
+	a.True(ts.RoomState.Hidden(ts.User.PubKey))
+}
+
+func TestMemberDetailsPresence(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	feedRef, err := generatePubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.MembersDB.GetByIDReturns(roomdb.Member{ID: 7, Role: roomdb.RoleMember, PubKey: feedRef}, nil)
+
+	ts.User = roomdb.Member{
+		ID:   1234,
+		Role: roomdb.RoleModerator,
+	}
+
+	memberURL := ts.URLTo(router.AdminMemberDetails, "id", "7")
+
+	// never connected
+	html, resp := ts.Client.GetHTML(memberURL)
+	a.Equal(http.StatusOK, resp.Code)
+	webassert.Localized(t, html, []webassert.LocalizedElement{
+		{"#presence p", "AdminMemberDetailsNeverSeen"},
+	})
+	a.Equal(0, html.Find("#session-list").Length())
+
+	start := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+	ts.PresenceDB.GetByMemberIDReturns(roomdb.Presence{
+		FirstSeen: start.Add(-24 * time.Hour),
+		LastSeen:  start.Add(2 * time.Hour),
+		Connected: 3 * time.Hour,
+		Sessions: []roomdb.Session{
+			{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour), Transport: roomdb.TransportWebsocket},
+			{Start: start, End: start.Add(30 * time.Minute), Transport: roomdb.TransportTCP},
+		},
+	}, nil)
+
+	html, resp = ts.Client.GetHTML(memberURL)
+	a.Equal(http.StatusOK, resp.Code)
+
+	a.Equal(2, ts.PresenceDB.GetByMemberIDCallCount())
+	_, id := ts.PresenceDB.GetByMemberIDArgsForCall(1)
+	a.EqualValues(7, id)
+
+	a.Equal("3h0m0s", html.Find("#presence-connected").Text())
+
+	sessions := html.Find("#session-list li")
+	a.Equal(2, sessions.Length())
+	a.Equal(roomdb.TransportWebsocket, sessions.Eq(0).Find(".session-transport").Text())
+	a.Equal(roomdb.TransportTCP, sessions.Eq(1).Find(".session-transport").Text())
+	a.Contains(sessions.Eq(1).Text(), "2021-03-04T10:30:00")
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/csrf"
+	"go.mindeco.de/http/render"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/roomstate"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/members"
+)
+
+type newsHandler struct {
+	r       *render.Renderer
+	flashes *weberrors.FlashHelper
+
+	db       roomdb.NewsService
+	pinnedDB roomdb.PinnedNoticesService
+	roomCfg  roomdb.RoomConfig
+
+	// tells the room.notices subscribers about new posts
+	roomState *roomstate.Manager
+}
+
+const redirectToNews = "/admin/news"
+
+func (h newsHandler) overview(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	ctx := req.Context()
+
+	lst, err := h.db.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// new posts can be written in the languages of the news notice
+	pinned, err := h.pinnedDB.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var languages []string
+	for _, n := range pinned[roomdb.NoticeNews] {
+		languages = append(languages, n.Language)
+	}
+
+	pageData := map[string]interface{}{
+		"Posts":          lst,
+		"Languages":      languages,
+		csrf.TemplateTag: csrf.TemplateField(req),
+	}
+	pageData["Flashes"], err = h.flashes.GetAll(rw, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pageData, nil
+}
+
+// create publishes a new notice and adds it as a post
+func (h newsHandler) create(rw http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(rw, req, redirectToNews, http.StatusSeeOther)
+
+	ctx := req.Context()
+
+	author, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		err = weberrors.ErrBadRequest{Where: "HTTP Method", Details: fmt.Errorf("expected POST not %s", req.Method)}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		err = weberrors.ErrBadRequest{Where: "Form data", Details: err}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	var n roomdb.Notice
+	n.Title = strings.TrimSpace(req.FormValue("title"))
+	if n.Title == "" {
+		err = weberrors.ErrBadRequest{Where: "title", Details: fmt.Errorf("title can't be empty")}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	n.Language = req.FormValue("language")
+	if n.Language == "" {
+		err = weberrors.ErrBadRequest{Where: "language", Details: fmt.Errorf("language can't be empty")}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	// https://github.com/russross/blackfriday/issues/575
+	n.Content = strings.Replace(req.FormValue("content"), "\r\n", "\n", -1)
+	if n.Content == "" {
+		err = weberrors.ErrBadRequest{Where: "content", Details: fmt.Errorf("content can't be empty")}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	post, err := h.db.Create(ctx, &n, author.ID)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	h.roomState.NoticePublished(post)
+
+	h.flashes.AddMessage(rw, req, "AdminNewsCreated")
+}
+
+func (h newsHandler) removeConfirm(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	id, err := strconv.ParseInt(req.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "ID", Details: err}
+		return nil, err
+	}
+
+	post, err := h.db.GetByID(req.Context(), id)
+	if err != nil {
+		return nil, weberrors.ErrRedirect{
+			Path:   redirectToNews,
+			Reason: err,
+		}
+	}
+
+	return map[string]interface{}{
+		"Post":           post,
+		csrf.TemplateTag: csrf.TemplateField(req),
+	}, nil
+}
+
+func (h newsHandler) remove(rw http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(rw, req, redirectToNews, http.StatusSeeOther)
+
+	ctx := req.Context()
+
+	_, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	err = req.ParseForm()
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "Form data", Details: err}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	id, err := strconv.ParseInt(req.FormValue("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "ID", Details: err}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	err = h.db.Remove(ctx, id)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+	} else {
+		h.flashes.AddMessage(rw, req, "AdminNewsRemoved")
+	}
+}
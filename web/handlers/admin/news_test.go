@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/broadcasts"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+	"github.com/ssbc/go-ssb-room/v2/web/webassert"
+)
+
+// publishedPosts records what the room state sends to the room.notices subscribers
+type publishedPosts chan broadcasts.Notice
+
+func (pp publishedPosts) Published(post broadcasts.Notice) error {
+	pp <- post
+	return nil
+}
+
+func (pp publishedPosts) Close() error { return nil }
+
+func TestNewsOverview(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	ts.User = roomdb.Member{ID: 1, Role: roomdb.RoleAdmin}
+
+	ts.PinnedDB.ListReturns(roomdb.PinnedNotices{
+		roomdb.NoticeNews: []roomdb.Notice{
+			{ID: 1, Title: "News", Language: "en", Published: true},
+			{ID: 2, Title: "Neuigkeiten", Language: "de", Published: true},
+		},
+	}, nil)
+	ts.NewsDB.ListReturns([]roomdb.NewsPost{
+		{ID: 2, PublishedAt: time.Now(), Notice: roomdb.Notice{ID: 20, Title: "Maintenance", Language: "en", Published: true}},
+		{ID: 1, PublishedAt: time.Now().Add(-time.Hour), Notice: roomdb.Notice{ID: 10, Title: "Hallo", Language: "de", Published: true}},
+	}, nil)
+
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminNewsOverview))
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	webassert.Localized(t, html, []webassert.LocalizedElement{
+		{"#welcome", "AdminNewsWelcome"},
+		{"title", "AdminNewsTitle"},
+	})
+
+	a.Equal(2, html.Find("#theList tr").Length())
+	a.Equal(2, html.Find("form#create-post select[name='language'] option").Length())
+
+	removeURL := ts.URLTo(router.AdminNewsRemoveConfirm, "id", 2)
+	a.Equal(1, html.Find("a[href='"+removeURL.String()+"']").Length())
+
+	editURL := ts.URLTo(router.AdminNoticeEdit, "id", 20)
+	a.Equal(1, html.Find("a[href='"+editURL.String()+"']").Length())
+}
+
+func TestNewsCreate(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	listURL := ts.URLTo(router.AdminNewsOverview)
+	createURL := ts.URLTo(router.AdminNewsCreate)
+
+	published := make(publishedPosts, 1)
+	ts.RoomState.RegisterNoticesUpdates(published)
+
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeRestricted, nil)
+
+	vals := url.Values{
+		"title":    []string{"Maintenance"},
+		"content":  []string{"down on sunday"},
+		"language": []string{"en"},
+	}
+
+	// members can't post in restricted rooms
+	ts.User = roomdb.Member{ID: 7331, Role: roomdb.RoleMember}
+	rec := ts.Client.PostForm(createURL, vals)
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(listURL.Path, rec.Header().Get("Location"))
+	a.Equal(0, ts.NewsDB.CreateCallCount())
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorNotAuthorized")
+
+	ts.User = roomdb.Member{ID: 9001, Role: roomdb.RoleModerator}
+
+	// the content is required
+	vals.Set("content", "")
+	rec = ts.Client.PostForm(createURL, vals)
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(0, ts.NewsDB.CreateCallCount())
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorBadRequest")
+
+	post := roomdb.NewsPost{ID: 3, PublishedAt: time.Now(), Notice: roomdb.Notice{ID: 42, Title: "Maintenance", Language: "en", Published: true}}
+	ts.NewsDB.CreateReturns(post, nil)
+
+	vals.Set("content", "down on sunday")
+	rec = ts.Client.PostForm(createURL, vals)
+	a.Equal(http.StatusSeeOther, rec.Code)
+
+	// the notice and the post are stored together
+	r.Equal(1, ts.NewsDB.CreateCallCount())
+	a.Equal(0, ts.NoticeDB.SaveCallCount())
+	_, notice, author := ts.NewsDB.CreateArgsForCall(0)
+	a.Equal("Maintenance", notice.Title)
+	a.Equal("en", notice.Language)
+	a.EqualValues(9001, author)
+
+	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminNewsCreated")
+
+	select {
+	case got := <-published:
+		a.EqualValues(3, got.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the post was not sent to the room.notices subscribers")
+	}
+}
+
+func TestNewsRemove(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	ts.User = roomdb.Member{ID: 1, Role: roomdb.RoleAdmin}
+	listURL := ts.URLTo(router.AdminNewsOverview)
+
+	ts.NewsDB.GetByIDReturns(roomdb.NewsPost{ID: 2, Notice: roomdb.Notice{ID: 20, Title: "Maintenance", Language: "en"}}, nil)
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminNewsRemoveConfirm, "id", 2))
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal("Maintenance (en)", html.Find("#verify").Text())
+
+	action, has := html.Find("form#confirm").Attr("action")
+	a.True(has)
+	a.Equal(ts.URLTo(router.AdminNewsRemove).String(), action)
+
+	rec := ts.Client.PostForm(ts.URLTo(router.AdminNewsRemove), url.Values{"id": []string{"2"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.NewsDB.RemoveCallCount())
+	_, id := ts.NewsDB.RemoveArgsForCall(0)
+	a.EqualValues(2, id)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminNewsRemoved")
+}
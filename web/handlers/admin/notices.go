@@ -5,8 +5,8 @@
 package admin
 
 import (
+	"context"
 	"fmt"
-	"html/template"
 	"net/http"
 	"strconv"
 	"strings"
@@ -16,7 +16,6 @@ import (
 	"github.com/ssbc/go-ssb-room/v2/web/router"
 
 	"github.com/gorilla/csrf"
-	"github.com/russross/blackfriday/v2"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
 	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
 	"go.mindeco.de/http/render"
@@ -27,9 +26,13 @@ type noticeHandler struct {
 	urlTo   web.URLMaker
 	flashes *weberrors.FlashHelper
 
-	noticeDB roomdb.NoticesService
-	pinnedDB roomdb.PinnedNoticesService
-	roomCfg  roomdb.RoomConfig
+	noticeDB  roomdb.NoticesService
+	pinnedDB  roomdb.PinnedNoticesService
+	pagesDB   roomdb.PagesService
+	roomCfg   roomdb.RoomConfig
+	membersDB roomdb.MembersService
+
+	codeOfConductDB roomdb.CodeOfConductService
 }
 
 func (h noticeHandler) draftTranslation(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -40,16 +43,33 @@ func (h noticeHandler) draftTranslation(rw http.ResponseWriter, req *http.Reques
 		return nil, err
 	}
 
+	pageData := map[string]interface{}{
+		"SubmitAction":   router.AdminNoticeAddTranslation,
+		csrf.TemplateTag: csrf.TemplateField(req),
+	}
+
+	// translations are either for one of the pinned notices or for an added page
+	if pageID := req.URL.Query().Get("page"); pageID != "" {
+		id, err := strconv.ParseInt(pageID, 10, 64)
+		if err != nil {
+			return nil, weberrors.ErrBadRequest{Where: "page", Details: err}
+		}
+		page, err := h.pagesDB.GetByID(req.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		pageData["Page"] = page
+		return pageData, nil
+	}
+
 	pinnedName := req.URL.Query().Get("name")
 	if !roomdb.PinnedNoticeName(pinnedName).Valid() {
 		return nil, weberrors.ErrBadRequest{Where: "pinnedName", Details: fmt.Errorf("invalid pinned notice name")}
 	}
+	pageData["PinnedName"] = pinnedName
+	pageData["IsCodeOfConduct"] = roomdb.PinnedNoticeName(pinnedName) == roomdb.NoticeCodeOfConduct
 
-	return map[string]interface{}{
-		"SubmitAction":   router.AdminNoticeAddTranslation,
-		"PinnedName":     pinnedName,
-		csrf.TemplateTag: csrf.TemplateField(req),
-	}, nil
+	return pageData, nil
 }
 
 func (h noticeHandler) addTranslation(rw http.ResponseWriter, req *http.Request) {
@@ -77,13 +97,22 @@ func (h noticeHandler) addTranslation(rw http.ResponseWriter, req *http.Request)
 
 	defer http.Redirect(rw, req, redirect, http.StatusSeeOther)
 
-	if _, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice); err != nil {
+	author, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice)
+	if err != nil {
 		h.flashes.AddError(rw, req, err)
 		return
 	}
 
+	var pageID int64
 	pinnedName := roomdb.PinnedNoticeName(req.FormValue("name"))
-	if !pinnedName.Valid() {
+	if page := req.FormValue("page"); page != "" {
+		pageID, err = strconv.ParseInt(page, 10, 64)
+		if err != nil {
+			err = weberrors.ErrBadRequest{Where: "page", Details: err}
+			h.flashes.AddError(rw, req, err)
+			return
+		}
+	} else if !pinnedName.Valid() {
 		err := weberrors.ErrBadRequest{Where: "name", Details: fmt.Errorf("invalid pinned notice name")}
 		h.flashes.AddError(rw, req, err)
 		return
@@ -114,19 +143,37 @@ func (h noticeHandler) addTranslation(rw http.ResponseWriter, req *http.Request)
 	// https://github.com/russross/blackfriday/issues/575
 	n.Content = strings.Replace(n.Content, "\r\n", "\n", -1)
 
-	err = h.noticeDB.Save(ctx, &n)
+	flash := "NoticeUpdated"
+	if req.FormValue("action") == "draft" {
+		_, err = h.noticeDB.SaveDraft(ctx, &n, author.ID)
+		flash = "NoticeDraftSaved"
+	} else {
+		err = h.noticeDB.Save(ctx, &n, author.ID)
+	}
 	if err != nil {
 		h.flashes.AddError(rw, req, err)
 		return
 	}
 
-	err = h.pinnedDB.Set(ctx, pinnedName, n.ID)
+	if pageID != 0 {
+		err = h.pagesDB.AddTranslation(ctx, pageID, n.ID)
+	} else {
+		err = h.pinnedDB.Set(ctx, pinnedName, n.ID)
+	}
 	if err != nil {
 		h.flashes.AddError(rw, req, err)
 		return
 	}
 
-	h.flashes.AddMessage(rw, req, "NoticeUpdated")
+	if pageID == 0 && pinnedName == roomdb.NoticeCodeOfConduct && flash == "NoticeUpdated" && wantsNewCodeOfConduct(req) {
+		if _, err = h.codeOfConductDB.Publish(ctx); err != nil {
+			h.flashes.AddError(rw, req, err)
+			return
+		}
+		flash = "NoticeCodeOfConductPublished"
+	}
+
+	h.flashes.AddMessage(rw, req, flash)
 
 }
 
@@ -146,22 +193,38 @@ func (h noticeHandler) edit(rw http.ResponseWriter, req *http.Request) (interfac
 		return nil, err
 	}
 
-	n, err := h.noticeDB.GetByID(req.Context(), id)
+	n, err := h.noticeDB.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// https://github.com/russross/blackfriday/issues/575
-	fixedContent := strings.Replace(n.Content, "\r\n", "\n", -1)
+	revisions, err := h.noticeDB.ListRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	contentBytes := []byte(fixedContent)
-	preview := blackfriday.Run(contentBytes)
+	isCodeOfConduct, err := h.isCodeOfConduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// continue where the last draft left off
+	var draft *roomdb.NoticeRevision
+	if len(revisions) > 0 && revisions[0].Draft {
+		draft = &revisions[0]
+		n.Title = draft.Title
+		n.Content = draft.Content
+	}
 
 	pageData := map[string]interface{}{
 		"SubmitAction":   router.AdminNoticeSave,
 		"Notice":         n,
-		"ContentPreview": template.HTML(preview),
+		"Draft":          draft,
+		"Revisions":      h.withAuthors(ctx, revisions),
+		"ContentPreview": web.RenderMarkdown(n.Content),
 		csrf.TemplateTag: csrf.TemplateField(req),
+
+		"IsCodeOfConduct": isCodeOfConduct,
 	}
 	pageData["Flashes"], err = h.flashes.GetAll(rw, req)
 	if err != nil {
@@ -187,8 +250,13 @@ func (h noticeHandler) save(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// drafts are not public, so keep editing them
+	saveDraft := req.FormValue("action") == "draft"
+
 	redirect := req.FormValue("redirect")
-	if redirect == "" {
+	if saveDraft {
+		redirect = h.urlTo(router.AdminNoticeEdit, "id", req.FormValue("id")).String()
+	} else if redirect == "" {
 		noticesURL := h.urlTo(router.CompleteNoticeList)
 		redirect = noticesURL.String()
 	}
@@ -196,7 +264,8 @@ func (h noticeHandler) save(rw http.ResponseWriter, req *http.Request) {
 	// now, always redirect
 	defer http.Redirect(rw, req, redirect, http.StatusSeeOther)
 
-	if _, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice); err != nil {
+	author, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice)
+	if err != nil {
 		h.flashes.AddError(rw, req, err)
 		return
 	}
@@ -234,11 +303,272 @@ func (h noticeHandler) save(rw http.ResponseWriter, req *http.Request) {
 	// https://github.com/russross/blackfriday/issues/575
 	n.Content = strings.Replace(n.Content, "\r\n", "\n", -1)
 
-	err = h.noticeDB.Save(req.Context(), &n)
+	if saveDraft {
+		_, err = h.noticeDB.SaveDraft(ctx, &n, author.ID)
+		if err != nil {
+			h.flashes.AddError(rw, req, err)
+			return
+		}
+		h.flashes.AddMessage(rw, req, "NoticeDraftSaved")
+		return
+	}
+
+	err = h.noticeDB.Save(ctx, &n, author.ID)
 	if err != nil {
 		h.flashes.AddError(rw, req, err)
 		return
 	}
 
+	if wantsNewCodeOfConduct(req) {
+		isCodeOfConduct, err := h.isCodeOfConduct(ctx, n.ID)
+		if err != nil {
+			h.flashes.AddError(rw, req, err)
+			return
+		}
+		if isCodeOfConduct {
+			if _, err = h.codeOfConductDB.Publish(ctx); err != nil {
+				h.flashes.AddError(rw, req, err)
+				return
+			}
+			h.flashes.AddMessage(rw, req, "NoticeCodeOfConductPublished")
+			return
+		}
+	}
+
 	h.flashes.AddMessage(rw, req, "NoticeUpdated")
 }
+
+// isCodeOfConduct returns true if the notice is one of the translations of the code of conduct
+func (h noticeHandler) isCodeOfConduct(ctx context.Context, noticeID int64) (bool, error) {
+	pinned, err := h.pinnedDB.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, n := range pinned[roomdb.NoticeCodeOfConduct] {
+		if n.ID == noticeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// wantsNewCodeOfConduct is true if the editor asked the members to accept the changed code of conduct again.
+// Small fixes don't need that, so it is not done for every change.
+func wantsNewCodeOfConduct(req *http.Request) bool {
+	return req.FormValue("code-of-conduct") == "new-version"
+}
+
+func (h noticeHandler) revision(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	ctx := req.Context()
+
+	if _, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice); err != nil {
+		h.flashes.AddError(rw, req, err)
+		noticesURL := h.urlTo(router.CompleteNoticeList)
+		http.Redirect(rw, req, noticesURL.String(), http.StatusSeeOther)
+		return nil, err
+	}
+
+	id, err := strconv.ParseInt(req.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "ID", Details: err}
+		return nil, err
+	}
+
+	rev, err := h.noticeDB.GetRevision(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the changes are shown against what is published right now
+	current, err := h.noticeDB.GetByID(ctx, rev.NoticeID)
+	if err != nil {
+		return nil, err
+	}
+
+	revs := h.withAuthors(ctx, []roomdb.NoticeRevision{rev})
+	diff, replaced := diffLines(current.Content, rev.Content)
+
+	return map[string]interface{}{
+		"Revision":       revs[0],
+		"Notice":         current,
+		"ContentPreview": web.RenderMarkdown(rev.Content),
+		"TitleChanged":   current.Title != rev.Title,
+		"Diff":           diff,
+		"DiffReplaced":   replaced,
+		csrf.TemplateTag: csrf.TemplateField(req),
+	}, nil
+}
+
+// revert publishes the content of an older revision or a draft again
+func (h noticeHandler) revert(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	if req.Method != "POST" {
+		err := weberrors.ErrBadRequest{Where: "http method type", Details: fmt.Errorf("revert only accepts POST requests, sorry!")}
+		h.r.Error(rw, req, http.StatusMethodNotAllowed, err)
+		return
+	}
+
+	err := req.ParseForm()
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "form data", Details: err}
+		h.r.Error(rw, req, http.StatusInternalServerError, err)
+		return
+	}
+
+	// now, always redirect. Once the revision is known, back to its notice
+	redirect := h.urlTo(router.CompleteNoticeList).String()
+	defer func() {
+		http.Redirect(rw, req, redirect, http.StatusSeeOther)
+	}()
+
+	author, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	id, err := strconv.ParseInt(req.FormValue("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "id", Details: err}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	rev, err := h.noticeDB.GetRevision(ctx, id)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+	redirect = h.urlTo(router.AdminNoticeEdit, "id", rev.NoticeID).String()
+
+	err = h.noticeDB.Revert(ctx, rev.ID, author.ID)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(rw, req, "NoticeReverted")
+}
+
+// noticeRevisionWithAuthor is a revision together with who made it.
+// AuthorMember is nil if the member is unknown or was removed in the meantime.
+type noticeRevisionWithAuthor struct {
+	roomdb.NoticeRevision
+
+	AuthorMember *roomdb.Member
+}
+
+func (h noticeHandler) withAuthors(ctx context.Context, revs []roomdb.NoticeRevision) []noticeRevisionWithAuthor {
+	authors := make(map[int64]*roomdb.Member)
+
+	entries := make([]noticeRevisionWithAuthor, len(revs))
+	for i, rev := range revs {
+		entries[i].NoticeRevision = rev
+
+		if rev.Author == 0 {
+			continue
+		}
+
+		m, has := authors[rev.Author]
+		if !has {
+			if member, err := h.membersDB.GetByID(ctx, rev.Author); err == nil {
+				m = &member
+			}
+			authors[rev.Author] = m
+		}
+		entries[i].AuthorMember = m
+	}
+
+	return entries
+}
+
+// diffLine is one line of the output of diffLines
+type diffLine struct {
+	Text           string
+	Added, Removed bool
+}
+
+// maxDiffLines is how many changed lines diffLines compares on each side.
+// The comparison needs memory for every pair of lines, beyond this the content is shown as replaced.
+const maxDiffLines = 1000
+
+// diffLines compares two texts line by line, using their longest common subsequence.
+// replaced is true if too many lines changed to compare them and all of them are shown as removed and added.
+func diffLines(oldText, newText string) (lines []diffLine, replaced bool) {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	// the lines before and after the changes don't need to be compared
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		lines = append(lines, diffLine{Text: a[0]})
+		a, b = a[1:], b[1:]
+	}
+	same := 0
+	for same < len(a) && same < len(b) && a[len(a)-1-same] == b[len(b)-1-same] {
+		same++
+	}
+	tail := a[len(a)-same:]
+	a, b = a[:len(a)-same], b[:len(b)-same]
+
+	if len(a) > maxDiffLines || len(b) > maxDiffLines {
+		replaced = true
+		for _, text := range a {
+			lines = append(lines, diffLine{Text: text, Removed: true})
+		}
+		for _, text := range b {
+			lines = append(lines, diffLine{Text: text, Added: true})
+		}
+	} else {
+		lines = append(lines, lcsDiff(a, b)...)
+	}
+
+	for _, text := range tail {
+		lines = append(lines, diffLine{Text: text})
+	}
+	return lines, replaced
+}
+
+// lcsDiff does the comparison for diffLines, it needs (len(a)+1)*(len(b)+1) ints
+func lcsDiff(a, b []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{Text: a[i], Removed: true})
+			i++
+		default:
+			lines = append(lines, diffLine{Text: b[j], Added: true})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{Text: a[i], Removed: true})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{Text: b[j], Added: true})
+	}
+	return lines
+}
@@ -8,12 +8,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
 	"github.com/ssbc/go-ssb-room/v2/web/router"
 	"github.com/ssbc/go-ssb-room/v2/web/webassert"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Verifies that the notice.go save handler is like, actually, called.
@@ -135,6 +138,44 @@ func TestNoticeDraftLanguageIncludesAllFields(t *testing.T) {
 	})
 }
 
+// Verifies that translations can be added to the pages that admins created, too
+func TestNoticeAddPageTranslation(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	ts.User = roomdb.Member{ID: 1, Role: roomdb.RoleAdmin}
+	ts.PagesDB.GetByIDReturns(roomdb.Page{ID: 3, Name: "FAQ", Slug: "faq"}, nil)
+
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminNoticeDraftTranslation, "page", 3))
+	a.Equal(http.StatusOK, resp.Code, "Wrong HTTP status code")
+	webassert.ElementsInForm(t, html.Find("form"), []webassert.FormElement{
+		{Name: "page", Type: "hidden", Value: "3"},
+		{Name: "title"},
+		{Name: "language"},
+		{Tag: "textarea", Name: "content"},
+	})
+
+	ts.PagesDB.GetByIDReturns(roomdb.Page{}, roomdb.ErrNotFound)
+	_, resp = ts.Client.GetHTML(ts.URLTo(router.AdminNoticeDraftTranslation, "page", 4))
+	a.Equal(http.StatusNotFound, resp.Code)
+
+	formValues := url.Values{
+		"page":     []string{"3"},
+		"title":    []string{"FAQ"},
+		"content":  []string{"ask away"},
+		"language": []string{"en"},
+	}
+	resp = ts.Client.PostForm(ts.URLTo(router.AdminNoticeAddTranslation), formValues)
+	a.Equal(http.StatusSeeOther, resp.Code)
+
+	r.Equal(1, ts.NoticeDB.SaveCallCount())
+	r.Equal(1, ts.PagesDB.AddTranslationCallCount())
+	a.Equal(0, ts.PinnedDB.SetCallCount(), "should not touch the pinned notices")
+	_, pageID, _ := ts.PagesDB.AddTranslationArgsForCall(0)
+	a.EqualValues(3, pageID)
+}
+
 func TestNoticeEditFormIncludesAllFields(t *testing.T) {
 	ts := newSession(t)
 	a := assert.New(t)
@@ -383,3 +424,172 @@ func TestNoticesRoleRightsAddingTranslation(t *testing.T) {
 		})
 	}
 }
+
+func TestNoticeSaveDraft(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	ts.User = roomdb.Member{ID: 1234, Role: roomdb.RoleModerator}
+
+	formValues := url.Values{
+		"id":       []string{"1"},
+		"title":    []string{"News"},
+		"content":  []string{"not yet"},
+		"language": []string{"en-GB"},
+		"action":   []string{"draft"},
+	}
+	resp := ts.Client.PostForm(ts.URLTo(router.AdminNoticeSave), formValues)
+	a.Equal(http.StatusSeeOther, resp.Code)
+
+	// stays on the edit page
+	a.Equal(ts.URLTo(router.AdminNoticeEdit, "id", 1).String(), resp.Header().Get("Location"))
+	a.Equal(0, ts.NoticeDB.SaveCallCount(), "drafts are not published")
+	if a.Equal(1, ts.NoticeDB.SaveDraftCallCount()) {
+		_, n, author := ts.NoticeDB.SaveDraftArgsForCall(0)
+		a.EqualValues(1, n.ID)
+		a.Equal("not yet", n.Content)
+		a.EqualValues(1234, author)
+	}
+	webassert.HasFlashMessages(t, ts.Client, ts.URLTo(router.AdminDashboard), "NoticeDraftSaved")
+
+	// publishing records the author, too
+	formValues.Set("action", "publish")
+	resp = ts.Client.PostForm(ts.URLTo(router.AdminNoticeSave), formValues)
+	a.Equal(http.StatusSeeOther, resp.Code)
+	if a.Equal(1, ts.NoticeDB.SaveCallCount()) {
+		_, _, author := ts.NoticeDB.SaveArgsForCall(0)
+		a.EqualValues(1234, author)
+	}
+}
+
+func TestNoticeEditShowsDraftAndRevisions(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	ts.User = roomdb.Member{ID: 1234, Role: roomdb.RoleAdmin}
+
+	ts.NoticeDB.GetByIDReturns(roomdb.Notice{
+		ID:        1,
+		Title:     "News",
+		Content:   "published content",
+		Language:  "en-GB",
+		Published: true,
+	}, nil)
+
+	ts.NoticeDB.ListRevisionsReturns([]roomdb.NoticeRevision{
+		{ID: 3, NoticeID: 1, Title: "News", Content: "**draft** content <script>alert(1)</script>", Author: 1234, Draft: true, CreatedAt: time.Now()},
+		{ID: 2, NoticeID: 1, Title: "News", Content: "published content", Author: 1234, CreatedAt: time.Now().Add(-time.Hour)},
+		{ID: 1, NoticeID: 1, Title: "News", Content: "first", CreatedAt: time.Now().Add(-2 * time.Hour)},
+	}, nil)
+	ts.MembersDB.GetByIDReturns(ts.User, nil)
+
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminNoticeEdit, "id", 1))
+	a.Equal(http.StatusOK, resp.Code)
+
+	// the form continues with the draft
+	a.Equal(1, html.Find("#notice-draft").Length())
+	a.Contains(html.Find("textarea[name=content]").Text(), "**draft** content")
+	a.Equal(1, html.Find(`button[name=action][value=draft]`).Length())
+
+	// the preview is sanitized
+	a.Equal("draft", html.Find("#notice-preview strong").Text())
+	a.Equal(0, html.Find("#notice-preview script").Length())
+
+	rows := html.Find("#notice-revisions tr")
+	a.Equal(3, rows.Length())
+	a.Equal(1, ts.MembersDB.GetByIDCallCount(), "authors should only be looked up once")
+	a.Contains(rows.Last().Text(), "unknown")
+
+	link, has := rows.First().Find("a[href*=revision]").Attr("href")
+	a.True(has)
+	a.Equal(ts.URLTo(router.AdminNoticeRevision, "id", 3).String(), link)
+}
+
+func TestNoticeRevisionAndRevert(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	ts.User = roomdb.Member{ID: 1234, Role: roomdb.RoleAdmin}
+
+	ts.NoticeDB.GetByIDReturns(roomdb.Notice{
+		ID:        1,
+		Title:     "News",
+		Content:   "line one\nline two",
+		Language:  "en-GB",
+		Published: true,
+	}, nil)
+	ts.NoticeDB.GetRevisionReturns(roomdb.NoticeRevision{
+		ID:        2,
+		NoticeID:  1,
+		Title:     "News",
+		Content:   "line one\nline 2",
+		CreatedAt: time.Now(),
+	}, nil)
+
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminNoticeRevision, "id", 2))
+	a.Equal(http.StatusOK, resp.Code)
+
+	diff := html.Find("#revision-diff")
+	a.Equal("- line two", diff.Find(".text-red-600").Text())
+	a.Equal("+ line 2", diff.Find(".text-green-800").Text())
+	a.Equal(0, html.Find("#revision-title-diff").Length())
+
+	form := html.Find("form#revert")
+	action, _ := form.Attr("action")
+	a.Equal(ts.URLTo(router.AdminNoticeRevert).String(), action)
+	webassert.ElementsInForm(t, form, []webassert.FormElement{
+		{Name: "id", Value: "2", Type: "hidden"},
+	})
+
+	resp = ts.Client.PostForm(ts.URLTo(router.AdminNoticeRevert), url.Values{"id": []string{"2"}})
+	a.Equal(http.StatusSeeOther, resp.Code)
+	a.Equal(ts.URLTo(router.AdminNoticeEdit, "id", 1).String(), resp.Header().Get("Location"))
+	if a.Equal(1, ts.NoticeDB.RevertCallCount()) {
+		_, revID, author := ts.NoticeDB.RevertArgsForCall(0)
+		a.EqualValues(2, revID)
+		a.EqualValues(1234, author)
+	}
+	webassert.HasFlashMessages(t, ts.Client, ts.URLTo(router.AdminDashboard), "NoticeReverted")
+
+	// members can't revert in restricted rooms
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeRestricted, nil)
+	ts.User = roomdb.Member{ID: 7331, Role: roomdb.RoleMember}
+	resp = ts.Client.PostForm(ts.URLTo(router.AdminNoticeRevert), url.Values{"id": []string{"2"}})
+	a.Equal(http.StatusSeeOther, resp.Code)
+	a.Equal(1, ts.NoticeDB.RevertCallCount())
+	webassert.HasFlashMessages(t, ts.Client, ts.URLTo(router.AdminDashboard), "ErrorNotAuthorized")
+}
+
+func TestNoticeDiffLines(t *testing.T) {
+	a := assert.New(t)
+
+	got, replaced := diffLines("a\nb\nc", "a\nc\nd")
+	a.False(replaced)
+	a.Equal([]diffLine{
+		{Text: "a"},
+		{Text: "b", Removed: true},
+		{Text: "c"},
+		{Text: "d", Added: true},
+	}, got)
+
+	got, replaced = diffLines("same", "same")
+	a.False(replaced)
+	a.Equal([]diffLine{{Text: "same"}}, got)
+
+	// too many changed lines are not compared
+	var oldLines, newLines []string
+	for i := 0; i <= maxDiffLines; i++ {
+		oldLines = append(oldLines, fmt.Sprintf("old %d", i))
+		newLines = append(newLines, fmt.Sprintf("new %d", i))
+	}
+	oldText := "title\n" + strings.Join(oldLines, "\n") + "\nfooter"
+	newText := "title\n" + strings.Join(newLines, "\n") + "\nfooter"
+	got, replaced = diffLines(oldText, newText)
+	a.True(replaced)
+	if a.Len(got, 2*(maxDiffLines+1)+2) {
+		a.Equal(diffLine{Text: "title"}, got[0])
+		a.Equal(diffLine{Text: "old 0", Removed: true}, got[1])
+		a.Equal(diffLine{Text: "new 0", Added: true}, got[maxDiffLines+2])
+		a.Equal(diffLine{Text: "footer"}, got[len(got)-1])
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/members"
+)
+
+// openInvitesHandler sets how hard it is to get an invite in open mode
+type openInvitesHandler struct {
+	flashes *weberrors.FlashHelper
+
+	// where to go after every change
+	redirect string
+
+	db roomdb.RoomConfig
+}
+
+func (h openInvitesHandler) setLimits(w http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(w, req, h.redirect, http.StatusSeeOther)
+
+	currentMember := members.FromContext(req.Context())
+	if currentMember == nil || currentMember.Role != roomdb.RoleAdmin {
+		h.flashes.AddError(w, req, weberrors.ErrNotAuthorized)
+		return
+	}
+
+	if req.Method != "POST" {
+		err := weberrors.ErrBadRequest{Where: "HTTP Method", Details: fmt.Errorf("expected POST not %s", req.Method)}
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		err = weberrors.ErrBadRequest{Where: "Form data", Details: err}
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	difficulty, err := strconv.ParseUint(strings.TrimSpace(req.Form.Get("difficulty")), 10, 8)
+	if err != nil {
+		h.flashes.AddError(w, req, weberrors.ErrBadRequest{Where: "Difficulty", Details: err})
+		return
+	}
+
+	dailyLimit, err := strconv.ParseUint(strings.TrimSpace(req.Form.Get("daily_limit")), 10, 32)
+	if err != nil {
+		h.flashes.AddError(w, req, weberrors.ErrBadRequest{Where: "Daily limit", Details: err})
+		return
+	}
+
+	limits := roomdb.OpenInviteLimits{
+		Difficulty:      uint(difficulty),
+		DailyPerAddress: uint(dailyLimit),
+	}
+	if err := limits.Validate(); err != nil {
+		h.flashes.AddError(w, req, weberrors.ErrBadRequest{Where: "Difficulty", Details: err})
+		return
+	}
+
+	if err := h.db.SetOpenInviteLimits(req.Context(), limits); err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(w, req, "AdminOpenInvitesUpdated")
+}
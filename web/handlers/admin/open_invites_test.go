@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+	"github.com/ssbc/go-ssb-room/v2/web/webassert"
+)
+
+func TestOpenInviteLimitsSet(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	ts.User = roomdb.Member{ID: 1234, Role: roomdb.RoleAdmin}
+	ts.ConfigDB.GetOpenInviteLimitsReturns(roomdb.OpenInviteLimits{Difficulty: 16, DailyPerAddress: 10}, nil)
+
+	settingsURL := ts.URLTo(router.AdminSettings)
+	setURL := ts.URLTo(router.AdminSettingsSetOpenInvites)
+
+	html, resp := ts.Client.GetHTML(settingsURL)
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	formSelection := html.Find("form#set-open-invites")
+	a.Equal(1, formSelection.Length())
+	webassert.ElementsInForm(t, formSelection, []webassert.FormElement{
+		{Name: "difficulty", Type: "number", Value: "16"},
+		{Name: "daily_limit", Type: "number", Value: "10"},
+	})
+
+	// too hard to solve
+	rec := ts.Client.PostForm(setURL, url.Values{"difficulty": []string{"64"}, "daily_limit": []string{"10"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(settingsURL.Path, rec.Header().Get("Location"))
+	r.Equal(0, ts.ConfigDB.SetOpenInviteLimitsCallCount())
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "ErrorBadRequest")
+
+	// not a number
+	rec = ts.Client.PostForm(setURL, url.Values{"difficulty": []string{"20"}, "daily_limit": []string{"many"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(0, ts.ConfigDB.SetOpenInviteLimitsCallCount())
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "ErrorBadRequest")
+
+	rec = ts.Client.PostForm(setURL, url.Values{"difficulty": []string{" 20 "}, "daily_limit": []string{"0"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.ConfigDB.SetOpenInviteLimitsCallCount())
+	_, l := ts.ConfigDB.SetOpenInviteLimitsArgsForCall(0)
+	a.Equal(roomdb.OpenInviteLimits{Difficulty: 20, DailyPerAddress: 0}, l)
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "AdminOpenInvitesUpdated")
+
+	// only admins can change them
+	ts.User = roomdb.Member{ID: 7331, Role: roomdb.RoleModerator}
+	rec = ts.Client.PostForm(setURL, url.Values{"difficulty": []string{"0"}, "daily_limit": []string{"0"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.ConfigDB.SetOpenInviteLimitsCallCount())
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "ErrorNotAuthorized")
+
+	html, _ = ts.Client.GetHTML(settingsURL)
+	a.Equal(0, html.Find("form#set-open-invites").Length(), "moderators should not see the form")
+	a.Equal(1, html.Find("#open-invites-values").Length())
+}
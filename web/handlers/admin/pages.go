@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/csrf"
+	"go.mindeco.de/http/render"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/members"
+)
+
+type pagesHandler struct {
+	r       *render.Renderer
+	flashes *weberrors.FlashHelper
+
+	db      roomdb.PagesService
+	roomCfg roomdb.RoomConfig
+}
+
+const redirectToPages = "/admin/pages"
+
+func (h pagesHandler) overview(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	lst, err := h.db.List(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	pageData := map[string]interface{}{
+		"Pages":          lst,
+		csrf.TemplateTag: csrf.TemplateField(req),
+	}
+	pageData["Flashes"], err = h.flashes.GetAll(rw, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pageData, nil
+}
+
+func (h pagesHandler) create(rw http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(rw, req, redirectToPages, http.StatusSeeOther)
+
+	page, err := h.pageFromForm(req)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	_, err = h.db.Create(req.Context(), page)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(rw, req, "AdminPagesCreated")
+}
+
+func (h pagesHandler) update(rw http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(rw, req, redirectToPages, http.StatusSeeOther)
+
+	page, err := h.pageFromForm(req)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	page.ID, err = strconv.ParseInt(req.FormValue("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "ID", Details: err}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	err = h.db.Update(req.Context(), page)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(rw, req, "AdminPagesUpdated")
+}
+
+// pageFromForm checks the permission and reads the fields that create and update share
+func (h pagesHandler) pageFromForm(req *http.Request) (roomdb.Page, error) {
+	var page roomdb.Page
+
+	if _, err := members.CheckAllowed(req.Context(), h.roomCfg, members.ActionChangeNotice); err != nil {
+		return page, err
+	}
+
+	if req.Method != "POST" {
+		return page, weberrors.ErrBadRequest{Where: "HTTP Method", Details: fmt.Errorf("expected POST not %s", req.Method)}
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return page, weberrors.ErrBadRequest{Where: "Form data", Details: err}
+	}
+
+	page.Name = strings.TrimSpace(req.FormValue("name"))
+	page.Slug = strings.TrimSpace(req.FormValue("slug"))
+
+	if pos := req.FormValue("menu_position"); pos != "" {
+		var err error
+		page.MenuPosition, err = strconv.ParseInt(pos, 10, 64)
+		if err != nil {
+			return page, weberrors.ErrBadRequest{Where: "menu position", Details: err}
+		}
+	}
+
+	if err := page.Validate(); err != nil {
+		return page, weberrors.ErrBadRequest{Where: "page", Details: err}
+	}
+
+	return page, nil
+}
+
+func (h pagesHandler) removeConfirm(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	id, err := strconv.ParseInt(req.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "ID", Details: err}
+		return nil, err
+	}
+
+	page, err := h.db.GetByID(req.Context(), id)
+	if err != nil {
+		return nil, weberrors.ErrRedirect{
+			Path:   redirectToPages,
+			Reason: err,
+		}
+	}
+
+	return map[string]interface{}{
+		"Page":           page,
+		csrf.TemplateTag: csrf.TemplateField(req),
+	}, nil
+}
+
+func (h pagesHandler) remove(rw http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(rw, req, redirectToPages, http.StatusSeeOther)
+
+	ctx := req.Context()
+
+	_, err := members.CheckAllowed(ctx, h.roomCfg, members.ActionChangeNotice)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	err = req.ParseForm()
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "Form data", Details: err}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	id, err := strconv.ParseInt(req.FormValue("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "ID", Details: err}
+		h.flashes.AddError(rw, req, err)
+		return
+	}
+
+	err = h.db.Remove(ctx, id)
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+	} else {
+		h.flashes.AddMessage(rw, req, "AdminPagesRemoved")
+	}
+}
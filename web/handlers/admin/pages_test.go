@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+	"github.com/ssbc/go-ssb-room/v2/web/webassert"
+)
+
+func TestPagesOverview(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	ts.User = roomdb.Member{ID: 1, Role: roomdb.RoleAdmin}
+
+	ts.PagesDB.ListReturns([]roomdb.Page{
+		{ID: 1, Name: "FAQ", Slug: "faq", Translations: []roomdb.Notice{
+			{ID: 10, Language: "en", Published: true},
+			{ID: 11, Language: "de"},
+		}},
+		{ID: 2, Name: "Onboarding", Slug: "onboarding", MenuPosition: 1},
+	}, nil)
+
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminPagesOverview))
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	webassert.Localized(t, html, []webassert.LocalizedElement{
+		{"#welcome", "AdminPagesWelcome"},
+		{"title", "AdminPagesTitle"},
+	})
+
+	a.Equal(2, html.Find("form[id^='update-page-']").Length())
+
+	slug, has := html.Find("input[form='update-page-1'][name='slug']").Attr("value")
+	a.True(has)
+	a.Equal("faq", slug)
+
+	translations := html.Find("a[href^='/page/faq?lang=']")
+	a.Equal(2, translations.Length())
+
+	addTranslation := ts.URLTo(router.AdminNoticeDraftTranslation, "page", 2)
+	a.Equal(1, html.Find("a[href='"+addTranslation.String()+"']").Length())
+
+	a.Equal(1, html.Find("form#create-page").Length())
+}
+
+func TestPagesCreate(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	listURL := ts.URLTo(router.AdminPagesOverview)
+	createURL := ts.URLTo(router.AdminPagesCreate)
+
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeRestricted, nil)
+
+	// members can't add pages in restricted rooms
+	ts.User = roomdb.Member{ID: 7331, Role: roomdb.RoleMember}
+	vals := url.Values{
+		"name":          []string{"FAQ"},
+		"slug":          []string{"faq"},
+		"menu_position": []string{"3"},
+	}
+	rec := ts.Client.PostForm(createURL, vals)
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(listURL.Path, rec.Header().Get("Location"))
+	a.Equal(0, ts.PagesDB.CreateCallCount())
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorNotAuthorized")
+
+	ts.User = roomdb.Member{ID: 9001, Role: roomdb.RoleModerator}
+
+	// slugs have to work in URLs
+	vals.Set("slug", "Frequently Asked")
+	rec = ts.Client.PostForm(createURL, vals)
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(0, ts.PagesDB.CreateCallCount())
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorBadRequest")
+
+	vals.Set("slug", "faq")
+	rec = ts.Client.PostForm(createURL, vals)
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.PagesDB.CreateCallCount())
+	_, page := ts.PagesDB.CreateArgsForCall(0)
+	a.Equal("FAQ", page.Name)
+	a.Equal("faq", page.Slug)
+	a.EqualValues(3, page.MenuPosition)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminPagesCreated")
+
+	// the slug is already used
+	ts.PagesDB.CreateReturns(-1, roomdb.ErrPageSlugTaken{Slug: "faq"})
+	rec = ts.Client.PostForm(createURL, vals)
+	a.Equal(http.StatusSeeOther, rec.Code)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "ErrorPageSlugTaken")
+}
+
+func TestPagesUpdateAndRemove(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	ts.User = roomdb.Member{ID: 1, Role: roomdb.RoleAdmin}
+	listURL := ts.URLTo(router.AdminPagesOverview)
+
+	rec := ts.Client.PostForm(ts.URLTo(router.AdminPagesUpdate), url.Values{
+		"id":            []string{"2"},
+		"name":          []string{"Getting started"},
+		"slug":          []string{"getting-started"},
+		"menu_position": []string{"-1"},
+	})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.PagesDB.UpdateCallCount())
+	_, page := ts.PagesDB.UpdateArgsForCall(0)
+	a.EqualValues(2, page.ID)
+	a.Equal("getting-started", page.Slug)
+	a.EqualValues(-1, page.MenuPosition)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminPagesUpdated")
+
+	ts.PagesDB.GetByIDReturns(roomdb.Page{ID: 2, Name: "Getting started", Slug: "getting-started"}, nil)
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminPagesRemoveConfirm, "id", 2))
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal("Getting started (/page/getting-started)", html.Find("#verify").Text())
+
+	form := html.Find("form#confirm")
+	action, has := form.Attr("action")
+	a.True(has)
+	a.Equal(ts.URLTo(router.AdminPagesRemove).String(), action)
+
+	rec = ts.Client.PostForm(ts.URLTo(router.AdminPagesRemove), url.Values{"id": []string{"2"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.PagesDB.RemoveCallCount())
+	_, id := ts.PagesDB.RemoveArgsForCall(0)
+	a.EqualValues(2, id)
+	webassert.HasFlashMessages(t, ts.Client, listURL, "AdminPagesRemoved")
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/internal/outbound"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/members"
+)
+
+// PeerConnections gives the admin pages access to the outgoing connections of the room (see outbound.Manager).
+type PeerConnections interface {
+	// Status returns the connection state of all the peers
+	Status() []outbound.Status
+
+	// Reload needs to be called after the list of peers in the database changed
+	Reload(context.Context) error
+}
+
+type peersHandler struct {
+	flashes *weberrors.FlashHelper
+
+	// where to go after add and remove
+	redirect string
+
+	db    roomdb.PeersService
+	conns PeerConnections
+}
+
+func (h peersHandler) add(w http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(w, req, h.redirect, http.StatusSeeOther)
+
+	if !checkPost(h.flashes, w, req) {
+		return
+	}
+
+	address := strings.TrimSpace(req.Form.Get("address"))
+	if _, _, err := network.ParseMultiserverAddress(address); err != nil {
+		err = weberrors.ErrBadRequest{Where: "Address", Details: err}
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	// can be empty
+	comment := req.Form.Get("comment")
+
+	ctx := req.Context()
+	if _, err := h.db.Add(ctx, address, comment); err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	if err := h.conns.Reload(ctx); err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(w, req, "AdminPeersAdded")
+}
+
+func (h peersHandler) remove(w http.ResponseWriter, req *http.Request) {
+	// always redirect
+	defer http.Redirect(w, req, h.redirect, http.StatusSeeOther)
+
+	if !checkPost(h.flashes, w, req) {
+		return
+	}
+
+	id, err := strconv.ParseInt(req.Form.Get("id"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "ID", Details: err}
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	ctx := req.Context()
+	if err := h.db.RemoveID(ctx, id); err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	if err := h.conns.Reload(ctx); err != nil {
+		h.flashes.AddError(w, req, err)
+		return
+	}
+
+	h.flashes.AddMessage(w, req, "AdminPeersRemoved")
+}
+
+// checkPost makes sure the request is a POST by an admin and parses the form
+func checkPost(flashes *weberrors.FlashHelper, w http.ResponseWriter, req *http.Request) bool {
+	if !checkAdminPost(flashes, w, req) {
+		return false
+	}
+
+	if err := req.ParseForm(); err != nil {
+		err = weberrors.ErrBadRequest{Where: "Form data", Details: err}
+		flashes.AddError(w, req, err)
+		return false
+	}
+
+	return true
+}
+
+// checkAdminPost makes sure the request is a POST by an admin, without touching the body
+func checkAdminPost(flashes *weberrors.FlashHelper, w http.ResponseWriter, req *http.Request) bool {
+	currentMember := members.FromContext(req.Context())
+	if currentMember == nil || currentMember.Role != roomdb.RoleAdmin {
+		flashes.AddError(w, req, weberrors.ErrNotAuthorized)
+		return false
+	}
+
+	if req.Method != "POST" {
+		err := weberrors.ErrBadRequest{Where: "HTTP Method", Details: fmt.Errorf("expected POST not %s", req.Method)}
+		flashes.AddError(w, req, err)
+		return false
+	}
+
+	return true
+}
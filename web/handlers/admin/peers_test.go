@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/outbound"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+	"github.com/ssbc/go-ssb-room/v2/web/webassert"
+)
+
+const testPeerAddr = "net:127.0.0.1:8008~shs:x7iOLUcq3o+sjGeAnipvWeGzfuYgrXl8L4LYlxIhwDc="
+
+func TestPeersAdd(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	ts.User = roomdb.Member{ID: 1234, Role: roomdb.RoleAdmin}
+
+	settingsURL := ts.URLTo(router.AdminSettings)
+	addURL := ts.URLTo(router.AdminSettingsPeersAdd)
+
+	html, resp := ts.Client.GetHTML(settingsURL)
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	formSelection := html.Find("form#add-peer")
+	a.Equal(1, formSelection.Length())
+	webassert.ElementsInForm(t, formSelection, []webassert.FormElement{
+		{Name: "address", Type: "text"},
+		{Name: "comment", Type: "text"},
+	})
+
+	// invalid addresses are not stored
+	rec := ts.Client.PostForm(addURL, url.Values{"address": []string{"wss://nope.example"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	a.Equal(settingsURL.Path, rec.Header().Get("Location"))
+	r.Equal(0, ts.PeersDB.AddCallCount())
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "ErrorBadRequest")
+
+	rec = ts.Client.PostForm(addURL, url.Values{
+		"address": []string{testPeerAddr},
+		"comment": []string{"our pub"},
+	})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.PeersDB.AddCallCount())
+	_, addr, comment := ts.PeersDB.AddArgsForCall(0)
+	a.Equal(testPeerAddr, addr)
+	a.Equal("our pub", comment)
+	a.Equal(1, ts.PeerConns.reloads, "manager wasn't told about the new peer")
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "AdminPeersAdded")
+
+	// only admins can change the list
+	ts.User = roomdb.Member{ID: 7331, Role: roomdb.RoleModerator}
+	rec = ts.Client.PostForm(addURL, url.Values{"address": []string{testPeerAddr}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.PeersDB.AddCallCount())
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "ErrorNotAuthorized")
+
+	html, _ = ts.Client.GetHTML(settingsURL)
+	a.Equal(0, html.Find("form#add-peer").Length(), "moderators should not see the form")
+}
+
+func TestPeersRemove(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	ts.User = roomdb.Member{ID: 1234, Role: roomdb.RoleAdmin}
+
+	ts.PeerConns.status = []outbound.Status{
+		{Address: "net:sibling.example:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=", Comment: "sibling room"},
+		{ID: 23, Address: testPeerAddr, Comment: "our pub"},
+	}
+
+	settingsURL := ts.URLTo(router.AdminSettings)
+	html, resp := ts.Client.GetHTML(settingsURL)
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	entries := html.Find("#peers-list li")
+	a.Equal(2, entries.Length())
+	// static peers can't be removed
+	a.Equal(0, entries.First().Find("form").Length())
+	idInput := entries.Last().Find("form input[name=id]")
+	val, _ := idInput.Attr("value")
+	a.Equal("23", val)
+
+	removeURL := ts.URLTo(router.AdminSettingsPeersRemove)
+	rec := ts.Client.PostForm(removeURL, url.Values{"id": []string{"23"}})
+	a.Equal(http.StatusSeeOther, rec.Code)
+	r.Equal(1, ts.PeersDB.RemoveIDCallCount())
+	_, id := ts.PeersDB.RemoveIDArgsForCall(0)
+	a.EqualValues(23, id)
+	a.Equal(1, ts.PeerConns.reloads)
+	webassert.HasFlashMessages(t, ts.Client, settingsURL, "AdminPeersRemoved")
+}
+
+func TestDashboardPeers(t *testing.T) {
+	ts := newSession(t)
+	a := assert.New(t)
+
+	ts.PeerConns.status = []outbound.Status{
+		{ID: 1, Address: testPeerAddr, Connected: true, ConnectedSince: time.Now()},
+		{ID: 2, Address: "net:down.example:8008~shs:b2hhaW9oYWlvaGFpb2hhaW9oYWlvaGFpb2hhaW9oYWk=", LastError: "connection refused", NextAttempt: time.Now().Add(time.Minute)},
+	}
+
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.AdminDashboard))
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+
+	entries := html.Find("#peers-status li")
+	a.Equal(2, entries.Length())
+	a.Contains(entries.First().Text(), "AdminPeersConnected")
+	a.Contains(entries.Last().Text(), "AdminPeersNotConnected")
+	a.Contains(entries.Last().Text(), "connection refused")
+}
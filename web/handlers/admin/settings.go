@@ -21,10 +21,13 @@ import (
 )
 
 type settingsHandler struct {
-	r     *render.Renderer
-	urlTo web.URLMaker
-	db    roomdb.RoomConfig
-	loc   *i18n.Helper
+	r       *render.Renderer
+	urlTo   web.URLMaker
+	flashes *weberrors.FlashHelper
+	db      roomdb.RoomConfig
+	loc     *i18n.Helper
+
+	peerConns PeerConnections
 }
 
 func (h settingsHandler) overview(w http.ResponseWriter, req *http.Request) (interface{}, error) {
@@ -40,12 +43,33 @@ func (h settingsHandler) overview(w http.ResponseWriter, req *http.Request) (int
 		return nil, fmt.Errorf("failed to retrieve current privacy mode: %w", err)
 	}
 
-	return map[string]interface{}{
+	branding, err := h.db.GetBranding(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the branding: %w", err)
+	}
+
+	openInviteLimits, err := h.db.GetOpenInviteLimits(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the open invite limits: %w", err)
+	}
+
+	pageData := map[string]interface{}{
+		"Branding":        branding,
+		"OpenInvites":     openInviteLimits,
+		"MaxDifficulty":   roomdb.MaxOpenInviteDifficulty,
 		"CurrentMode":     currentMode,
 		"CurrentLanguage": h.loc.ChooseTranslation(currentLanguage),
 		"PrivacyModes":    privacyModes,
+		"Peers":           h.peerConns.Status(),
 		csrf.TemplateTag:  csrf.TemplateField(req),
-	}, nil
+	}
+
+	pageData["Flashes"], err = h.flashes.GetAll(w, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pageData, nil
 }
 
 func (h settingsHandler) setLanguage(w http.ResponseWriter, req *http.Request) {
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"go.mindeco.de/logging/logtest"
 
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/internal/outbound"
 	"github.com/ssbc/go-ssb-room/v2/internal/randutil"
 	"github.com/ssbc/go-ssb-room/v2/internal/repo"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
@@ -46,20 +48,55 @@ type testSession struct {
 
 	URLTo web.URLMaker
 
-	AliasesDB    *mockdb.FakeAliasesService
-	ConfigDB     *mockdb.FakeRoomConfig
-	DeniedKeysDB *mockdb.FakeDeniedKeysService
-	FallbackDB   *mockdb.FakeAuthFallbackService
-	InvitesDB    *mockdb.FakeInvitesService
-	NoticeDB     *mockdb.FakeNoticesService
-	MembersDB    *mockdb.FakeMembersService
-	PinnedDB     *mockdb.FakePinnedNoticesService
+	AliasesDB       *mockdb.FakeAliasesService
+	ConfigDB        *mockdb.FakeRoomConfig
+	DeniedKeysDB    *mockdb.FakeDeniedKeysService
+	FallbackDB      *mockdb.FakeAuthFallbackService
+	InvitesDB       *mockdb.FakeInvitesService
+	NoticeDB        *mockdb.FakeNoticesService
+	MembersDB       *mockdb.FakeMembersService
+	NewsDB          *mockdb.FakeNewsService
+	CodeOfConductDB *mockdb.FakeCodeOfConductService
+	JoinRequestsDB  *mockdb.FakeJoinRequestsService
+	PagesDB         *mockdb.FakePagesService
+	PeersDB         *mockdb.FakePeersService
+	PinnedDB        *mockdb.FakePinnedNoticesService
+	PresenceDB      *mockdb.FakePresenceService
 
 	User roomdb.Member
 
 	RoomState *roomstate.Manager
+	PeerConns *fakePeerConns
+	ConnStats *fakeConnStats
 }
 
+// fakePeerConns stands in for the outbound.Manager of the room
+type fakePeerConns struct {
+	mu      sync.Mutex
+	status  []outbound.Status
+	reloads int
+}
+
+func (fpc *fakePeerConns) Status() []outbound.Status {
+	fpc.mu.Lock()
+	defer fpc.mu.Unlock()
+	return fpc.status
+}
+
+func (fpc *fakePeerConns) Reload(context.Context) error {
+	fpc.mu.Lock()
+	defer fpc.mu.Unlock()
+	fpc.reloads++
+	return nil
+}
+
+// fakeConnStats stands in for the network.ConnLimiter of the room
+type fakeConnStats struct {
+	stats network.ConnLimiterStats
+}
+
+func (fcs *fakeConnStats) Stats() network.ConnLimiterStats { return fcs.stats }
+
 var pubKeyCount byte
 
 func generatePubKey() (refs.FeedRef, error) {
@@ -83,12 +120,22 @@ func newSession(t *testing.T) *testSession {
 	ts.DeniedKeysDB = new(mockdb.FakeDeniedKeysService)
 	ts.FallbackDB = new(mockdb.FakeAuthFallbackService)
 	ts.MembersDB = new(mockdb.FakeMembersService)
+	ts.PeersDB = new(mockdb.FakePeersService)
 	ts.PinnedDB = new(mockdb.FakePinnedNoticesService)
+	ts.PresenceDB = new(mockdb.FakePresenceService)
+	// members didn't connect yet, unless a test says otherwise
+	ts.PresenceDB.GetByMemberIDReturns(roomdb.Presence{}, roomdb.ErrNotFound)
 	ts.NoticeDB = new(mockdb.FakeNoticesService)
+	ts.NewsDB = new(mockdb.FakeNewsService)
+	ts.CodeOfConductDB = new(mockdb.FakeCodeOfConductService)
+	ts.JoinRequestsDB = new(mockdb.FakeJoinRequestsService)
+	ts.PagesDB = new(mockdb.FakePagesService)
 	ts.InvitesDB = new(mockdb.FakeInvitesService)
 
 	log, _ := logtest.KitLogger("admin", t)
 	ts.RoomState = roomstate.NewManager(log)
+	ts.PeerConns = new(fakePeerConns)
+	ts.ConnStats = new(fakeConnStats)
 
 	pubKey, err := generatePubKey()
 	if err != nil {
@@ -156,6 +203,8 @@ func newSession(t *testing.T) *testSession {
 	testFuncs["current_page_is"] = func(routeName string) bool { return true }
 	testFuncs["is_logged_in"] = func() *roomdb.Member { return &ts.User }
 	testFuncs["urlToNotice"] = func(name string) string { return "" }
+	testFuncs["urlToPage"] = func(slug string) string { return "/page/" + slug }
+	testFuncs["room_pages"] = func() []roomdb.Page { return nil }
 	testFuncs["language_count"] = func() int { return 1 }
 	testFuncs["list_languages"] = func(*url.URL, string) string { return "" }
 	testFuncs["privacy_mode_is"] = func(mode string) bool {
@@ -182,6 +231,10 @@ func newSession(t *testing.T) *testSession {
 		return actionCheck(pm, ts.User.Role), nil
 	}
 	testFuncs["list_languages"] = func(*url.URL, string) string { return "" }
+	testFuncs["room_branding"] = func() roomdb.Branding {
+		b, _ := ts.ConfigDB.GetBranding(context.TODO())
+		return b
+	}
 	testFuncs["relative_time"] = func(when time.Time) string { return humanize.Time(when) }
 
 	eh := weberrs.NewErrorHandler(locHelper, flashHelper)
@@ -206,6 +259,8 @@ func newSession(t *testing.T) *testSession {
 		ts.netInfo,
 		r,
 		ts.RoomState,
+		ts.PeerConns,
+		ts.ConnStats,
 		flashHelper,
 		locHelper,
 		Databases{
@@ -216,7 +271,13 @@ func newSession(t *testing.T) *testSession {
 			Members:       ts.MembersDB,
 			Invites:       ts.InvitesDB,
 			Notices:       ts.NoticeDB,
+			News:          ts.NewsDB,
+			CodeOfConduct: ts.CodeOfConductDB,
+			JoinRequests:  ts.JoinRequestsDB,
+			Pages:         ts.PagesDB,
+			Peers:         ts.PeersDB,
 			PinnedNotices: ts.PinnedDB,
+			Presence:      ts.PresenceDB,
 		},
 	)
 
@@ -335,10 +335,27 @@ func (h WithSSBHandler) clientInitiated(w http.ResponseWriter, req *http.Request
 		return err
 	}
 
-	http.Redirect(w, req, dashboardURL.Path, http.StatusTemporaryRedirect)
+	signedInURL, err := signedInRedirect(dashboardURL.Path)
+	if err != nil {
+		return err
+	}
+
+	http.Redirect(w, req, signedInURL, http.StatusTemporaryRedirect)
 	return nil
 }
 
+// signedInRedirect returns where members go after signing in, which asks them to accept the code of conduct if they have to.
+// after that they go on to the passed path.
+func signedInRedirect(to string) (string, error) {
+	signedInURL, err := router.CompleteApp().Get(router.MembersSignedIn).URL()
+	if err != nil {
+		return "", err
+	}
+
+	signedInURL.RawQuery = url.Values{"redirect": []string{to}}.Encode()
+	return signedInURL.RequestURI(), nil
+}
+
 // server-sent-events stuff
 
 type templateData struct {
@@ -424,7 +441,13 @@ func (h WithSSBHandler) finalizeCookie(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
+	signedInURL, err := signedInRedirect("/")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, signedInURL, http.StatusTemporaryRedirect)
 }
 
 // the time after which the SSE dance is considered failed
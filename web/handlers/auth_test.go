@@ -370,8 +370,13 @@ func TestAuthWithSSBClientInitHasClient(t *testing.T) {
 	doc, resp := ts.Client.GetHTML(signInStartURL)
 	a.Equal(http.StatusTemporaryRedirect, resp.Code)
 
+	// the code of conduct is checked before going to the dashboard
+	signedInURL, err := url.Parse(resp.Header().Get("Location"))
+	r.NoError(err)
+	a.Equal(ts.URLTo(router.MembersSignedIn).Path, signedInURL.Path)
+
 	dashboardURL := ts.URLTo(router.AdminDashboard)
-	a.Equal(dashboardURL.Path, resp.Header().Get("Location"))
+	a.Equal(dashboardURL.Path, signedInURL.Query().Get("redirect"))
 
 	webassert.Localized(t, doc, []webassert.LocalizedElement{
 		// {"#welcome", "AuthWithSSBWelcome"},
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.mindeco.de/log/level"
+	"go.mindeco.de/logging"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+)
+
+// brandingHandler serves the logo, the stylesheet with the accent color and the public information about the room
+type brandingHandler struct {
+	urlTo   web.URLMaker
+	netInfo network.ServerEndpointDetails
+
+	config roomdb.RoomConfig
+}
+
+func (h brandingHandler) logo(w http.ResponseWriter, req *http.Request) {
+	logo, err := h.config.GetLogo(req.Context())
+	if err != nil {
+		if errors.Is(err, roomdb.ErrNotFound) {
+			http.NotFound(w, req)
+			return
+		}
+		http.Error(w, "failed to load the logo", http.StatusInternalServerError)
+		return
+	}
+
+	// the logo can change at any time, browsers need to check but don't need to load it again
+	sum := sha256.Sum256(logo.Data)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if req.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", logo.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(logo.Data)
+}
+
+// style serves the accent color as a stylesheet, since the content security policy doesn't allow inline styles
+func (h brandingHandler) style(w http.ResponseWriter, req *http.Request) {
+	branding, err := h.config.GetBranding(req.Context())
+	if err != nil {
+		http.Error(w, "failed to load the branding", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	// the color was validated when it was stored, see roomdb.Branding.Validate
+	if branding.AccentColor == "" {
+		return
+	}
+	fmt.Fprintf(w, `:root { --room-accent: %s; }
+body { border-top: 4px solid var(--room-accent); }
+#room-brand-name { color: var(--room-accent); }
+`, branding.AccentColor)
+}
+
+// roomInfoResponse is the public information about the room for apps and directories of rooms
+type roomInfoResponse struct {
+	Name        string `json:"name"`
+	Tagline     string `json:"tagline,omitempty"`
+	Logo        string `json:"logo,omitempty"`
+	AccentColor string `json:"accentColor,omitempty"`
+
+	RoomID             string `json:"roomId"`
+	MultiserverAddress string `json:"multiserverAddress"`
+	PrivacyMode        string `json:"privacyMode"`
+}
+
+func (h brandingHandler) roomInfo(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	branding, err := h.config.GetBranding(ctx)
+	if err != nil {
+		http.Error(w, "failed to load the branding", http.StatusInternalServerError)
+		return
+	}
+
+	pm, err := h.config.GetPrivacyMode(ctx)
+	if err != nil {
+		http.Error(w, "failed to load the privacy mode", http.StatusInternalServerError)
+		return
+	}
+
+	resp := roomInfoResponse{
+		Name:        h.netInfo.Domain,
+		Tagline:     branding.Tagline,
+		AccentColor: branding.AccentColor,
+
+		RoomID:             h.netInfo.RoomID.String(),
+		MultiserverAddress: h.netInfo.MultiserverAddress(),
+		PrivacyMode:        pm.String(),
+	}
+	if branding.Name != "" {
+		resp.Name = branding.Name
+	}
+	if branding.HasLogo {
+		resp.Logo = h.urlTo(router.CompleteBrandingLogo).String()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		level.Warn(logging.FromContext(ctx)).Log("event", "sending room info failed", "err", err)
+	}
+}
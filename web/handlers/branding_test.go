@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+)
+
+func TestBrandingRoomInfo(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	infoURL := ts.URLTo(router.CompleteRoomInfo)
+
+	// without branding the domain is the name
+	resp := ts.Client.GetBody(infoURL)
+	a.Equal(http.StatusOK, resp.Code)
+
+	var info roomInfoResponse
+	r.NoError(json.NewDecoder(resp.Body).Decode(&info))
+	a.Equal("localhost", info.Name)
+	a.Equal("", info.Logo)
+	a.Equal(ts.NetworkInfo.RoomID.String(), info.RoomID)
+	a.Equal(ts.NetworkInfo.MultiserverAddress(), info.MultiserverAddress)
+	a.Equal("ModeCommunity", info.PrivacyMode)
+
+	ts.ConfigDB.GetBrandingReturns(roomdb.Branding{
+		Name:        "Hermies Club",
+		Tagline:     "a place for crabs",
+		AccentColor: "#6d28d9",
+		HasLogo:     true,
+	}, nil)
+
+	resp = ts.Client.GetBody(infoURL)
+	a.Equal(http.StatusOK, resp.Code)
+	info = roomInfoResponse{}
+	r.NoError(json.NewDecoder(resp.Body).Decode(&info))
+	a.Equal("Hermies Club", info.Name)
+	a.Equal("a place for crabs", info.Tagline)
+	a.Equal("#6d28d9", info.AccentColor)
+	a.Equal(ts.URLTo(router.CompleteBrandingLogo).String(), info.Logo)
+
+	// the name and the tagline are shown on the landing page
+	ts.PinnedDB.GetReturns(&roomdb.Notice{Title: "Welcome"}, nil)
+	html, resp := ts.Client.GetHTML(ts.URLTo(router.CompleteIndex))
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal("Welcome · Hermies Club", html.Find("title").Text())
+	a.Equal("Hermies Club", html.Find("#room-brand-name").Text())
+	a.Equal("a place for crabs", strings.TrimSpace(html.Find("#room-tagline").Text()))
+
+	resp = ts.Client.GetBody(ts.URLTo(router.CompleteBrandingStyle))
+	a.Equal(http.StatusOK, resp.Code)
+	a.Contains(resp.Body.String(), "--room-accent: #6d28d9;")
+}
+
+func TestBrandingLogo(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+
+	logoURL := ts.URLTo(router.CompleteBrandingLogo)
+
+	ts.ConfigDB.GetLogoReturns(roomdb.Logo{}, roomdb.ErrNotFound)
+	resp := ts.Client.GetBody(logoURL)
+	a.Equal(http.StatusNotFound, resp.Code)
+
+	logo := roomdb.Logo{ContentType: "image/png", Data: []byte("\x89PNG\r\n\x1a\nnot really")}
+	ts.ConfigDB.GetLogoReturns(logo, nil)
+	resp = ts.Client.GetBody(logoURL)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal("image/png", resp.Header().Get("Content-Type"))
+	a.Equal("nosniff", resp.Header().Get("X-Content-Type-Options"))
+	a.Equal(logo.Data, resp.Body.Bytes())
+	a.NotEmpty(resp.Header().Get("ETag"))
+}
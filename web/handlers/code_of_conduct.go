@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/csrf"
+	"go.mindeco.de/http/render"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/members"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+)
+
+// codeOfConductSlug is where the pinned code of conduct is shown, see CompletePageShow
+const codeOfConductSlug = "code-of-conduct"
+
+type codeOfConductHandler struct {
+	r       *render.Renderer
+	urlTo   web.URLMaker
+	flashes *weberrors.FlashHelper
+
+	codeOfConduct roomdb.CodeOfConductService
+	pinned        roomdb.PinnedNoticesService
+}
+
+// hasAccepted returns true if the member accepted the current version of the code of conduct,
+// or if the room doesn't ask for one.
+func (h codeOfConductHandler) hasAccepted(ctx context.Context, memberID int64) (bool, error) {
+	current, err := h.codeOfConduct.Current(ctx)
+	if err != nil {
+		return false, err
+	}
+	if current.Version == 0 {
+		return true, nil
+	}
+
+	accepted, err := h.codeOfConduct.Accepted(ctx, memberID)
+	if err != nil {
+		if errors.Is(err, roomdb.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return accepted.Version >= current.Version, nil
+}
+
+// requireAccepted sends signed in members that didn't accept the current code of conduct to its form first.
+// This is how members are asked again after signing in, once it changed.
+func (h codeOfConductHandler) requireAccepted(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		m := members.FromContext(req.Context())
+		if m == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		accepted, err := h.hasAccepted(req.Context(), m.ID)
+		if err != nil {
+			h.r.Error(w, req, http.StatusInternalServerError, err)
+			return
+		}
+
+		if !accepted {
+			formURL := h.urlTo(router.MembersCodeOfConductForm, "redirect", req.URL.RequestURI())
+			http.Redirect(w, req, formURL.String(), http.StatusSeeOther)
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// signedIn is where both ways of signing in end up.
+// Members that didn't accept the current code of conduct are asked to do that before they go on.
+func (h codeOfConductHandler) signedIn(w http.ResponseWriter, req *http.Request) {
+	m := members.FromContext(req.Context())
+	if m == nil {
+		h.r.Error(w, req, http.StatusUnauthorized, weberrors.ErrNotAuthorized)
+		return
+	}
+
+	redirect := h.localRedirect(req.URL.Query().Get("redirect"))
+
+	accepted, err := h.hasAccepted(req.Context(), m.ID)
+	if err != nil {
+		h.r.Error(w, req, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !accepted {
+		formURL := h.urlTo(router.MembersCodeOfConductForm, "redirect", redirect)
+		http.Redirect(w, req, formURL.String(), http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, req, redirect, http.StatusSeeOther)
+}
+
+type codeOfConductFormData struct {
+	Version int64
+
+	// Notice is the translation that is shown, picked with ?lang=
+	Notice  roomdb.Notice
+	Content template.HTML
+
+	// Languages are the other translations
+	Languages []string
+
+	// Redirect is where the member goes after accepting it
+	Redirect string
+
+	CSRFField template.HTML
+	Flashes   []weberrors.FlashMessage
+}
+
+func (h codeOfConductHandler) form(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	ctx := req.Context()
+
+	if members.FromContext(ctx) == nil {
+		return nil, weberrors.ErrNotAuthorized
+	}
+
+	current, err := h.codeOfConduct.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned, err := h.pinned.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the translations of a pinned notice are a page, too
+	page := roomdb.Page{Translations: pinned[roomdb.NoticeCodeOfConduct]}
+	notice, has := page.Translation(req.URL.Query().Get("lang"))
+	if !has {
+		return nil, roomdb.ErrNotFound
+	}
+
+	pageData := codeOfConductFormData{
+		Version:   current.Version,
+		Notice:    notice,
+		Content:   web.RenderMarkdown(notice.Content),
+		Redirect:  h.localRedirect(req.URL.Query().Get("redirect")),
+		CSRFField: csrf.TemplateField(req),
+	}
+	for _, n := range page.Translations {
+		if n.ID != notice.ID && n.Published {
+			pageData.Languages = append(pageData.Languages, n.Language)
+		}
+	}
+
+	pageData.Flashes, err = h.flashes.GetAll(rw, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pageData, nil
+}
+
+func (h codeOfConductHandler) accept(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	m := members.FromContext(ctx)
+	if m == nil {
+		h.r.Error(rw, req, http.StatusUnauthorized, weberrors.ErrNotAuthorized)
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		h.r.Error(rw, req, http.StatusBadRequest, weberrors.ErrBadRequest{Where: "form data", Details: err})
+		return
+	}
+
+	redirect := h.localRedirect(req.FormValue("redirect"))
+
+	version, err := strconv.ParseInt(req.FormValue("version"), 10, 64)
+	if err != nil {
+		err = weberrors.ErrBadRequest{Where: "version", Details: err}
+	} else if req.FormValue("accept") != "yes" {
+		err = weberrors.ErrBadRequest{Where: "accept", Details: fmt.Errorf("the code of conduct wasn't accepted")}
+	} else {
+		err = h.codeOfConduct.Accept(ctx, m.ID, version)
+	}
+	if err != nil {
+		h.flashes.AddError(rw, req, err)
+		formURL := h.urlTo(router.MembersCodeOfConductForm, "redirect", redirect)
+		http.Redirect(rw, req, formURL.String(), http.StatusSeeOther)
+		return
+	}
+
+	h.flashes.AddMessage(rw, req, "CodeOfConductAccepted")
+	http.Redirect(rw, req, redirect, http.StatusSeeOther)
+}
+
+// localRedirect only allows paths on this site and falls back to the dashboard
+func (h codeOfConductHandler) localRedirect(to string) string {
+	if !strings.HasPrefix(to, "/") || strings.HasPrefix(to, "//") || strings.HasPrefix(to, "/\\") {
+		return h.urlTo(router.AdminDashboard).Path
+	}
+	return to
+}
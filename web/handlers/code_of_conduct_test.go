@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+	"github.com/ssbc/go-ssb-room/v2/web/webassert"
+)
+
+func TestCodeOfConductRequiredAfterSignIn(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	ts.CodeOfConductDB.CurrentReturns(roomdb.CodeOfConductVersion{Version: 2}, nil)
+	ts.CodeOfConductDB.AcceptedReturns(roomdb.CodeOfConductAcceptance{MemberID: 23, Version: 1}, nil)
+	ts.PinnedDB.ListReturns(roomdb.PinnedNotices{
+		roomdb.NoticeCodeOfConduct: []roomdb.Notice{
+			{ID: 1, Title: "Code of conduct", Content: "be *nice*", Language: "en", Published: true},
+			{ID: 2, Title: "Verhaltenskodex", Content: "sei *nett*", Language: "de", Published: true},
+		},
+	}, nil)
+
+	// sign in
+	doc, resp := ts.Client.GetHTML(ts.URLTo(router.AuthFallbackLogin))
+	a.Equal(http.StatusOK, resp.Code)
+	loginVals := webassert.CSRFTokenPresent(t, doc.Find("#password-fallback"))
+	loginVals.Set("user", "test")
+	loginVals.Set("pass", "test")
+	ts.AuthFallbackDB.CheckReturns(int64(23), nil)
+	ts.MembersDB.GetByIDReturns(roomdb.Member{ID: 23}, nil)
+
+	var refererHeader = make(http.Header)
+	refererHeader.Set("Referer", "https://localhost")
+	ts.Client.SetHeaders(refererHeader)
+
+	resp = ts.Client.PostForm(ts.URLTo(router.AuthFallbackFinalize), loginVals)
+	r.Equal(http.StatusSeeOther, resp.Code, "wrong HTTP status code for sign in")
+	signedInURL, err := url.Parse(resp.Header().Get("Location"))
+	r.NoError(err)
+	a.Equal(ts.URLTo(router.MembersSignedIn).Path, signedInURL.Path)
+
+	// signing in sends them to the new version first
+	dashboardURL := ts.URLTo(router.AdminDashboard)
+	resp = ts.Client.GetBody(signedInURL)
+	r.Equal(http.StatusSeeOther, resp.Code)
+
+	formURL, err := url.Parse(resp.Header().Get("Location"))
+	r.NoError(err)
+	a.Equal(ts.URLTo(router.MembersCodeOfConductForm).Path, formURL.Path)
+	a.Equal(dashboardURL.Path, formURL.Query().Get("redirect"))
+
+	// and so does the dashboard
+	resp = ts.Client.GetBody(dashboardURL)
+	r.Equal(http.StatusSeeOther, resp.Code)
+	a.Equal(formURL.String(), resp.Header().Get("Location"))
+
+	doc, resp = ts.Client.GetHTML(formURL)
+	r.Equal(http.StatusOK, resp.Code)
+	a.Equal("Code of conduct", doc.Find("h1").Text())
+	a.Equal(1, doc.Find("#code-of-conduct-languages a").Length())
+
+	form := doc.Find("form#accept-code-of-conduct")
+	postData := webassert.CSRFTokenPresent(t, form)
+	webassert.ElementsInForm(t, form, []webassert.FormElement{
+		{Name: "version", Type: "hidden", Value: "2"},
+		{Name: "redirect", Type: "hidden", Value: dashboardURL.Path},
+		{Name: "accept", Type: "checkbox", Value: "yes"},
+	})
+
+	// without the checkbox it isn't accepted
+	acceptURL := ts.URLTo(router.MembersCodeOfConductAccept)
+	postData.Set("version", "2")
+	postData.Set("redirect", dashboardURL.Path)
+	resp = ts.Client.PostForm(acceptURL, postData)
+	a.Equal(http.StatusSeeOther, resp.Code)
+	a.Equal(0, ts.CodeOfConductDB.AcceptCallCount())
+
+	// other sites are not followed
+	postData.Set("accept", "yes")
+	postData.Set("redirect", "//evil.example")
+	resp = ts.Client.PostForm(acceptURL, postData)
+	a.Equal(http.StatusSeeOther, resp.Code)
+	a.Equal(dashboardURL.Path, resp.Header().Get("Location"))
+
+	r.Equal(1, ts.CodeOfConductDB.AcceptCallCount())
+	_, memberID, version := ts.CodeOfConductDB.AcceptArgsForCall(0)
+	a.EqualValues(23, memberID)
+	a.EqualValues(2, version)
+
+	// once accepted the dashboard is shown
+	ts.CodeOfConductDB.AcceptedReturns(roomdb.CodeOfConductAcceptance{MemberID: 23, Version: 2}, nil)
+	resp = ts.Client.GetBody(dashboardURL)
+	a.Equal(http.StatusOK, resp.Code)
+
+	resp = ts.Client.GetBody(signedInURL)
+	a.Equal(http.StatusSeeOther, resp.Code)
+	a.Equal(dashboardURL.Path, resp.Header().Get("Location"))
+}
+
+func TestCodeOfConductNotRequiredWithoutOne(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+
+	cc := codeOfConductHandler{codeOfConduct: ts.CodeOfConductDB}
+
+	// no version means there is nothing to accept
+	accepted, err := cc.hasAccepted(context.Background(), 23)
+	a.NoError(err)
+	a.True(accepted)
+	a.Equal(0, ts.CodeOfConductDB.AcceptedCallCount())
+
+	ts.CodeOfConductDB.CurrentReturns(roomdb.CodeOfConductVersion{Version: 1}, nil)
+	ts.CodeOfConductDB.AcceptedReturns(roomdb.CodeOfConductAcceptance{}, roomdb.ErrNotFound)
+	accepted, err = cc.hasAccepted(context.Background(), 23)
+	a.NoError(err)
+	a.False(accepted)
+}
+
+func TestInviteConsumeRequiresCodeOfConduct(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	testToken := "existing-test-token-coc"
+	testInvite := roomdb.Invite{ID: 4321}
+	ts.InvitesDB.GetByTokenReturns(testInvite, nil)
+	ts.InvitesDB.ConsumeReturns(testInvite, nil)
+	ts.MembersDB.GetByFeedReturns(roomdb.Member{ID: 42}, nil)
+	ts.CodeOfConductDB.CurrentReturns(roomdb.CodeOfConductVersion{Version: 3}, nil)
+
+	// the facade asks to accept it before showing the invite
+	facadeURL := ts.URLTo(router.CompleteInviteFacade, "token", testToken)
+	doc, resp := ts.Client.GetHTML(facadeURL)
+	r.Equal(http.StatusOK, resp.Code)
+
+	form := doc.Find("form#accept-code-of-conduct")
+	r.Equal(1, form.Length())
+	webassert.ElementsInForm(t, form, []webassert.FormElement{
+		{Name: "token", Type: "hidden", Value: testToken},
+		{Name: codeOfConductParam, Type: "checkbox", Value: "3"},
+	})
+	a.Equal(0, doc.Find("#claim-invite-uri").Length(), "should not offer the invite yet")
+
+	testNewMember, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{1}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	// without the version the invite is not consumed
+	consumeInviteURL := ts.URLTo(router.CompleteInviteConsume)
+	var consume inviteConsumePayload
+	consume.Invite = testToken
+	consume.ID = testNewMember
+
+	var consumeResp struct {
+		Status string
+		Error  string
+	}
+	resp = ts.Client.SendJSON(consumeInviteURL, consume)
+	r.NoError(json.NewDecoder(resp.Body).Decode(&consumeResp))
+	a.Equal("error", consumeResp.Status)
+	a.Equal(0, ts.InvitesDB.ConsumeCallCount())
+
+	// an old version isn't enough either
+	consume.CodeOfConduct = 2
+	resp = ts.Client.SendJSON(consumeInviteURL, consume)
+	r.NoError(json.NewDecoder(resp.Body).Decode(&consumeResp))
+	a.Equal("error", consumeResp.Status)
+	a.Equal(0, ts.InvitesDB.ConsumeCallCount())
+
+	// apps that only know the postTo URL get the version through it
+	consume.CodeOfConduct = 0
+	consumeWithVersion := ts.URLTo(router.CompleteInviteConsume, codeOfConductParam, 3)
+	resp = ts.Client.SendJSON(consumeWithVersion, consume)
+	r.NoError(json.NewDecoder(resp.Body).Decode(&consumeResp))
+	a.Equal("successful", consumeResp.Status)
+	r.Equal(1, ts.InvitesDB.ConsumeCallCount())
+
+	// and it's recorded for the new member
+	r.Equal(1, ts.CodeOfConductDB.AcceptCallCount())
+	_, memberID, version := ts.CodeOfConductDB.AcceptArgsForCall(0)
+	a.EqualValues(42, memberID)
+	a.EqualValues(3, version)
+
+	// failing to record it doesn't undo the invite
+	ts.CodeOfConductDB.AcceptReturns(errors.New("db is full"))
+	consume.CodeOfConduct = 3
+	resp = ts.Client.SendJSON(consumeInviteURL, consume)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal(2, ts.InvitesDB.ConsumeCallCount())
+}
@@ -14,12 +14,12 @@ import (
 
 	"github.com/gorilla/csrf"
 	"github.com/gorilla/sessions"
-	"github.com/russross/blackfriday/v2"
 	"go.mindeco.de/http/auth"
 	"go.mindeco.de/http/render"
 	"go.mindeco.de/log/level"
 	"go.mindeco.de/logging"
 
+	"github.com/ssbc/go-ssb-room/v2/internal/hashcash"
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/internal/repo"
 	"github.com/ssbc/go-ssb-room/v2/internal/signinwithssb"
@@ -39,15 +39,24 @@ var HTMLTemplates = []string{
 	"alias.tmpl",
 
 	"change-member-password.tmpl",
+	"accept-code-of-conduct.tmpl",
 
 	"invite/consumed.tmpl",
 	"invite/facade.tmpl",
 	"invite/facade-fallback.tmpl",
 	"invite/insert-id.tmpl",
+	"invite/open-challenge.tmpl",
+
+	"join-request/form.tmpl",
+	"join-request/status.tmpl",
 
 	"notice/list.tmpl",
 	"notice/show.tmpl",
 
+	"page/show.tmpl",
+
+	"news/list.tmpl",
+
 	"error.tmpl",
 }
 
@@ -56,12 +65,18 @@ type Databases struct {
 	Aliases       roomdb.AliasesService
 	AuthFallback  roomdb.AuthFallbackService
 	AuthWithSSB   roomdb.AuthWithSSBService
+	CodeOfConduct roomdb.CodeOfConductService
 	Config        roomdb.RoomConfig
 	DeniedKeys    roomdb.DeniedKeysService
 	Invites       roomdb.InvitesService
+	JoinRequests  roomdb.JoinRequestsService
 	Notices       roomdb.NoticesService
 	Members       roomdb.MembersService
+	News          roomdb.NewsService
+	Pages         roomdb.PagesService
+	Peers         roomdb.PeersService
 	PinnedNotices roomdb.PinnedNoticesService
+	Presence      roomdb.PresenceService
 }
 
 // New initializes the whole web stack for rooms, with all the sub-modules and routing.
@@ -71,6 +86,8 @@ func New(
 	netInfo network.ServerEndpointDetails,
 	roomState *roomstate.Manager,
 	roomEndpoints network.Endpoints,
+	peerConns admin.PeerConnections,
+	connStats admin.ConnectionStats,
 	bridge *signinwithssb.SignalBridge,
 	dbs Databases,
 ) (http.Handler, error) {
@@ -87,6 +104,15 @@ func New(
 		return nil, err
 	}
 
+	// templates and assets from the repo replace the embedded ones
+	overrides, err := web.LoadOverrides(repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range overrides.Files {
+		level.Info(logger).Log("event", "using custom file", "file", f)
+	}
+
 	cookieStore := &sessions.CookieStore{
 		Codecs: cookieCodec,
 		Options: &sessions.Options{
@@ -122,6 +148,15 @@ func New(
 			}
 		}),
 
+		render.InjectTemplateFunc("room_branding", func(r *http.Request) interface{} {
+			// load it once per request, the base template and the pages all use it
+			b, err := dbs.Config.GetBranding(r.Context())
+			if err != nil {
+				b = roomdb.Branding{}
+			}
+			return func() roomdb.Branding { return b }
+		}),
+
 		render.InjectTemplateFunc("current_page_is", func(r *http.Request) interface{} {
 			return func(routeName string) bool {
 				route := m.Get(routeName)
@@ -179,12 +214,39 @@ func New(
 				return urlTo(router.CompleteNoticeShow, "id", notice.ID)
 			}
 		}),
+
+		render.InjectTemplateFunc("urlToPage", func(r *http.Request) interface{} {
+			return func(slug string) *url.URL {
+				// urlTo can't fill in the {slug} of the route
+				u := urlTo(router.CompleteIndex)
+				u.Path = "/page/" + url.PathEscape(slug)
+				u.RawQuery = ""
+				return u
+			}
+		}),
+
+		// room_pages returns the added pages that have a published translation, in the order of the menu
+		render.InjectTemplateFunc("room_pages", func(r *http.Request) interface{} {
+			return func() []roomdb.Page {
+				all, err := dbs.Pages.List(r.Context())
+				if err != nil {
+					return nil
+				}
+				var published []roomdb.Page
+				for _, p := range all {
+					if _, has := p.Translation(""); has {
+						published = append(published, p)
+					}
+				}
+				return published
+			}
+		}),
 	}
 
 	renderOpts = append(renderOpts, locHelper.GetRenderFuncs()...)
 	renderOpts = append(renderOpts, members.TemplateHelpers(dbs.Config)...)
 
-	r, err := render.New(web.Templates, renderOpts...)
+	r, err := render.New(overrides.Templates, renderOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("web Handler: failed to create renderer: %w", err)
 	}
@@ -199,6 +261,9 @@ func New(
 			eh.Handle(rw, req, http.StatusForbidden, weberrs.ErrNotAuthorized)
 		})),
 		auth.SetLifetime(2*time.Hour), // TODO: configure
+
+		// the code of conduct might have to be accepted first
+		auth.SetLanding(urlTo(router.MembersSignedIn).Path),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("web Handler: failed to init fallback auth system: %w", err)
@@ -269,20 +334,42 @@ func New(
 		netInfo,
 		r,
 		roomState,
+		peerConns,
+		connStats,
 		flashHelper,
 		locHelper,
 		admin.Databases{
 			Aliases:       dbs.Aliases,
 			AuthFallback:  dbs.AuthFallback,
+			CodeOfConduct: dbs.CodeOfConduct,
 			Config:        dbs.Config,
 			DeniedKeys:    dbs.DeniedKeys,
 			Invites:       dbs.Invites,
+			JoinRequests:  dbs.JoinRequests,
 			Notices:       dbs.Notices,
 			Members:       dbs.Members,
+			News:          dbs.News,
+			Pages:         dbs.Pages,
+			Peers:         dbs.Peers,
 			PinnedNotices: dbs.PinnedNotices,
+			Presence:      dbs.Presence,
 		},
 	)
-	mainMux.Handle("/admin/", members.AuthenticateFromContext(r)(adminHandler))
+
+	// members have to accept the current code of conduct when they sign in.
+	// the member area checks it, too, in case it changed while they were signed in.
+	var coch = codeOfConductHandler{
+		r:       r,
+		urlTo:   urlTo,
+		flashes: flashHelper,
+
+		codeOfConduct: dbs.CodeOfConduct,
+		pinned:        dbs.PinnedNotices,
+	}
+	mainMux.Handle("/admin/", members.AuthenticateFromContext(r)(coch.requireAccepted(adminHandler)))
+	m.Get(router.MembersCodeOfConductForm).HandlerFunc(r.HTML("accept-code-of-conduct.tmpl", coch.form))
+	m.Get(router.MembersCodeOfConductAccept).HandlerFunc(coch.accept)
+	m.Get(router.MembersSignedIn).HandlerFunc(coch.signedIn)
 
 	var mh = newMembersHandler(netInfo.Development, r, urlTo, flashHelper, dbs.AuthFallback)
 	m.Get(router.MembersChangePasswordForm).HandlerFunc(r.HTML("change-member-password.tmpl", mh.changePasswordForm))
@@ -318,11 +405,10 @@ func New(
 		if err != nil {
 			return nil, fmt.Errorf("failed to find description: %w", err)
 		}
-		markdown := blackfriday.Run([]byte(notice.Content), blackfriday.WithNoExtensions())
 		return noticeShowData{
 			ID:       notice.ID,
 			Title:    notice.Title,
-			Content:  template.HTML(markdown),
+			Content:  web.RenderMarkdown(notice.Content),
 			Language: notice.Language,
 		}, nil
 	}))
@@ -334,10 +420,34 @@ func New(
 
 		notices: dbs.Notices,
 		pinned:  dbs.PinnedNotices,
+		roomCfg: dbs.Config,
 	}
 	m.Get(router.CompleteNoticeList).HandlerFunc(nh.list)
 	m.Get(router.CompleteNoticeShow).Handler(r.HTML("notice/show.tmpl", nh.show))
 
+	var ph = pageHandler{
+		flashes: flashHelper,
+
+		pages:   dbs.Pages,
+		roomCfg: dbs.Config,
+	}
+	m.Get(router.CompletePageShow).Handler(r.HTML("page/show.tmpl", ph.show))
+
+	// news posts and their feeds
+	var newsh = newsHandler{
+		flashes: flashHelper,
+		urlTo:   urlTo,
+		netInfo: netInfo,
+
+		news:    dbs.News,
+		pinned:  dbs.PinnedNotices,
+		roomCfg: dbs.Config,
+	}
+	m.Get(router.CompleteNewsList).Handler(r.HTML("news/list.tmpl", newsh.list))
+	m.Get(router.CompleteNewsFeedAtom).HandlerFunc(newsh.feedAtom)
+	m.Get(router.CompleteNewsFeedRSS).HandlerFunc(newsh.feedRSS)
+	m.Get(router.CompleteNewsFeedJSON).HandlerFunc(newsh.feedJSON)
+
 	// public aliases
 	var ah = aliasHandler{
 		r: r,
@@ -359,6 +469,11 @@ func New(
 		pinnedNotices: dbs.PinnedNotices,
 		invites:       dbs.Invites,
 		deniedKeys:    dbs.DeniedKeys,
+		members:       dbs.Members,
+		codeOfConduct: dbs.CodeOfConduct,
+
+		challenges:    hashcash.NewIssuer(),
+		openModeQuota: new(openInviteQuota),
 	}
 	m.Get(router.CompleteInviteFacade).HandlerFunc(ih.presentFacade)
 	m.Get(router.CompleteInviteFacadeFallback).Handler(r.HTML("invite/facade-fallback.tmpl", ih.presentFacadeFallback))
@@ -366,8 +481,33 @@ func New(
 	m.Get(router.CompleteInviteConsume).HandlerFunc(ih.consume)
 	m.Get(router.OpenModeCreateInvite).HandlerFunc(ih.createOpenMode)
 
+	// public join requests
+	var jrh = joinRequestsHandler{
+		render:      r,
+		urlTo:       urlTo,
+		networkInfo: netInfo,
+
+		config:       dbs.Config,
+		joinRequests: dbs.JoinRequests,
+		members:      dbs.Members,
+		deniedKeys:   dbs.DeniedKeys,
+	}
+	m.Get(router.CompleteJoinRequestForm).Handler(r.HTML("join-request/form.tmpl", jrh.form))
+	m.Get(router.CompleteJoinRequestCreate).HandlerFunc(jrh.create)
+	m.Get(router.CompleteJoinRequestStatus).HandlerFunc(jrh.status)
+
+	// branding and public room information
+	var bh = brandingHandler{
+		urlTo:   urlTo,
+		netInfo: netInfo,
+		config:  dbs.Config,
+	}
+	m.Get(router.CompleteBrandingLogo).HandlerFunc(bh.logo)
+	m.Get(router.CompleteBrandingStyle).HandlerFunc(bh.style)
+	m.Get(router.CompleteRoomInfo).HandlerFunc(bh.roomInfo)
+
 	// static assets
-	m.PathPrefix("/assets/").Handler(http.StripPrefix("/assets/", http.FileServer(web.Assets)))
+	m.PathPrefix("/assets/").Handler(http.StripPrefix("/assets/", http.FileServer(overrides.Assets)))
 
 	// TODO: doesnt work because of of mainMux wrapper, see issue #35
 	m.NotFoundHandler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -380,6 +520,7 @@ func New(
 
 	consumeURL := urlTo(router.CompleteInviteConsume)
 	openModeCreateInviteURL := urlTo(router.OpenModeCreateInvite)
+	joinRequestCreateURL := urlTo(router.CompleteJoinRequestCreate)
 
 	// apply HTTP middleware
 	middlewares := []func(http.Handler) http.Handler{
@@ -399,6 +540,10 @@ func New(
 					next.ServeHTTP(w, csrf.UnsafeSkipCheck(req))
 					return
 				}
+				if req.URL.Path == joinRequestCreateURL.Path && ct == "application/json" {
+					next.ServeHTTP(w, csrf.UnsafeSkipCheck(req))
+					return
+				}
 				next.ServeHTTP(w, req)
 			})
 		},
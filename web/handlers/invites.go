@@ -5,14 +5,19 @@
 package handlers
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
 	"image/color"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gorilla/csrf"
 	"github.com/skip2/go-qrcode"
@@ -21,6 +26,7 @@ import (
 	"go.mindeco.de/logging"
 
 	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/hashcash"
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
 	"github.com/ssbc/go-ssb-room/v2/web"
@@ -37,14 +43,51 @@ type inviteHandler struct {
 	pinnedNotices roomdb.PinnedNoticesService
 	config        roomdb.RoomConfig
 	deniedKeys    roomdb.DeniedKeysService
+	members       roomdb.MembersService
+	codeOfConduct roomdb.CodeOfConductService
+
+	// challenges and openModeQuota keep bots from creating all the invites in open mode
+	challenges    *hashcash.Issuer
+	openModeQuota *openInviteQuota
+}
+
+// codeOfConductParam is the query parameter that carries the version of the code of conduct
+// that was accepted on the invite pages, until the invite is consumed.
+const codeOfConductParam = "coc"
+
+// inviteCodeOfConduct tells apps which version of the code of conduct has to be accepted and where to read it
+type inviteCodeOfConduct struct {
+	Version int64  `json:"version"`
+	URL     string `json:"url"`
+}
+
+func (h inviteHandler) codeOfConductURL() *url.URL {
+	// urlTo can't fill in the {slug} of the route
+	u := h.urlTo(router.CompleteIndex)
+	u.Path = "/page/" + codeOfConductSlug
+	u.RawQuery = ""
+	return u
 }
 
-func (h inviteHandler) buildJoinRoomURI(token string, userAgent string) template.URL {
+// acceptedCodeOfConduct returns the version that was accepted, or zero if the value isn't one
+func acceptedCodeOfConduct(v string) int64 {
+	version, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || version < 0 {
+		return 0
+	}
+	return version
+}
+
+func (h inviteHandler) buildJoinRoomURI(token string, userAgent string, acceptedVersion int64) template.URL {
 	queryVals := make(url.Values)
 	queryVals.Set("action", "claim-http-invite")
 	queryVals.Set("invite", token)
 
+	// apps don't know about the code of conduct, so the acceptance rides along with the address they post to
 	submissionURL := h.urlTo(router.CompleteInviteConsume)
+	if acceptedVersion != 0 {
+		submissionURL = h.urlTo(router.CompleteInviteConsume, codeOfConductParam, acceptedVersion)
+	}
 	queryVals.Set("postTo", submissionURL.String())
 
 	joinRoomURI := url.URL{
@@ -96,7 +139,21 @@ func (h inviteHandler) presentFacadeAsJSON(rw http.ResponseWriter, req *http.Req
 		Status string `json:"status"`
 		Invite string `json:"invite"`
 		PostTo string `json:"postTo"`
-	}{"success", token, postTo.String()}
+
+		// CodeOfConduct has to be accepted by sending its version with the invite, if there is one
+		CodeOfConduct *inviteCodeOfConduct `json:"codeOfConduct,omitempty"`
+	}{Status: "success", Invite: token, PostTo: postTo.String()}
+
+	current, err := h.codeOfConduct.Current(req.Context())
+	if err != nil {
+		level.Warn(logger).Log("event", "loading the code of conduct failed", "err", err)
+	} else if current.Version != 0 {
+		data.CodeOfConduct = &inviteCodeOfConduct{
+			Version: current.Version,
+			URL:     h.codeOfConductURL().String(),
+		}
+	}
+
 	if err := enc.Encode(data); err != nil {
 		level.Warn(logger).Log("event", "sending json response failed", "err", err)
 	}
@@ -117,10 +174,38 @@ func (h inviteHandler) presentFacadeAsHTML(rw http.ResponseWriter, req *http.Req
 	if err != nil {
 		return nil, fmt.Errorf("failed to find room's description: %w", err)
 	}
+	roomTitle := notice.Title
 
-	joinRoomURI := h.buildJoinRoomURI(token, req.UserAgent())
+	branding, err := h.config.GetBranding(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load room's branding: %w", err)
+	}
+	if branding.Name != "" {
+		roomTitle = branding.Name
+	}
+
+	// the code of conduct has to be accepted before the invite can be used
+	current, err := h.codeOfConduct.Current(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the code of conduct: %w", err)
+	}
+	accepted := acceptedCodeOfConduct(req.URL.Query().Get(codeOfConductParam))
+	if current.Version != 0 && accepted != current.Version {
+		return map[string]interface{}{
+			"RoomTitle":           roomTitle,
+			"Token":               token,
+			"AcceptCodeOfConduct": true,
+			"CodeOfConduct":       current.Version,
+			"CodeOfConductParam":  codeOfConductParam,
+		}, nil
+	}
+
+	joinRoomURI := h.buildJoinRoomURI(token, req.UserAgent(), accepted)
 
 	fallbackURL := h.urlTo(router.CompleteInviteFacadeFallback, "token", token)
+	if accepted != 0 {
+		fallbackURL = h.urlTo(router.CompleteInviteFacadeFallback, "token", token, codeOfConductParam, accepted)
+	}
 
 	// generate a QR code with the token inside so that you can open it easily in a supporting mobile app
 	thisURL := req.URL
@@ -146,7 +231,7 @@ func (h inviteHandler) presentFacadeAsHTML(rw http.ResponseWriter, req *http.Req
 
 	return map[string]interface{}{
 		csrf.TemplateTag: csrf.TemplateField(req),
-		"RoomTitle":      notice.Title,
+		"RoomTitle":      roomTitle,
 		"JoinRoomURI":    joinRoomURI,
 		"FallbackURL":    fallbackURL,
 		"QRCodeURI":      template.URL(qrURI),
@@ -165,6 +250,9 @@ func (h inviteHandler) presentFacadeFallback(rw http.ResponseWriter, req *http.R
 	}
 
 	insertURL := h.urlTo(router.CompleteInviteInsertID, "token", token)
+	if accepted := acceptedCodeOfConduct(req.URL.Query().Get(codeOfConductParam)); accepted != 0 {
+		insertURL = h.urlTo(router.CompleteInviteInsertID, "token", token, codeOfConductParam, accepted)
+	}
 
 	return map[string]interface{}{
 		csrf.TemplateTag: csrf.TemplateField(req),
@@ -183,15 +271,28 @@ func (h inviteHandler) presentInsert(rw http.ResponseWriter, req *http.Request)
 		return nil, err
 	}
 
+	current, err := h.codeOfConduct.Current(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the code of conduct: %w", err)
+	}
+
 	return map[string]interface{}{
 		csrf.TemplateTag: csrf.TemplateField(req),
 		"Token":          token,
+
+		// zero if there is nothing to accept
+		"CodeOfConduct":         current.Version,
+		"CodeOfConductParam":    codeOfConductParam,
+		"CodeOfConductAccepted": acceptedCodeOfConduct(req.URL.Query().Get(codeOfConductParam)) == current.Version,
 	}, nil
 }
 
 type inviteConsumePayload struct {
 	ID     refs.FeedRef `json:"id"`
 	Invite string       `json:"invite"`
+
+	// CodeOfConduct is the version of the code of conduct that the new member accepted
+	CodeOfConduct int64 `json:"codeOfConduct"`
 }
 
 func (h inviteHandler) consume(rw http.ResponseWriter, req *http.Request) {
@@ -201,6 +302,9 @@ func (h inviteHandler) consume(rw http.ResponseWriter, req *http.Request) {
 		token     string
 		newMember refs.FeedRef
 		resp      inviteConsumeResponder
+
+		// the version of the code of conduct that was accepted
+		acceptedVersion int64
 	)
 
 	ct := req.Header.Get("Content-Type")
@@ -220,6 +324,12 @@ func (h inviteHandler) consume(rw http.ResponseWriter, req *http.Request) {
 
 		newMember = body.ID
 		token = body.Invite
+
+		// apps that got the address from the invite page have it in there
+		acceptedVersion = body.CodeOfConduct
+		if acceptedVersion == 0 {
+			acceptedVersion = acceptedCodeOfConduct(req.URL.Query().Get(codeOfConductParam))
+		}
 	case "application/x-www-form-urlencoded":
 		resp = newinviteConsumeHTMLResponder(h.render, rw, req)
 
@@ -238,6 +348,8 @@ func (h inviteHandler) consume(rw http.ResponseWriter, req *http.Request) {
 			return
 		}
 		newMember = parsedID
+
+		acceptedVersion = acceptedCodeOfConduct(req.FormValue(codeOfConductParam))
 	default:
 		http.Error(rw, fmt.Sprintf("unhandled Content-Type (%q)", ct), http.StatusBadRequest)
 		return
@@ -249,6 +361,16 @@ func (h inviteHandler) consume(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	current, err := h.codeOfConduct.Current(req.Context())
+	if err != nil {
+		resp.SendError(err)
+		return
+	}
+	if current.Version != 0 && acceptedVersion != current.Version {
+		resp.SendError(roomdb.ErrCodeOfConductNotAccepted{Current: current.Version})
+		return
+	}
+
 	resp.UpdateMultiserverAddr(h.networkInfo.MultiserverAddress())
 
 	inv, err := h.invites.Consume(req.Context(), token, newMember)
@@ -257,15 +379,35 @@ func (h inviteHandler) consume(rw http.ResponseWriter, req *http.Request) {
 			resp.SendError(weberrors.ErrNotFound{What: "invite"})
 			return
 		}
+		if errors.Is(err, roomdb.ErrInviteForOtherFeed) {
+			resp.SendError(weberrors.ErrGenericLocalized{Label: "ErrorInviteForOtherFeed"})
+			return
+		}
 		resp.SendError(err)
 		return
 	}
 	log := logging.FromContext(req.Context())
 	level.Info(log).Log("event", "invite consumed", "id", inv.ID, "ref", newMember.ShortSigil())
 
+	if current.Version != 0 {
+		// the member is in already. If this fails, they are asked again when they sign in.
+		err = h.acceptCodeOfConduct(req.Context(), newMember, current.Version)
+		if err != nil {
+			level.Warn(log).Log("event", "failed to record the accepted code of conduct", "ref", newMember.ShortSigil(), "err", err)
+		}
+	}
+
 	resp.SendSuccess()
 }
 
+func (h inviteHandler) acceptCodeOfConduct(ctx context.Context, feed refs.FeedRef, version int64) error {
+	member, err := h.members.GetByFeed(ctx, feed)
+	if err != nil {
+		return err
+	}
+	return h.codeOfConduct.Accept(ctx, member.ID, version)
+}
+
 // inviteConsumeResponder is supposed to handle different encoding types transparently.
 // It either sends the rooms multiaddress on success or an error.
 type inviteConsumeResponder interface {
@@ -350,59 +492,240 @@ func (html inviteConsumeHTMLResponder) SendError(err error) {
 	html.renderer.Error(html.rw, html.req, http.StatusInternalServerError, err)
 }
 
-func (h inviteHandler) createOpenMode(rw http.ResponseWriter, r *http.Request) {
-	switch r.Header.Get("Accept") {
-	case "application/json":
-		if r.Method != http.MethodPost {
-			h.render.Error(rw, r, http.StatusBadRequest, errors.New("invalid method"))
+// openModeChallengeJSONResponse tells apps which proof-of-work to solve before they post to create an invite
+type openModeChallengeJSONResponse struct {
+	Status     string    `json:"status"`
+	Challenge  string    `json:"challenge"`
+	Difficulty uint      `json:"difficulty"`
+	Expires    time.Time `json:"expires"`
+	PostTo     string    `json:"postTo"`
+}
+
+// openModeSolutionPayload is what apps post back, the solution makes the SHA-256 hash of challenge:solution start with difficulty zero bits
+type openModeSolutionPayload struct {
+	Challenge string `json:"challenge"`
+	Solution  string `json:"solution"`
+}
+
+// createOpenMode lets anyone create an invite in open mode.
+// GET returns a proof-of-work challenge, which browsers solve with invite-challenge.js and apps on their own, before they POST the solution.
+// If the difficulty is 0, browsers get their invite right away, like before there was a challenge.
+func (h inviteHandler) createOpenMode(rw http.ResponseWriter, req *http.Request) {
+	isJSON := req.Header.Get("Accept") == "application/json"
+
+	// no challenges and no quota for rooms that don't let anyone create invites
+	pm, err := h.config.GetPrivacyMode(req.Context())
+	if err != nil {
+		h.sendOpenModeError(rw, req, isJSON, http.StatusInternalServerError, err)
+		return
+	}
+	if pm != roomdb.ModeOpen {
+		err = weberrors.ErrForbidden{Details: fmt.Errorf("room is not in open mode")}
+		h.sendOpenModeError(rw, req, isJSON, http.StatusForbidden, err)
+		return
+	}
+
+	limits, err := h.config.GetOpenInviteLimits(req.Context())
+	if err != nil {
+		h.sendOpenModeError(rw, req, isJSON, http.StatusInternalServerError, err)
+		return
+	}
+
+	if req.Method == http.MethodGet {
+		if isJSON {
+			h.sendOpenModeChallenge(rw, req, limits.Difficulty)
+			return
 		}
-		h.createOpenModeJSON(rw, r)
-	default:
-		if r.Method != http.MethodGet {
-			h.render.Error(rw, r, http.StatusBadRequest, errors.New("invalid method"))
+		if limits.Difficulty > 0 {
+			h.render.HTML("invite/open-challenge.tmpl", func(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+				c := h.challenges.Issue(limits.Difficulty)
+				return map[string]interface{}{
+					"Challenge":      c.Challenge,
+					"Difficulty":     c.Difficulty,
+					csrf.TemplateTag: csrf.TemplateField(req),
+				}, nil
+			})(rw, req)
+			return
+		}
+	} else if limits.Difficulty > 0 {
+		payload, err := decodeOpenModeSolution(req)
+		if err != nil {
+			h.sendOpenModeError(rw, req, isJSON, http.StatusBadRequest, err)
+			return
+		}
+
+		err = h.challenges.Redeem(payload.Challenge, payload.Solution)
+		if err != nil {
+			logger := logging.FromContext(req.Context())
+			level.Info(logger).Log("event", "open invite challenge failed", "err", err)
+			h.sendOpenModeError(rw, req, isJSON, http.StatusForbidden, weberrors.ErrGenericLocalized{Label: "ErrorInviteChallengeFailed"})
+			return
 		}
-		h.render.HTML("admin/invite-created.tmpl", h.createOpenModeHTML)(rw, r)
 	}
-}
 
-func (h inviteHandler) createOpenModeHTML(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
-	ctx := req.Context()
+	addr := quotaAddress(req)
+	if !h.openModeQuota.take(addr, limits.DailyPerAddress) {
+		h.sendOpenModeError(rw, req, isJSON, http.StatusTooManyRequests, weberrors.ErrGenericLocalized{Label: "ErrorOpenInviteDailyLimit"})
+		return
+	}
 
-	token, err := h.invites.Create(ctx, -1)
+	token, err := h.invites.Create(req.Context(), -1)
 	if err != nil {
-		return nil, err
+		h.openModeQuota.giveBack(addr, limits.DailyPerAddress)
+		h.sendOpenModeError(rw, req, isJSON, http.StatusInternalServerError, err)
+		return
 	}
 
 	facadeURL := h.urlTo(router.CompleteInviteFacade, "token", token)
 
-	return map[string]interface{}{
-		"FacadeURL": facadeURL.String(),
-	}, nil
+	if !isJSON {
+		h.render.HTML("admin/invite-created.tmpl", func(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+			return map[string]interface{}{
+				"FacadeURL": facadeURL.String(),
+			}, nil
+		})(rw, req)
+		return
+	}
+
+	response := map[string]string{
+		"url": facadeURL.String(),
+	}
+
+	if err := json.NewEncoder(rw).Encode(response); err != nil {
+		logger := logging.FromContext(req.Context())
+		level.Warn(logger).Log("event", "sending json response failed", "err", err)
+	}
 }
 
-func (h inviteHandler) createOpenModeJSON(rw http.ResponseWriter, req *http.Request) {
-	logger := logging.FromContext(req.Context())
-	ctx := req.Context()
-	enc := json.NewEncoder(rw)
+func (h inviteHandler) sendOpenModeChallenge(rw http.ResponseWriter, req *http.Request, difficulty uint) {
+	c := h.challenges.Issue(difficulty)
 
-	token, err := h.invites.Create(ctx, -1)
+	rw.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(rw).Encode(openModeChallengeJSONResponse{
+		Status:     "challenge",
+		Challenge:  c.Challenge,
+		Difficulty: c.Difficulty,
+		Expires:    c.Expires,
+		PostTo:     h.urlTo(router.OpenModeCreateInvite).String(),
+	})
 	if err != nil {
-		data := struct {
-			Status string `json:"status"`
-			Error  string `json:"error"`
-		}{"failed", err.Error()}
-		rw.WriteHeader(http.StatusInternalServerError)
-		if err := enc.Encode(data); err != nil {
-			level.Warn(logger).Log("event", "sending json error failed", "err", err)
+		logger := logging.FromContext(req.Context())
+		level.Warn(logger).Log("event", "sending json response failed", "err", err)
+	}
+}
+
+func (h inviteHandler) sendOpenModeError(rw http.ResponseWriter, req *http.Request, isJSON bool, code int, err error) {
+	if !isJSON {
+		h.render.Error(rw, req, code, err)
+		return
+	}
+
+	data := struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}{"failed", err.Error()}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	if err := json.NewEncoder(rw).Encode(data); err != nil {
+		logger := logging.FromContext(req.Context())
+		level.Warn(logger).Log("event", "sending json error failed", "err", err)
+	}
+}
+
+// decodeOpenModeSolution reads the solution from the JSON body of apps or the form of the challenge page
+func decodeOpenModeSolution(req *http.Request) (openModeSolutionPayload, error) {
+	var payload openModeSolutionPayload
+
+	if req.Header.Get("Content-Type") == "application/json" {
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return payload, weberrors.ErrBadRequest{Where: "json body", Details: err}
 		}
+		return payload, nil
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return payload, weberrors.ErrBadRequest{Where: "form data", Details: err}
+	}
+	payload.Challenge = req.FormValue("challenge")
+	payload.Solution = req.FormValue("solution")
+	return payload, nil
+}
+
+// maxOpenInviteAddresses is how many addresses openInviteQuota keeps track of per day.
+// Once that many created invites, others have to wait for the next day, so that the map can't grow without bounds.
+const maxOpenInviteAddresses = 10000
+
+// openInviteQuota counts the invites each IP address created in open mode today.
+// It only lives in memory, so that the addresses don't end up in the database.
+type openInviteQuota struct {
+	mu     sync.Mutex
+	day    string
+	counts map[string]uint
+}
+
+// take counts another invite for the address, unless it already has limit of them today. A limit of 0 means no limit.
+func (q *openInviteQuota) take(addr string, limit uint) bool {
+	if limit == 0 {
+		return true
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	today := time.Now().UTC().Format("2006-01-02")
+	if q.day != today {
+		q.day = today
+		q.counts = make(map[string]uint)
+	}
+
+	count, has := q.counts[addr]
+	if !has && len(q.counts) >= maxOpenInviteAddresses {
+		return false
+	}
+	if count >= limit {
+		return false
+	}
+	q.counts[addr] = count + 1
+	return true
+}
+
+// giveBack returns an invite that was taken but not created
+func (q *openInviteQuota) giveBack(addr string, limit uint) {
+	if limit == 0 {
 		return
 	}
 
-	response := map[string]string{
-		"url": h.urlTo(router.CompleteInviteFacade, "token", token).String(),
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// nothing to give back if the day changed in between
+	count := q.counts[addr]
+	if count <= 1 {
+		delete(q.counts, addr)
+		return
 	}
+	q.counts[addr] = count - 1
+}
 
-	if err := enc.Encode(response); err != nil {
-		level.Warn(logger).Log("event", "sending json response failed", "err", err)
+// quotaAddress is what openInviteQuota counts for the request.
+// It drops the port, which changes with every connection.
+// IPv6 addresses are counted by their /64 network, because that is what one host usually gets to pick addresses from.
+// The RemoteAddr was already resolved by the trusted proxies of the server, if it is behind one.
+func quotaAddress(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
 	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return host
+	}
+
+	if ip.To4() != nil {
+		return ip.String()
+	}
+
+	network := net.IPNet{IP: ip.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}
+	return network.String()
 }
@@ -8,16 +8,20 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/hashcash"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
 	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
 	"github.com/ssbc/go-ssb-room/v2/web/router"
@@ -297,6 +301,17 @@ func TestInviteConsumeInviteJSON(t *testing.T) {
 	gotRA := jsonConsumeResp.RoomAddress
 	a.True(strings.HasPrefix(gotRA, "net:localhost:8008~shs:"), "not for the test host: %s", gotRA)
 	a.True(strings.HasSuffix(gotRA, base64.StdEncoding.EncodeToString(ts.NetworkInfo.RoomID.PubKey())), "public key missing? %s", gotRA)
+
+	// the invite of someone else's join request
+	ts.InvitesDB.ConsumeReturns(roomdb.Invite{}, roomdb.ErrInviteForOtherFeed)
+
+	resp = ts.Client.SendJSON(consumeInviteURL, consume)
+	a.Equal(http.StatusOK, resp.Code)
+
+	var failed struct{ Status, Error string }
+	r.NoError(json.NewDecoder(resp.Body).Decode(&failed))
+	a.Equal("error", failed.Status)
+	a.Contains(failed.Error, "ErrorInviteForOtherFeed")
 }
 
 func TestInviteConsumptionDenied(t *testing.T) {
@@ -348,6 +363,7 @@ func TestOpenModeCreateInviteHTML(t *testing.T) {
 	ts := setup(t)
 	r := require.New(t)
 
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeOpen, nil)
 	someToken := "fake-token"
 	ts.InvitesDB.CreateReturns(someToken, nil)
 
@@ -364,6 +380,7 @@ func TestOpenModeCreateInviteJSON(t *testing.T) {
 	ts := setup(t)
 	r := require.New(t)
 
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeOpen, nil)
 	someToken := "fake-token"
 	ts.InvitesDB.CreateReturns(someToken, nil)
 
@@ -382,3 +399,237 @@ func TestOpenModeCreateInviteJSON(t *testing.T) {
 
 	require.Contains(t, response["url"], someToken)
 }
+
+func TestOpenModeCreateInviteChallengeJSON(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeOpen, nil)
+	ts.ConfigDB.GetOpenInviteLimitsReturns(roomdb.OpenInviteLimits{Difficulty: 8, DailyPerAddress: 2}, nil)
+	ts.InvitesDB.CreateReturns("fake-token", nil)
+
+	createURL := ts.URLTo(router.OpenModeCreateInvite)
+
+	getChallenge := func() openModeChallengeJSONResponse {
+		req, err := http.NewRequest("GET", createURL.String(), nil)
+		r.NoError(err)
+		req.Header.Set("Accept", "application/json")
+
+		recorder := httptest.NewRecorder()
+		ts.Mux.ServeHTTP(recorder, req)
+		r.Equal(http.StatusOK, recorder.Code)
+
+		var challenge openModeChallengeJSONResponse
+		err = json.NewDecoder(recorder.Body).Decode(&challenge)
+		r.NoError(err)
+		return challenge
+	}
+
+	postSolution := func(payload openModeSolutionPayload) *httptest.ResponseRecorder {
+		body, err := json.Marshal(payload)
+		r.NoError(err)
+
+		req, err := http.NewRequest("POST", createURL.String(), bytes.NewReader(body))
+		r.NoError(err)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		ts.Mux.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	challenge := getChallenge()
+	a.Equal("challenge", challenge.Status)
+	a.EqualValues(8, challenge.Difficulty)
+	a.Equal(createURL.String(), challenge.PostTo)
+	a.Equal(0, ts.InvitesDB.CreateCallCount(), "no invite before the solution")
+
+	// a wrong solution
+	wrong := "x"
+	for hashcash.Check(challenge.Challenge, wrong, challenge.Difficulty) {
+		wrong += "x"
+	}
+	rec := postSolution(openModeSolutionPayload{Challenge: challenge.Challenge, Solution: wrong})
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(0, ts.InvitesDB.CreateCallCount())
+
+	// solved
+	solution := hashcash.Solve(challenge.Challenge, challenge.Difficulty)
+	rec = postSolution(openModeSolutionPayload{Challenge: challenge.Challenge, Solution: solution})
+	r.Equal(http.StatusOK, rec.Code)
+	response := map[string]string{}
+	r.NoError(json.NewDecoder(rec.Body).Decode(&response))
+	a.Contains(response["url"], "fake-token")
+	a.Equal(1, ts.InvitesDB.CreateCallCount())
+
+	// each challenge works only once
+	rec = postSolution(openModeSolutionPayload{Challenge: challenge.Challenge, Solution: solution})
+	a.Equal(http.StatusForbidden, rec.Code)
+	a.Equal(1, ts.InvitesDB.CreateCallCount())
+
+	// the second invite of the day
+	challenge = getChallenge()
+	rec = postSolution(openModeSolutionPayload{Challenge: challenge.Challenge, Solution: hashcash.Solve(challenge.Challenge, challenge.Difficulty)})
+	a.Equal(http.StatusOK, rec.Code)
+	a.Equal(2, ts.InvitesDB.CreateCallCount())
+
+	// but not a third one
+	challenge = getChallenge()
+	rec = postSolution(openModeSolutionPayload{Challenge: challenge.Challenge, Solution: hashcash.Solve(challenge.Challenge, challenge.Difficulty)})
+	a.Equal(http.StatusTooManyRequests, rec.Code)
+	var failed struct{ Status string }
+	r.NoError(json.NewDecoder(rec.Body).Decode(&failed))
+	a.Equal("failed", failed.Status)
+	a.Equal(2, ts.InvitesDB.CreateCallCount())
+}
+
+func TestOpenModeCreateInviteChallengeHTML(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeOpen, nil)
+	ts.ConfigDB.GetOpenInviteLimitsReturns(roomdb.OpenInviteLimits{Difficulty: 8}, nil)
+	ts.InvitesDB.CreateReturns("fake-token", nil)
+
+	createURL := ts.URLTo(router.OpenModeCreateInvite)
+
+	doc, resp := ts.Client.GetHTML(createURL)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal(0, ts.InvitesDB.CreateCallCount(), "no invite before the solution")
+
+	webassert.Localized(t, doc, []webassert.LocalizedElement{
+		{"#welcome", "InviteChallengeWelcome"},
+		{"title", "InviteChallengeTitle"},
+	})
+
+	form := doc.Find("form#invite-challenge")
+	r.Equal(1, form.Length())
+	a.Equal(createURL.Path, form.AttrOr("action", ""))
+	a.Equal("8", form.AttrOr("data-difficulty", ""))
+	webassert.CSRFTokenPresent(t, form)
+
+	challenge, has := form.Find(`input[name="challenge"]`).Attr("value")
+	r.True(has, "should have the challenge")
+
+	csrfTokenElem := form.Find(`input[name="gorilla.csrf.Token"]`)
+	csrfName, has := csrfTokenElem.Attr("name")
+	a.True(has, "should have a name attribute")
+	csrfValue, has := csrfTokenElem.Attr("value")
+	a.True(has, "should have value attribute")
+
+	var csrfCookieHeader = http.Header(map[string][]string{})
+	csrfCookieHeader.Set("Referer", "https://localhost")
+	ts.Client.SetHeaders(csrfCookieHeader)
+
+	// what invite-challenge.js does
+	vals := url.Values{
+		"challenge": []string{challenge},
+		"solution":  []string{hashcash.Solve(challenge, 8)},
+
+		csrfName: []string{csrfValue},
+	}
+	rec := ts.Client.PostForm(createURL, vals)
+	r.Equal(http.StatusOK, rec.Code)
+	a.Equal(1, ts.InvitesDB.CreateCallCount())
+
+	doc, err := goquery.NewDocumentFromReader(rec.Body)
+	r.NoError(err)
+	a.Contains(doc.Find("#invite-facade-link").AttrOr("href", ""), "fake-token")
+}
+
+func TestOpenModeCreateInviteNotOpen(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeCommunity, nil)
+	ts.ConfigDB.GetOpenInviteLimitsReturns(roomdb.OpenInviteLimits{Difficulty: 8}, nil)
+
+	createURL := ts.URLTo(router.OpenModeCreateInvite)
+
+	// no challenge for apps
+	req, err := http.NewRequest("GET", createURL.String(), nil)
+	r.NoError(err)
+	req.Header.Set("Accept", "application/json")
+
+	recorder := httptest.NewRecorder()
+	ts.Mux.ServeHTTP(recorder, req)
+	a.Equal(http.StatusForbidden, recorder.Code)
+	var failed struct{ Status string }
+	r.NoError(json.NewDecoder(recorder.Body).Decode(&failed))
+	a.Equal("failed", failed.Status)
+
+	// and no challenge page for browsers
+	doc, resp := ts.Client.GetHTML(createURL)
+	a.Equal(http.StatusForbidden, resp.Code)
+	a.Equal(0, doc.Find("form#invite-challenge").Length())
+
+	a.Equal(0, ts.ConfigDB.GetOpenInviteLimitsCallCount())
+	a.Equal(0, ts.InvitesDB.CreateCallCount())
+}
+
+func TestOpenModeCreateInviteFailedKeepsQuota(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeOpen, nil)
+	ts.ConfigDB.GetOpenInviteLimitsReturns(roomdb.OpenInviteLimits{DailyPerAddress: 1}, nil)
+	ts.InvitesDB.CreateReturnsOnCall(0, "", errors.New("database is gone"))
+	ts.InvitesDB.CreateReturnsOnCall(1, "fake-token", nil)
+
+	createURL := ts.URLTo(router.OpenModeCreateInvite)
+
+	_, resp := ts.Client.GetHTML(createURL)
+	a.Equal(http.StatusInternalServerError, resp.Code)
+
+	// the failed one didn't use up the only invite of the day
+	doc, resp := ts.Client.GetHTML(createURL)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Contains(doc.Find("#invite-facade-link").AttrOr("href", ""), "fake-token")
+	a.Equal(2, ts.InvitesDB.CreateCallCount())
+}
+
+func TestOpenInviteQuota(t *testing.T) {
+	a := assert.New(t)
+
+	var q openInviteQuota
+	a.True(q.take("192.0.2.1", 2))
+	a.True(q.take("192.0.2.1", 2))
+	a.False(q.take("192.0.2.1", 2), "over the limit")
+	a.True(q.take("192.0.2.2", 2), "other addresses have their own")
+	a.True(q.take("192.0.2.1", 0), "no limit")
+
+	// a full map doesn't take new addresses, but the ones it knows still count
+	for i := len(q.counts); i < maxOpenInviteAddresses; i++ {
+		q.counts[fmt.Sprintf("address-%d", i)] = 1
+	}
+	a.False(q.take("192.0.2.3", 2))
+	a.True(q.take("192.0.2.2", 2))
+
+	// invites that weren't created don't count
+	a.False(q.take("192.0.2.2", 2))
+	q.giveBack("192.0.2.2", 2)
+	a.True(q.take("192.0.2.2", 2))
+	q.giveBack("192.0.2.2", 2)
+	q.giveBack("192.0.2.2", 2)
+	_, has := q.counts["192.0.2.2"]
+	a.False(has, "unused addresses are dropped")
+}
+
+func TestQuotaAddress(t *testing.T) {
+	a := assert.New(t)
+
+	addrFor := func(remote string) string {
+		req := httptest.NewRequest("GET", "/create-invite", nil)
+		req.RemoteAddr = remote
+		return quotaAddress(req)
+	}
+
+	a.Equal("192.0.2.1", addrFor("192.0.2.1:1234"))
+	a.Equal("192.0.2.1", addrFor("192.0.2.1"))
+
+	// the hosts of a /64 share their quota
+	a.Equal("2001:db8:1:2::/64", addrFor("[2001:db8:1:2::1]:1234"))
+	a.Equal("2001:db8:1:2::/64", addrFor("[2001:db8:1:2:aaaa:bbbb:cccc:dddd]:4321"))
+	a.Equal("2001:db8:1:3::/64", addrFor("[2001:db8:1:3::1]:1234"))
+}
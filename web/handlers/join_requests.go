@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/gorilla/csrf"
+	"go.mindeco.de/http/render"
+	"go.mindeco.de/log/level"
+	"go.mindeco.de/logging"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+)
+
+// maxJoinRequestMessage is how many characters the message to the moderators can have
+const maxJoinRequestMessage = 1000
+
+// joinRequestsHandler lets people who aren't members yet ask to join community and restricted rooms
+type joinRequestsHandler struct {
+	render      *render.Renderer
+	urlTo       web.URLMaker
+	networkInfo network.ServerEndpointDetails
+
+	config       roomdb.RoomConfig
+	joinRequests roomdb.JoinRequestsService
+	members      roomdb.MembersService
+	deniedKeys   roomdb.DeniedKeysService
+}
+
+func (h joinRequestsHandler) form(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	pm, err := h.config.GetPrivacyMode(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		// in open rooms everyone can create an invite instead
+		"OpenMode": pm == roomdb.ModeOpen,
+
+		"MaxMessage":     maxJoinRequestMessage,
+		csrf.TemplateTag: csrf.TemplateField(req),
+	}, nil
+}
+
+// joinRequestPayload is what apps send to the create endpoint as JSON
+type joinRequestPayload struct {
+	ID      refs.FeedRef `json:"id"`
+	Message string       `json:"message"`
+}
+
+// joinRequestCreatedJSONResponse has the token to check the status with and where to do that
+type joinRequestCreatedJSONResponse struct {
+	Status    string `json:"status"`
+	Token     string `json:"token"`
+	StatusURL string `json:"statusURL"`
+}
+
+func (h joinRequestsHandler) create(rw http.ResponseWriter, req *http.Request) {
+	var (
+		payload joinRequestPayload
+		isJSON  bool
+	)
+
+	ct := req.Header.Get("Content-Type")
+	switch ct {
+	case "application/json":
+		isJSON = true
+
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		if err != nil {
+			h.sendJSONError(rw, req, weberrors.ErrBadRequest{Where: "json body", Details: err})
+			return
+		}
+	case "application/x-www-form-urlencoded":
+		if err := req.ParseForm(); err != nil {
+			h.render.Error(rw, req, http.StatusBadRequest, weberrors.ErrBadRequest{Where: "form data", Details: err})
+			return
+		}
+
+		parsedID, err := refs.ParseFeedRef(req.FormValue("id"))
+		if err != nil {
+			h.render.Error(rw, req, http.StatusBadRequest, weberrors.ErrBadRequest{Where: "id", Details: err})
+			return
+		}
+		payload.ID = parsedID
+		payload.Message = req.FormValue("message")
+	default:
+		http.Error(rw, fmt.Sprintf("unhandled Content-Type (%q)", ct), http.StatusBadRequest)
+		return
+	}
+
+	token, err := h.add(req, payload)
+	if err != nil {
+		if isJSON {
+			h.sendJSONError(rw, req, err)
+		} else {
+			h.render.Error(rw, req, http.StatusBadRequest, err)
+		}
+		return
+	}
+
+	logger := logging.FromContext(req.Context())
+	level.Info(logger).Log("event", "join request created", "ref", payload.ID.ShortSigil())
+
+	statusURL := h.urlTo(router.CompleteJoinRequestStatus, "token", token)
+
+	if !isJSON {
+		http.Redirect(rw, req, statusURL.String(), http.StatusSeeOther)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(rw).Encode(joinRequestCreatedJSONResponse{
+		Status:    "pending",
+		Token:     token,
+		StatusURL: statusURL.String(),
+	})
+	if err != nil {
+		level.Warn(logger).Log("event", "sending json response failed", "err", err)
+	}
+}
+
+// add checks the request and puts it into the moderation queue
+func (h joinRequestsHandler) add(req *http.Request, payload joinRequestPayload) (string, error) {
+	ctx := req.Context()
+
+	pm, err := h.config.GetPrivacyMode(ctx)
+	if err != nil {
+		return "", err
+	}
+	if pm == roomdb.ModeOpen {
+		return "", weberrors.ErrGenericLocalized{Label: "ErrorJoinRequestsOpenMode"}
+	}
+
+	if _, err := refs.ParseFeedRef(payload.ID.String()); err != nil {
+		return "", weberrors.ErrBadRequest{Where: "id", Details: err}
+	}
+
+	if n := utf8.RuneCountInString(payload.Message); n > maxJoinRequestMessage {
+		err = fmt.Errorf("the message has %d characters but only %d are allowed", n, maxJoinRequestMessage)
+		return "", weberrors.ErrBadRequest{Where: "message", Details: err}
+	}
+
+	if h.deniedKeys.HasFeed(ctx, payload.ID) {
+		return "", weberrors.ErrDenied
+	}
+
+	_, err = h.members.GetByFeed(ctx, payload.ID)
+	if err == nil {
+		return "", weberrors.ErrGenericLocalized{Label: "ErrorJoinRequestAlreadyMember"}
+	} else if !errors.Is(err, roomdb.ErrNotFound) {
+		return "", err
+	}
+
+	return h.joinRequests.Create(ctx, payload.ID, payload.Message)
+}
+
+func (h joinRequestsHandler) sendJSONError(rw http.ResponseWriter, req *http.Request, err error) {
+	rw.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(rw).Encode(struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}{"error", err.Error()})
+	if err != nil {
+		logger := logging.FromContext(req.Context())
+		level.Warn(logger).Log("event", "sending json error failed", "err", err)
+	}
+}
+
+// joinRequestStatusJSONResponse tells the requester what the moderators decided
+type joinRequestStatusJSONResponse struct {
+	// Status is pending, approved or declined
+	Status string `json:"status"`
+
+	// Invite is the address of the personal invite, if it was approved with one.
+	// It is only sent once, after that InviteHandedOut is set instead.
+	Invite          string `json:"invite,omitempty"`
+	InviteHandedOut bool   `json:"inviteHandedOut,omitempty"`
+
+	// RoomAddress is set if it was approved by adding the feed as a member
+	RoomAddress string `json:"multiserverAddress,omitempty"`
+}
+
+// switch between JSON and HTML responses, like the invite facade
+func (h joinRequestsHandler) status(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Query().Get("encoding") == "json" {
+		h.statusAsJSON(rw, req)
+		return
+	}
+
+	h.render.HTML("join-request/status.tmpl", h.statusAsHTML)(rw, req)
+}
+
+func (h joinRequestsHandler) statusAsJSON(rw http.ResponseWriter, req *http.Request) {
+	token := req.URL.Query().Get("token")
+
+	jr, err := h.joinRequests.GetByToken(req.Context(), token)
+	if err != nil {
+		h.sendJSONError(rw, req, err)
+		return
+	}
+
+	resp, err := h.statusResponse(req.Context(), token, jr)
+	if err != nil {
+		h.sendJSONError(rw, req, err)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(rw).Encode(resp)
+	if err != nil {
+		logger := logging.FromContext(req.Context())
+		level.Warn(logger).Log("event", "sending json response failed", "err", err)
+	}
+}
+
+func (h joinRequestsHandler) statusAsHTML(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	token := req.URL.Query().Get("token")
+
+	jr, err := h.joinRequests.GetByToken(req.Context(), token)
+	if err != nil {
+		if errors.Is(err, roomdb.ErrNotFound) {
+			return nil, weberrors.ErrNotFound{What: "join request"}
+		}
+		return nil, weberrors.ErrBadRequest{Where: "token", Details: err}
+	}
+
+	resp, err := h.statusResponse(req.Context(), token, jr)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"Request":  jr,
+		"Response": resp,
+	}, nil
+}
+
+// statusResponse hands out the personal invite, the first time the status of a request that was approved with one is checked.
+// Only the requester knows the status token, so only they get the invite.
+func (h joinRequestsHandler) statusResponse(ctx context.Context, token string, jr roomdb.JoinRequest) (joinRequestStatusJSONResponse, error) {
+	resp := joinRequestStatusJSONResponse{Status: jr.Status.String()}
+
+	if jr.Status != roomdb.JoinRequestApproved {
+		return resp, nil
+	}
+
+	if !jr.WithInvite {
+		resp.RoomAddress = h.networkInfo.MultiserverAddress()
+		return resp, nil
+	}
+
+	if jr.InviteID != 0 {
+		resp.InviteHandedOut = true
+		return resp, nil
+	}
+
+	inviteToken, err := h.joinRequests.HandOutInvite(ctx, token)
+	if err != nil {
+		if errors.Is(err, roomdb.ErrNotFound) {
+			// another check of the status got it in the meantime
+			resp.InviteHandedOut = true
+			return resp, nil
+		}
+		return resp, err
+	}
+
+	resp.Invite = h.urlTo(router.CompleteInviteFacade, "token", inviteToken).String()
+	return resp, nil
+}
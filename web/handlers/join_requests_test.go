@@ -0,0 +1,279 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	refs "github.com/ssbc/go-ssb-refs"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+	"github.com/ssbc/go-ssb-room/v2/web/webassert"
+)
+
+func TestJoinRequestForm(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	formURL := ts.URLTo(router.CompleteJoinRequestForm)
+
+	// signed out visitors find the form from every page
+	doc, resp := ts.Client.GetHTML(ts.URLTo(router.CompleteIndex))
+	a.Equal(http.StatusOK, resp.Code)
+	link, has := doc.Find("#request-to-join").Attr("href")
+	a.True(has, "should have a link to the form")
+	a.Equal(formURL.Path, link)
+
+	doc, resp = ts.Client.GetHTML(formURL)
+	a.Equal(http.StatusOK, resp.Code)
+
+	webassert.Localized(t, doc, []webassert.LocalizedElement{
+		{"#welcome", "JoinRequestWelcome"},
+		{"title", "JoinRequestTitle"},
+	})
+
+	form := doc.Find("form#join-request")
+	r.Equal(1, form.Length())
+
+	action, has := form.Attr("action")
+	a.True(has, "form should have an action attribute")
+	a.Equal(ts.URLTo(router.CompleteJoinRequestCreate).Path, action)
+
+	webassert.CSRFTokenPresent(t, form)
+	webassert.ElementsInForm(t, form, []webassert.FormElement{
+		{Name: "id", Type: "text"},
+	})
+	a.Equal(1, form.Find("textarea[name=message]").Length())
+
+	// open rooms point to the invite creation instead
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeOpen, nil)
+
+	doc, resp = ts.Client.GetHTML(formURL)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal(0, doc.Find("form#join-request").Length())
+	a.Equal(1, doc.Find("#create-invite").Length())
+}
+
+func TestJoinRequestCreateHTTP(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	// request the form (for a valid csrf token)
+	doc, resp := ts.Client.GetHTML(ts.URLTo(router.CompleteJoinRequestForm))
+	a.Equal(http.StatusOK, resp.Code)
+
+	csrfTokenElem := doc.Find(`form#join-request input[name="gorilla.csrf.Token"]`)
+	r.Equal(1, csrfTokenElem.Length())
+	csrfName, has := csrfTokenElem.Attr("name")
+	a.True(has, "should have a name attribute")
+	csrfValue, has := csrfTokenElem.Attr("value")
+	a.True(has, "should have value attribute")
+
+	testRef, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{1}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	vals := url.Values{
+		"id":      []string{testRef.String()},
+		"message": []string{"hi, i know alice"},
+
+		csrfName: []string{csrfValue},
+	}
+
+	var csrfCookieHeader = http.Header(map[string][]string{})
+	csrfCookieHeader.Set("Referer", "https://localhost")
+	ts.Client.SetHeaders(csrfCookieHeader)
+
+	ts.MembersDB.GetByFeedReturns(roomdb.Member{}, roomdb.ErrNotFound)
+	ts.JoinRequestsDB.CreateReturns("requested-token", nil)
+
+	resp = ts.Client.PostForm(ts.URLTo(router.CompleteJoinRequestCreate), vals)
+	a.Equal(http.StatusSeeOther, resp.Code)
+
+	statusURL := ts.URLTo(router.CompleteJoinRequestStatus, "token", "requested-token")
+	a.Equal(statusURL.String(), resp.Header().Get("Location"))
+
+	r.Equal(1, ts.JoinRequestsDB.CreateCallCount())
+	_, ref, msg := ts.JoinRequestsDB.CreateArgsForCall(0)
+	a.True(ref.Equal(testRef))
+	a.Equal("hi, i know alice", msg)
+}
+
+func TestJoinRequestCreateJSON(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	createURL := ts.URLTo(router.CompleteJoinRequestCreate)
+
+	testRef, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{2}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	var payload joinRequestPayload
+	payload.ID = testRef
+	payload.Message = "from my app"
+
+	ts.MembersDB.GetByFeedReturns(roomdb.Member{}, roomdb.ErrNotFound)
+	ts.JoinRequestsDB.CreateReturns("json-token", nil)
+
+	resp := ts.Client.SendJSON(createURL, payload)
+	a.Equal(http.StatusOK, resp.Code)
+
+	var created joinRequestCreatedJSONResponse
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	r.NoError(err)
+	a.Equal("pending", created.Status)
+	a.Equal("json-token", created.Token)
+	a.Equal(ts.URLTo(router.CompleteJoinRequestStatus, "token", "json-token").String(), created.StatusURL)
+
+	r.Equal(1, ts.JoinRequestsDB.CreateCallCount())
+	_, ref, msg := ts.JoinRequestsDB.CreateArgsForCall(0)
+	a.True(ref.Equal(testRef))
+	a.Equal("from my app", msg)
+
+	type errorReply struct {
+		Status string
+		Error  string
+	}
+
+	// too long
+	payload.Message = strings.Repeat("a", maxJoinRequestMessage+1)
+	resp = ts.Client.SendJSON(createURL, payload)
+	var reply errorReply
+	r.NoError(json.NewDecoder(resp.Body).Decode(&reply))
+	a.Equal("error", reply.Status)
+	payload.Message = "from my app"
+
+	// denied keys can't ask again
+	ts.DeniedKeysDB.HasFeedReturns(true)
+	resp = ts.Client.SendJSON(createURL, payload)
+	reply = errorReply{}
+	r.NoError(json.NewDecoder(resp.Body).Decode(&reply))
+	a.Equal("error", reply.Status)
+	ts.DeniedKeysDB.HasFeedReturns(false)
+
+	// members don't need to
+	ts.MembersDB.GetByFeedReturns(roomdb.Member{ID: 23, PubKey: testRef}, nil)
+	resp = ts.Client.SendJSON(createURL, payload)
+	reply = errorReply{}
+	r.NoError(json.NewDecoder(resp.Body).Decode(&reply))
+	a.Equal("error", reply.Status)
+	ts.MembersDB.GetByFeedReturns(roomdb.Member{}, roomdb.ErrNotFound)
+
+	// open rooms don't take requests
+	ts.ConfigDB.GetPrivacyModeReturns(roomdb.ModeOpen, nil)
+	resp = ts.Client.SendJSON(createURL, payload)
+	reply = errorReply{}
+	r.NoError(json.NewDecoder(resp.Body).Decode(&reply))
+	a.Equal("error", reply.Status)
+
+	a.Equal(1, ts.JoinRequestsDB.CreateCallCount(), "only the first request should have been created")
+}
+
+func TestJoinRequestStatus(t *testing.T) {
+	ts := setup(t)
+	a, r := assert.New(t), require.New(t)
+
+	testRef, err := refs.NewFeedRefFromBytes(bytes.Repeat([]byte{3}, 32), refs.RefAlgoFeedSSB1)
+	r.NoError(err)
+
+	statusURL := ts.URLTo(router.CompleteJoinRequestStatus, "token", "status-token")
+	jsonURL := ts.URLTo(router.CompleteJoinRequestStatus, "token", "status-token", "encoding", "json")
+
+	getStatus := func() joinRequestStatusJSONResponse {
+		resp := ts.Client.GetBody(jsonURL)
+		a.Equal(http.StatusOK, resp.Code)
+
+		var status joinRequestStatusJSONResponse
+		err := json.NewDecoder(resp.Body).Decode(&status)
+		r.NoError(err)
+		return status
+	}
+
+	// still waiting
+	ts.JoinRequestsDB.GetByTokenReturns(roomdb.JoinRequest{ID: 1, PubKey: testRef, Status: roomdb.JoinRequestPending}, nil)
+
+	status := getStatus()
+	a.Equal("pending", status.Status)
+	a.Equal("", status.Invite)
+	a.Equal("", status.RoomAddress)
+
+	_, token := ts.JoinRequestsDB.GetByTokenArgsForCall(0)
+	a.Equal("status-token", token)
+
+	doc, resp := ts.Client.GetHTML(statusURL)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal("pending", doc.Find("#join-request-status").AttrOr("data-status", ""))
+
+	a.Equal(0, ts.JoinRequestsDB.HandOutInviteCallCount())
+
+	// approved with an invite, which is created when the requester checks
+	ts.JoinRequestsDB.GetByTokenReturns(roomdb.JoinRequest{ID: 1, PubKey: testRef, Status: roomdb.JoinRequestApproved, WithInvite: true}, nil)
+	ts.JoinRequestsDB.HandOutInviteReturns("invite-token", nil)
+	facadeURL := ts.URLTo(router.CompleteInviteFacade, "token", "invite-token")
+
+	status = getStatus()
+	a.Equal("approved", status.Status)
+	a.Equal(facadeURL.String(), status.Invite)
+	a.False(status.InviteHandedOut)
+	a.Equal("", status.RoomAddress)
+
+	r.Equal(1, ts.JoinRequestsDB.HandOutInviteCallCount())
+	_, token = ts.JoinRequestsDB.HandOutInviteArgsForCall(0)
+	a.Equal("status-token", token)
+
+	doc, resp = ts.Client.GetHTML(statusURL)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal(facadeURL.String(), doc.Find("#join-request-invite").AttrOr("href", ""))
+
+	// another check got it in the meantime
+	ts.JoinRequestsDB.HandOutInviteReturns("", roomdb.ErrNotFound)
+
+	status = getStatus()
+	a.Equal("approved", status.Status)
+	a.Equal("", status.Invite)
+	a.True(status.InviteHandedOut)
+
+	// it isn't shown again
+	ts.JoinRequestsDB.GetByTokenReturns(roomdb.JoinRequest{ID: 1, PubKey: testRef, Status: roomdb.JoinRequestApproved, WithInvite: true, InviteID: 5}, nil)
+
+	status = getStatus()
+	a.Equal("approved", status.Status)
+	a.Equal("", status.Invite)
+	a.True(status.InviteHandedOut)
+
+	doc, resp = ts.Client.GetHTML(statusURL)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal(0, doc.Find("#join-request-invite").Length())
+	a.Equal(1, doc.Find("#join-request-invite-handed-out").Length())
+	a.Equal(3, ts.JoinRequestsDB.HandOutInviteCallCount())
+
+	// approved as a member
+	ts.JoinRequestsDB.GetByTokenReturns(roomdb.JoinRequest{ID: 1, PubKey: testRef, Status: roomdb.JoinRequestApproved}, nil)
+
+	status = getStatus()
+	a.Equal("approved", status.Status)
+	a.Equal("", status.Invite)
+	a.True(strings.HasPrefix(status.RoomAddress, "net:localhost:8008~shs:"), "not for the test host: %s", status.RoomAddress)
+
+	doc, resp = ts.Client.GetHTML(statusURL)
+	a.Equal(http.StatusOK, resp.Code)
+	a.Equal(1, doc.Find("#join-request-room-address").Length())
+
+	// unknown tokens
+	ts.JoinRequestsDB.GetByTokenReturns(roomdb.JoinRequest{}, roomdb.ErrNotFound)
+
+	doc, resp = ts.Client.GetHTML(statusURL)
+	// 500 until https://github.com/ssbc/go-ssb-room/issues/66 is fixed, like the invite facade
+	a.Equal(http.StatusInternalServerError, resp.Code)
+	a.EqualError(weberrors.ErrNotFound{What: "join request"}, doc.Find("#errBody").Text())
+}
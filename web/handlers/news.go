@@ -0,0 +1,397 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web"
+	weberrors "github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+)
+
+// newsFeedLength is how many of the latest posts the feeds contain
+const newsFeedLength = 20
+
+type newsHandler struct {
+	flashes *weberrors.FlashHelper
+	urlTo   web.URLMaker
+	netInfo network.ServerEndpointDetails
+
+	news    roomdb.NewsService
+	pinned  roomdb.PinnedNoticesService
+	roomCfg roomdb.RoomConfig
+}
+
+// newsFeed is what the list and the feeds have in common
+type newsFeed struct {
+	RoomName string
+	Title    string
+	Language string
+
+	// Languages are the ones the NoticeNews pinned notice is available in
+	Languages []string
+
+	Posts []roomdb.NewsPost
+}
+
+// feed loads the posts in the language of ?lang=, or in all of them if it is empty.
+// Languages that the NoticeNews pinned notice isn't translated to are not found.
+func (h newsHandler) feed(req *http.Request) (newsFeed, error) {
+	ctx := req.Context()
+
+	feed := newsFeed{Language: req.URL.Query().Get("lang")}
+
+	branding, err := h.roomCfg.GetBranding(ctx)
+	if err != nil {
+		return feed, err
+	}
+	feed.RoomName = h.netInfo.Domain
+	if branding.Name != "" {
+		feed.RoomName = branding.Name
+	}
+
+	pinned, err := h.pinned.List(ctx)
+	if err != nil {
+		return feed, err
+	}
+
+	var newsTitle string
+	for _, n := range pinned[roomdb.NoticeNews] {
+		if !n.Published {
+			continue
+		}
+		feed.Languages = append(feed.Languages, n.Language)
+		if newsTitle == "" || n.Language == feed.Language {
+			newsTitle = n.Title
+		}
+	}
+
+	if feed.Language != "" && !containsString(feed.Languages, feed.Language) {
+		return feed, roomdb.ErrNotFound
+	}
+
+	feed.Title = feed.RoomName
+	if newsTitle != "" {
+		feed.Title = feed.RoomName + " · " + newsTitle
+	}
+
+	feed.Posts, err = h.news.List(ctx, feed.Language)
+	if err != nil {
+		return feed, err
+	}
+
+	return feed, nil
+}
+
+// updated is the date of the newest post, or now if there are none
+func (f newsFeed) updated() time.Time {
+	if len(f.Posts) == 0 {
+		return time.Now()
+	}
+	return f.Posts[0].PublishedAt
+}
+
+// latest cuts the posts down to the ones that go into the feeds
+func (f newsFeed) latest() []roomdb.NewsPost {
+	if len(f.Posts) > newsFeedLength {
+		return f.Posts[:newsFeedLength]
+	}
+	return f.Posts
+}
+
+type newsListData struct {
+	Title     string
+	Language  string
+	Languages []string
+
+	Posts []newsListPost
+
+	// the feeds in the same language as the list
+	AtomURL, RSSURL, JSONURL string
+
+	Flashes []weberrors.FlashMessage
+}
+
+type newsListPost struct {
+	roomdb.NewsPost
+	Content template.HTML
+}
+
+func (h newsHandler) list(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	feed, err := h.feed(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pageData := newsListData{
+		Title:     feed.Title,
+		Language:  feed.Language,
+		Languages: feed.Languages,
+		Posts:     make([]newsListPost, len(feed.Posts)),
+
+		AtomURL: h.feedURL(router.CompleteNewsFeedAtom, feed.Language),
+		RSSURL:  h.feedURL(router.CompleteNewsFeedRSS, feed.Language),
+		JSONURL: h.feedURL(router.CompleteNewsFeedJSON, feed.Language),
+	}
+	for i, p := range feed.Posts {
+		pageData.Posts[i] = newsListPost{
+			NewsPost: p,
+			Content:  web.RenderMarkdown(p.Notice.Content),
+		}
+	}
+
+	pageData.Flashes, err = h.flashes.GetAll(rw, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pageData, nil
+}
+
+// feedURL returns the URL of a feed in the language of the feed
+func (h newsHandler) feedURL(route, lang string) string {
+	if lang == "" {
+		return h.urlTo(route).String()
+	}
+	return h.urlTo(route, "lang", lang).String()
+}
+
+func (h newsHandler) postURL(p roomdb.NewsPost) string {
+	return h.urlTo(router.CompleteNoticeShow, "id", p.Notice.ID).String()
+}
+
+// the Atom feed, see RFC 4287
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Language string      `xml:"xml:lang,attr,omitempty"`
+	Title    string      `xml:"title"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Author   atomAuthor  `xml:"author"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	Language  string      `xml:"xml:lang,attr,omitempty"`
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Link      atomLink    `xml:"link"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+func (h newsHandler) feedAtom(rw http.ResponseWriter, req *http.Request) {
+	feed, err := h.feed(req)
+	if err != nil {
+		h.feedError(rw, err)
+		return
+	}
+
+	atom := atomFeed{
+		Language: feed.Language,
+		Title:    feed.Title,
+		ID:       h.feedURL(router.CompleteNewsFeedAtom, feed.Language),
+		Updated:  feed.updated().UTC().Format(time.RFC3339),
+		Author:   atomAuthor{Name: feed.RoomName},
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: h.feedURL(router.CompleteNewsFeedAtom, feed.Language)},
+			{Rel: "alternate", Type: "text/html", Href: h.feedURL(router.CompleteNewsList, feed.Language)},
+		},
+	}
+
+	for _, p := range feed.latest() {
+		published := p.PublishedAt.UTC().Format(time.RFC3339)
+		atom.Entries = append(atom.Entries, atomEntry{
+			Language:  p.Notice.Language,
+			Title:     p.Notice.Title,
+			ID:        h.postURL(p),
+			Published: published,
+			Updated:   published,
+			Link:      atomLink{Rel: "alternate", Type: "text/html", Href: h.postURL(p)},
+			Content:   atomContent{Type: "html", Body: string(web.RenderMarkdown(p.Notice.Content))},
+		})
+	}
+
+	h.writeXML(rw, "application/atom+xml; charset=utf-8", atom)
+}
+
+// the RSS 2.0 feed, see https://www.rssboard.org/rss-specification
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func (h newsHandler) feedRSS(rw http.ResponseWriter, req *http.Request) {
+	feed, err := h.feed(req)
+	if err != nil {
+		h.feedError(rw, err)
+		return
+	}
+
+	rss := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          h.feedURL(router.CompleteNewsList, feed.Language),
+			Description:   feed.Title,
+			Language:      feed.Language,
+			LastBuildDate: feed.updated().UTC().Format(time.RFC1123Z),
+		},
+	}
+
+	for _, p := range feed.latest() {
+		rss.Channel.Items = append(rss.Channel.Items, rssItem{
+			Title:       p.Notice.Title,
+			Link:        h.postURL(p),
+			GUID:        rssGUID{IsPermaLink: true, Value: h.postURL(p)},
+			PubDate:     p.PublishedAt.UTC().Format(time.RFC1123Z),
+			Description: string(web.RenderMarkdown(p.Notice.Content)),
+		})
+	}
+
+	h.writeXML(rw, "application/rss+xml; charset=utf-8", rss)
+}
+
+func (h newsHandler) writeXML(rw http.ResponseWriter, contentType string, v interface{}) {
+	rw.Header().Set("Content-Type", contentType)
+	rw.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(rw)
+	enc.Indent("", "  ")
+	enc.Encode(v)
+}
+
+// the JSON feed, see https://www.jsonfeed.org/version/1.1/
+// _ssb_room is an extension with the details that ssb clients need to connect to the room.
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Language    string         `json:"language,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+
+	Room jsonFeedRoom `json:"_ssb_room"`
+}
+
+type jsonFeedItem struct {
+	ID            string `json:"id"`
+	URL           string `json:"url"`
+	Title         string `json:"title"`
+	ContentHTML   string `json:"content_html"`
+	ContentText   string `json:"content_text"` // markdown
+	DatePublished string `json:"date_published"`
+	Language      string `json:"language"`
+}
+
+type jsonFeedRoom struct {
+	RoomID             string `json:"roomId"`
+	MultiserverAddress string `json:"multiserverAddress"`
+}
+
+func (h newsHandler) feedJSON(rw http.ResponseWriter, req *http.Request) {
+	feed, err := h.feed(req)
+	if err != nil {
+		h.feedError(rw, err)
+		return
+	}
+
+	resp := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		HomePageURL: h.feedURL(router.CompleteNewsList, feed.Language),
+		FeedURL:     h.feedURL(router.CompleteNewsFeedJSON, feed.Language),
+		Language:    feed.Language,
+		Items:       []jsonFeedItem{},
+
+		Room: jsonFeedRoom{
+			RoomID:             h.netInfo.RoomID.String(),
+			MultiserverAddress: h.netInfo.MultiserverAddress(),
+		},
+	}
+
+	for _, p := range feed.latest() {
+		resp.Items = append(resp.Items, jsonFeedItem{
+			ID:            h.postURL(p),
+			URL:           h.postURL(p),
+			Title:         p.Notice.Title,
+			ContentHTML:   string(web.RenderMarkdown(p.Notice.Content)),
+			ContentText:   p.Notice.Content,
+			DatePublished: p.PublishedAt.UTC().Format(time.RFC3339),
+			Language:      p.Notice.Language,
+		})
+	}
+
+	rw.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+	rw.Header().Set("Access-Control-Allow-Origin", "*")
+	json.NewEncoder(rw).Encode(resp)
+}
+
+func (h newsHandler) feedError(rw http.ResponseWriter, err error) {
+	if errors.Is(err, roomdb.ErrNotFound) {
+		http.Error(rw, "no news in this language", http.StatusNotFound)
+		return
+	}
+	http.Error(rw, "failed to load the news", http.StatusInternalServerError)
+}
+
+func containsString(lst []string, s string) bool {
+	for _, v := range lst {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+)
+
+func newsTestData(ts *testSession) []roomdb.NewsPost {
+	ts.PinnedDB.ListReturns(roomdb.PinnedNotices{
+		roomdb.NoticeNews: []roomdb.Notice{
+			{ID: 1, Title: "News", Language: "en", Published: true},
+			{ID: 2, Title: "Neuigkeiten", Language: "de", Published: true},
+		},
+	}, nil)
+
+	posts := []roomdb.NewsPost{
+		{ID: 2, PublishedAt: time.Date(2021, 3, 2, 12, 0, 0, 0, time.UTC), Notice: roomdb.Notice{
+			ID: 20, Title: "Maintenance", Content: "down on **sunday**", Language: "en", Published: true,
+		}},
+		{ID: 1, PublishedAt: time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), Notice: roomdb.Notice{
+			ID: 10, Title: "Hello", Content: "we are open", Language: "en", Published: true,
+		}},
+	}
+	ts.NewsDB.ListReturns(posts, nil)
+	return posts
+}
+
+func TestNewsList(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+
+	newsTestData(ts)
+
+	listURL := ts.URLTo(router.CompleteNewsList)
+
+	html, res := ts.Client.GetHTML(listURL)
+	a.Equal(http.StatusOK, res.Code, "wrong HTTP status code")
+
+	posts := html.Find("article.news-post")
+	a.Equal(2, posts.Length())
+	a.Equal("Maintenance", strings.TrimSpace(posts.First().Find("h2").Text()))
+	a.Equal("sunday", posts.First().Find(".markdown strong").Text())
+
+	_, lang := ts.NewsDB.ListArgsForCall(0)
+	a.Equal("", lang)
+
+	// the feeds are linked for feed readers
+	a.Equal(3, html.Find("link[rel='alternate']").Length())
+	a.Equal(3, html.Find("#news-languages a").Length())
+
+	listURL.RawQuery = "lang=de"
+	_, res = ts.Client.GetHTML(listURL)
+	a.Equal(http.StatusOK, res.Code, "wrong HTTP status code")
+	_, lang = ts.NewsDB.ListArgsForCall(1)
+	a.Equal("de", lang)
+
+	// the news notice isn't translated to french
+	listURL.RawQuery = "lang=fr"
+	_, res = ts.Client.GetHTML(listURL)
+	a.Equal(http.StatusNotFound, res.Code)
+	a.Equal(2, ts.NewsDB.ListCallCount())
+
+	// the footer links to the news
+	html, _ = ts.Client.GetHTML(ts.URLTo(router.CompleteIndex))
+	a.Equal(1, html.Find("footer a[href$='/news']").Length())
+}
+
+func TestNewsFeedAtom(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	newsTestData(ts)
+
+	feedURL := ts.URLTo(router.CompleteNewsFeedAtom, "lang", "en")
+	resp := ts.Client.GetBody(feedURL)
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+	a.Equal("application/atom+xml; charset=utf-8", resp.Header().Get("Content-Type"))
+
+	var feed atomFeed
+	r.NoError(xml.NewDecoder(resp.Body).Decode(&feed))
+	a.Equal("localhost · News", feed.Title)
+	a.Equal("2021-03-02T12:00:00Z", feed.Updated)
+	r.Len(feed.Entries, 2)
+	a.Equal("Maintenance", feed.Entries[0].Title)
+	a.Equal(ts.URLTo(router.CompleteNoticeShow, "id", 20).String(), feed.Entries[0].ID)
+	a.Equal("html", feed.Entries[0].Content.Type)
+	a.Contains(feed.Entries[0].Content.Body, "<strong>sunday</strong>")
+
+	feedURL = ts.URLTo(router.CompleteNewsFeedAtom, "lang", "fr")
+	resp = ts.Client.GetBody(feedURL)
+	a.Equal(http.StatusNotFound, resp.Code)
+}
+
+func TestNewsFeedRSS(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	newsTestData(ts)
+	ts.ConfigDB.GetBrandingReturns(roomdb.Branding{Name: "Cozy room"}, nil)
+
+	resp := ts.Client.GetBody(ts.URLTo(router.CompleteNewsFeedRSS, "lang", "de"))
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+	a.Equal("application/rss+xml; charset=utf-8", resp.Header().Get("Content-Type"))
+
+	var feed rssFeed
+	r.NoError(xml.NewDecoder(resp.Body).Decode(&feed))
+	a.Equal("2.0", feed.Version)
+	a.Equal("Cozy room · Neuigkeiten", feed.Channel.Title)
+	a.Equal("de", feed.Channel.Language)
+	r.Len(feed.Channel.Items, 2)
+	a.Equal("Tue, 02 Mar 2021 12:00:00 +0000", feed.Channel.Items[0].PubDate)
+	a.True(feed.Channel.Items[0].GUID.IsPermaLink)
+}
+
+func TestNewsFeedJSON(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+	r := require.New(t)
+
+	newsTestData(ts)
+
+	resp := ts.Client.GetBody(ts.URLTo(router.CompleteNewsFeedJSON))
+	a.Equal(http.StatusOK, resp.Code, "wrong HTTP status code")
+	a.Equal("application/feed+json; charset=utf-8", resp.Header().Get("Content-Type"))
+
+	var feed jsonFeed
+	r.NoError(json.NewDecoder(resp.Body).Decode(&feed))
+	a.Equal("https://jsonfeed.org/version/1.1", feed.Version)
+	a.Equal(ts.NetworkInfo.RoomID.String(), feed.Room.RoomID)
+	r.Len(feed.Items, 2)
+	a.Equal("Hello", feed.Items[1].Title)
+	a.Equal("we are open", feed.Items[1].ContentText)
+	a.Equal("2021-03-01T12:00:00Z", feed.Items[1].DatePublished)
+	a.Equal(ts.URLTo(router.CompleteNoticeShow, "id", 10).String(), feed.Items[1].URL)
+
+	// no posts are still a list
+	ts.NewsDB.ListReturns(nil, nil)
+	resp = ts.Client.GetBody(ts.URLTo(router.CompleteNewsFeedJSON))
+	a.Equal(http.StatusOK, resp.Code)
+	a.Contains(resp.Body.String(), `"items":[]`)
+}
@@ -11,10 +11,10 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/russross/blackfriday/v2"
-
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web"
 	"github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/members"
 )
 
 type noticeHandler struct {
@@ -23,6 +23,7 @@ type noticeHandler struct {
 
 	pinned  roomdb.PinnedNoticesService
 	notices roomdb.NoticesService
+	roomCfg roomdb.RoomConfig
 }
 
 type noticesListData struct {
@@ -45,6 +46,18 @@ func (h noticeHandler) list(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if !h.canSeeDrafts(req) {
+		for name, notices := range lst {
+			published := notices[:0]
+			for _, n := range notices {
+				if n.Published {
+					published = append(published, n)
+				}
+			}
+			lst[name] = published
+		}
+	}
+
 	flashes, err := h.flashes.GetAll(rw, req)
 	if err != nil {
 		responder.RenderError(err)
@@ -63,6 +76,7 @@ type noticeShowData struct {
 	ID              int64
 	Title, Language string
 	Content         template.HTML
+	Draft           bool
 
 	Flashes []errors.FlashMessage
 }
@@ -78,13 +92,17 @@ func (h noticeHandler) show(rw http.ResponseWriter, req *http.Request) (interfac
 		return nil, err
 	}
 
-	markdown := blackfriday.Run([]byte(notice.Content), blackfriday.WithNoExtensions())
+	// drafts only exist for the people that can edit them
+	if !notice.Published && !h.canSeeDrafts(req) {
+		return nil, roomdb.ErrNotFound
+	}
 
 	pageData := noticeShowData{
 		ID:       noticeID,
 		Title:    notice.Title,
-		Content:  template.HTML(markdown),
+		Content:  web.RenderMarkdown(notice.Content),
 		Language: notice.Language,
+		Draft:    !notice.Published,
 	}
 
 	pageData.Flashes, err = h.flashes.GetAll(rw, req)
@@ -95,6 +113,11 @@ func (h noticeHandler) show(rw http.ResponseWriter, req *http.Request) (interfac
 	return pageData, nil
 }
 
+func (h noticeHandler) canSeeDrafts(req *http.Request) bool {
+	_, err := members.CheckAllowed(req.Context(), h.roomCfg, members.ActionChangeNotice)
+	return err == nil
+}
+
 type listNoticesResponder interface {
 	Render(noticesListData)
 	RenderError(error)
@@ -21,8 +21,9 @@ func TestNoticeSmokeTest(t *testing.T) {
 	a := assert.New(t)
 
 	noticeData := roomdb.Notice{
-		ID:    1,
-		Title: "Welcome!",
+		ID:        1,
+		Title:     "Welcome!",
+		Published: true,
 	}
 
 	ts.NoticeDB.GetByIDReturns(noticeData, nil)
@@ -43,9 +44,10 @@ Hello world!
 ## The loveliest of rooms is here
 `
 	noticeData := roomdb.Notice{
-		ID:      1,
-		Title:   "Welcome!",
-		Content: markdown,
+		ID:        1,
+		Title:     "Welcome!",
+		Content:   markdown,
+		Published: true,
 	}
 
 	ts.NoticeDB.GetByIDReturns(noticeData, nil)
@@ -66,9 +68,10 @@ func TestNoticesEditButtonVisible(t *testing.T) {
 	ts.AliasesDB.ResolveReturns(roomdb.Alias{}, roomdb.ErrNotFound)
 
 	noticeData := roomdb.Notice{
-		ID:      42,
-		Title:   "Welcome!",
-		Content: `super simple conent`,
+		ID:        42,
+		Title:     "Welcome!",
+		Content:   `super simple conent`,
+		Published: true,
 	}
 	ts.NoticeDB.GetByIDReturns(noticeData, nil)
 
@@ -231,24 +234,27 @@ func TestNoticesListReturnsJSONWhenCorrectParameterIsSet(t *testing.T) {
 	ts.PinnedDB.ListReturns(roomdb.PinnedNotices{
 		"name1": {
 			{
-				ID:       1,
-				Title:    "title1",
-				Content:  "content1",
-				Language: "language1",
+				ID:        1,
+				Title:     "title1",
+				Content:   "content1",
+				Language:  "language1",
+				Published: true,
 			},
 			{
-				ID:       2,
-				Title:    "title2",
-				Content:  "content2",
-				Language: "language2",
+				ID:        2,
+				Title:     "title2",
+				Content:   "content2",
+				Language:  "language2",
+				Published: true,
 			},
 		},
 		"name2": {
 			{
-				ID:       3,
-				Title:    "title3",
-				Content:  "content3",
-				Language: "language3",
+				ID:        3,
+				Title:     "title3",
+				Content:   "content3",
+				Language:  "language3",
+				Published: true,
 			},
 		},
 	}, nil)
@@ -294,3 +300,39 @@ func TestNoticesListReturnsJSONWhenCorrectParameterIsSet(t *testing.T) {
 		},
 	}, response)
 }
+
+func TestNoticeDraftsHidden(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+
+	ts.NoticeDB.GetByIDReturns(roomdb.Notice{
+		ID:      7,
+		Title:   "Not yet",
+		Content: "still working on it",
+	}, nil)
+
+	// a new notice that was only saved as a draft
+	noticeURL := ts.URLTo(router.CompleteNoticeShow, "id", 7)
+	_, res := ts.Client.GetHTML(noticeURL)
+	a.Equal(http.StatusNotFound, res.Code, "drafts should not be public")
+
+	ts.PinnedDB.ListReturns(roomdb.PinnedNotices{
+		roomdb.NoticeNews: {
+			{ID: 1, Title: "News", Language: "en-GB", Published: true},
+			{ID: 7, Title: "Not yet", Language: "de"},
+		},
+	}, nil)
+
+	listURL := ts.URLTo(router.CompleteNoticeList)
+	values := listURL.Query()
+	values.Set("encoding", "json")
+	listURL.RawQuery = values.Encode()
+
+	var response listNoticesJSONResponse
+	res = ts.Client.GetJSON(listURL, &response)
+	a.Equal(http.StatusOK, res.Code)
+	if a.Len(response.PinnedNotices, 1) {
+		a.Len(response.PinnedNotices[0].Notices, 1)
+		a.EqualValues(1, response.PinnedNotices[0].Notices[0].ID)
+	}
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web"
+	"github.com/ssbc/go-ssb-room/v2/web/errors"
+	"github.com/ssbc/go-ssb-room/v2/web/members"
+)
+
+type pageHandler struct {
+	flashes *errors.FlashHelper
+
+	pages   roomdb.PagesService
+	roomCfg roomdb.RoomConfig
+}
+
+type pageShowData struct {
+	Page roomdb.Page
+
+	// Notice is the translation that is shown
+	Notice  roomdb.Notice
+	Content template.HTML
+	Draft   bool
+
+	// Languages are the other translations of the page
+	Languages []string
+
+	Flashes []errors.FlashMessage
+}
+
+// show renders the translation of the page that was asked for with ?lang=
+// or the first published one if there is none in that language.
+func (h pageHandler) show(rw http.ResponseWriter, req *http.Request) (interface{}, error) {
+	page, err := h.pages.GetBySlug(req.Context(), mux.Vars(req)["slug"])
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = members.CheckAllowed(req.Context(), h.roomCfg, members.ActionChangeNotice)
+	canSeeDrafts := err == nil
+
+	lang := req.URL.Query().Get("lang")
+
+	notice, has := page.Translation(lang)
+	if canSeeDrafts {
+		// drafts only exist for the people that can edit them
+		for _, n := range page.Translations {
+			if n.Language == lang && !n.Published {
+				notice, has = n, true
+				break
+			}
+		}
+		if !has && len(page.Translations) > 0 {
+			notice, has = page.Translations[0], true
+		}
+	}
+	if !has {
+		return nil, roomdb.ErrNotFound
+	}
+
+	pageData := pageShowData{
+		Page:    page,
+		Notice:  notice,
+		Content: web.RenderMarkdown(notice.Content),
+		Draft:   !notice.Published,
+	}
+
+	for _, n := range page.Translations {
+		if n.ID == notice.ID || (!n.Published && !canSeeDrafts) {
+			continue
+		}
+		pageData.Languages = append(pageData.Languages, n.Language)
+	}
+
+	pageData.Flashes, err = h.flashes.GetAll(rw, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return pageData, nil
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ssbc/go-ssb-room/v2/roomdb"
+	"github.com/ssbc/go-ssb-room/v2/web/router"
+)
+
+func TestPageShow(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+
+	faq := roomdb.Page{
+		ID:   1,
+		Name: "FAQ",
+		Slug: "faq",
+		Translations: []roomdb.Notice{
+			{ID: 10, Title: "Questions", Content: "## ask away", Language: "en", Published: true},
+			{ID: 11, Title: "Fragen", Content: "frag ruhig", Language: "de", Published: true},
+			{ID: 12, Title: "Preguntas", Content: "tbd", Language: "es"},
+		},
+	}
+	ts.PagesDB.GetBySlugReturns(faq, nil)
+
+	pageURL := ts.URLTo(router.CompleteIndex)
+	pageURL.Path = "/page/faq"
+
+	html, res := ts.Client.GetHTML(pageURL)
+	a.Equal(http.StatusOK, res.Code, "wrong HTTP status code")
+	a.Equal("Questions", html.Find("h1").Text())
+	a.Equal("ask away", html.Find(".markdown h2").Text())
+
+	_, slug := ts.PagesDB.GetBySlugArgsForCall(0)
+	a.Equal("faq", slug)
+
+	// only the published translations are offered
+	langs := html.Find("#page-languages a")
+	a.Equal(1, langs.Length())
+	a.Equal("de", langs.Text())
+
+	pageURL.RawQuery = "lang=de"
+	html, res = ts.Client.GetHTML(pageURL)
+	a.Equal(http.StatusOK, res.Code, "wrong HTTP status code")
+	a.Equal("Fragen", html.Find("h1").Text())
+
+	// drafts fall back to a published translation
+	pageURL.RawQuery = "lang=es"
+	html, res = ts.Client.GetHTML(pageURL)
+	a.Equal(http.StatusOK, res.Code, "wrong HTTP status code")
+	a.Equal("Questions", html.Find("h1").Text())
+	a.Equal(0, html.Find("#notice-unpublished").Length())
+
+	// pages without a published translation are not there
+	ts.PagesDB.GetBySlugReturns(roomdb.Page{ID: 2, Name: "Draft", Slug: "draft", Translations: faq.Translations[2:]}, nil)
+	pageURL.Path = "/page/draft"
+	pageURL.RawQuery = ""
+	_, res = ts.Client.GetHTML(pageURL)
+	a.Equal(http.StatusNotFound, res.Code)
+
+	ts.PagesDB.GetBySlugReturns(roomdb.Page{}, roomdb.ErrNotFound)
+	pageURL.Path = "/page/nope"
+	_, res = ts.Client.GetHTML(pageURL)
+	a.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestPageFooterLinks(t *testing.T) {
+	ts := setup(t)
+	a := assert.New(t)
+
+	ts.PagesDB.ListReturns([]roomdb.Page{
+		{ID: 1, Name: "Getting started", Slug: "getting-started", Translations: []roomdb.Notice{
+			{ID: 10, Language: "en", Published: true},
+		}},
+		// not published yet
+		{ID: 2, Name: "FAQ", Slug: "faq", Translations: []roomdb.Notice{
+			{ID: 11, Language: "en"},
+		}},
+	}, nil)
+
+	html, res := ts.Client.GetHTML(ts.URLTo(router.CompleteIndex))
+	a.Equal(http.StatusOK, res.Code, "wrong HTTP status code")
+
+	links := html.Find("footer a[href$='/page/getting-started']")
+	a.Equal(1, links.Length())
+	a.Equal("Getting started", links.Text())
+
+	a.Equal(0, html.Find("footer a[href$='/page/faq']").Length())
+}
@@ -6,6 +6,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 	"net/url"
 	"os"
@@ -18,6 +19,7 @@ import (
 	refs "github.com/ssbc/go-ssb-refs"
 	"github.com/ssbc/go-ssb-room/v2/internal/network"
 	"github.com/ssbc/go-ssb-room/v2/internal/network/mocked"
+	"github.com/ssbc/go-ssb-room/v2/internal/outbound"
 	"github.com/ssbc/go-ssb-room/v2/internal/repo"
 	"github.com/ssbc/go-ssb-room/v2/internal/signinwithssb"
 	"github.com/ssbc/go-ssb-room/v2/roomdb"
@@ -36,16 +38,20 @@ type testSession struct {
 	netInfo network.ServerEndpointDetails
 
 	// mocked dbs
-	AuthDB         *mockdb.FakeAuthWithSSBService
-	AuthFallbackDB *mockdb.FakeAuthFallbackService
-	AuthWithSSB    *mockdb.FakeAuthWithSSBService
-	AliasesDB      *mockdb.FakeAliasesService
-	ConfigDB       *mockdb.FakeRoomConfig
-	MembersDB      *mockdb.FakeMembersService
-	InvitesDB      *mockdb.FakeInvitesService
-	DeniedKeysDB   *mockdb.FakeDeniedKeysService
-	PinnedDB       *mockdb.FakePinnedNoticesService
-	NoticeDB       *mockdb.FakeNoticesService
+	AuthDB          *mockdb.FakeAuthWithSSBService
+	AuthFallbackDB  *mockdb.FakeAuthFallbackService
+	AuthWithSSB     *mockdb.FakeAuthWithSSBService
+	AliasesDB       *mockdb.FakeAliasesService
+	ConfigDB        *mockdb.FakeRoomConfig
+	MembersDB       *mockdb.FakeMembersService
+	InvitesDB       *mockdb.FakeInvitesService
+	DeniedKeysDB    *mockdb.FakeDeniedKeysService
+	PinnedDB        *mockdb.FakePinnedNoticesService
+	NoticeDB        *mockdb.FakeNoticesService
+	NewsDB          *mockdb.FakeNewsService
+	CodeOfConductDB *mockdb.FakeCodeOfConductService
+	JoinRequestsDB  *mockdb.FakeJoinRequestsService
+	PagesDB         *mockdb.FakePagesService
 
 	RoomState *roomstate.Manager
 
@@ -83,6 +89,10 @@ func setup(t *testing.T) *testSession {
 	}
 	ts.PinnedDB.GetReturns(defaultNotice, nil)
 	ts.NoticeDB = new(mockdb.FakeNoticesService)
+	ts.NewsDB = new(mockdb.FakeNewsService)
+	ts.CodeOfConductDB = new(mockdb.FakeCodeOfConductService)
+	ts.JoinRequestsDB = new(mockdb.FakeJoinRequestsService)
+	ts.PagesDB = new(mockdb.FakePagesService)
 
 	ts.MockedEndpoints = new(mocked.FakeEndpoints)
 
@@ -121,6 +131,8 @@ func setup(t *testing.T) *testSession {
 		ts.NetworkInfo,
 		ts.RoomState,
 		ts.MockedEndpoints,
+		outbound.NewManager(context.Background(), log, nil, nil),
+		network.NewConnLimiter(log, network.ConnLimits{}),
 		ts.SignalBridge,
 		Databases{
 			Aliases:       ts.AliasesDB,
@@ -131,6 +143,10 @@ func setup(t *testing.T) *testSession {
 			Invites:       ts.InvitesDB,
 			DeniedKeys:    ts.DeniedKeysDB,
 			Notices:       ts.NoticeDB,
+			News:          ts.NewsDB,
+			CodeOfConduct: ts.CodeOfConductDB,
+			JoinRequests:  ts.JoinRequestsDB,
+			Pages:         ts.PagesDB,
 			PinnedNotices: ts.PinnedDB,
 		},
 	)
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package web
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+	"golang.org/x/net/html"
+)
+
+// RenderMarkdown turns the markdown of a notice into HTML that is safe to put into a page.
+// Markdown allows raw HTML, so everything blackfriday produces goes through an allowlist of tags and attributes.
+// Other tags are dropped but their text is kept, except for the ones like script where the text is code.
+func RenderMarkdown(src string) template.HTML {
+	// https://github.com/russross/blackfriday/issues/575
+	src = strings.Replace(src, "\r\n", "\n", -1)
+
+	rendered := blackfriday.Run([]byte(src), blackfriday.WithNoExtensions())
+	return template.HTML(sanitizeHTML(rendered))
+}
+
+// allowedTags maps the tags that are kept to their allowed attributes
+var allowedTags = map[string][]string{
+	"a":          {"href", "title"},
+	"blockquote": nil,
+	"br":         nil,
+	"code":       nil,
+	"del":        nil,
+	"em":         nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"img":        {"src", "alt", "title"},
+	"li":         nil,
+	"ol":         nil,
+	"p":          nil,
+	"pre":        nil,
+	"strong":     nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         nil,
+	"th":         nil,
+	"thead":      nil,
+	"tr":         nil,
+	"ul":         nil,
+}
+
+// droppedContent are the tags whose content is removed as well
+var droppedContent = map[string]bool{
+	"iframe":   true,
+	"math":     true,
+	"noembed":  true,
+	"noframes": true,
+	"noscript": true,
+	"object":   true,
+	"script":   true,
+	"style":    true,
+	"svg":      true,
+	"template": true,
+	"textarea": true,
+	"title":    true,
+	"xmp":      true,
+}
+
+func sanitizeHTML(in []byte) string {
+	var out strings.Builder
+
+	// how many droppedContent tags we are in
+	skipping := 0
+
+	z := html.NewTokenizer(bytes.NewReader(in))
+	for {
+		if z.Next() == html.ErrorToken {
+			// io.EOF or broken markup, either way the rest is not trusted
+			if z.Err() != io.EOF {
+				out.WriteString(html.EscapeString(string(z.Raw())))
+			}
+			return out.String()
+		}
+
+		tok := z.Token()
+		switch tok.Type {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedContent[tok.Data] {
+				if tok.Type == html.StartTagToken {
+					skipping++
+				}
+				continue
+			}
+			attrs, allowed := allowedTags[tok.Data]
+			if skipping > 0 || !allowed {
+				continue
+			}
+			out.WriteString("<" + tok.Data)
+			for _, attr := range tok.Attr {
+				if attr.Namespace != "" || !contains(attrs, attr.Key) {
+					continue
+				}
+				if (attr.Key == "href" || attr.Key == "src") && !safeURL(attr.Val) {
+					continue
+				}
+				out.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			if tok.Data == "a" {
+				out.WriteString(` rel="nofollow noopener noreferrer"`)
+			}
+			out.WriteString(">")
+
+		case html.EndTagToken:
+			if droppedContent[tok.Data] {
+				if skipping > 0 {
+					skipping--
+				}
+				continue
+			}
+			if _, allowed := allowedTags[tok.Data]; skipping > 0 || !allowed {
+				continue
+			}
+			out.WriteString("</" + tok.Data + ">")
+
+		case html.TextToken:
+			if skipping > 0 {
+				continue
+			}
+			out.WriteString(html.EscapeString(tok.Data))
+
+		default:
+			// comments and doctypes
+		}
+	}
+}
+
+// safeURL allows relative links and the schemes that don't run anything in the browser
+func safeURL(val string) bool {
+	u, err := url.Parse(strings.TrimSpace(val))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto", "ssb":
+		return true
+	default:
+		return false
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
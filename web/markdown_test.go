@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package web
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	a := assert.New(t)
+
+	type testCase struct {
+		input, want string
+	}
+
+	cases := []testCase{
+		{"# Hello\r\n\r\nthis is **important**", "<h1>Hello</h1>\n\n<p>this is <strong>important</strong></p>"},
+		{"[the room](https://example.org)", `<p><a href="https://example.org" rel="nofollow noopener noreferrer">the room</a></p>`},
+		{"a < b & c", "<p>a &lt; b &amp; c</p>"},
+
+		// scripts and their content are removed
+		{"hi <script>alert(1)</script>there", "<p>hi there</p>"},
+		{"<script>\nalert(1)\n</script>\n", ""},
+
+		// other tags are removed but their text stays
+		{`<div onclick="alert(1)">text</div>`, "text"},
+		{`<p style="color: red">red</p>`, "<p>red</p>"},
+
+		// links that run code lose their target
+		{"[click](javascript:void)", `<p><a rel="nofollow noopener noreferrer">click</a></p>`},
+		{`<a href="JaVaScRiPt:alert(1)">click</a>`, `<p><a rel="nofollow noopener noreferrer">click</a></p>`},
+		{`<img src="x" onerror="alert(1)">`, `<p><img src="x"></p>`},
+	}
+
+	for i, tc := range cases {
+		got := strings.TrimSpace(string(RenderMarkdown(tc.input)))
+		a.Equal(tc.want, got, "case %d", i)
+	}
+}
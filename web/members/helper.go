@@ -206,6 +206,8 @@ const (
 	ActionChangeDeniedKeys = "change-denied-keys"
 	ActionRemoveMember     = "remove-member"
 	ActionChangeNotice     = "change-notice"
+
+	ActionDecideJoinRequests = "decide-join-requests"
 )
 
 var allowedActionsMap = map[string]AllowedFunc{
@@ -239,6 +241,11 @@ var allowedActionsMap = map[string]AllowedFunc{
 		return role == roomdb.RoleAdmin || role == roomdb.RoleModerator
 	},
 
+	// join requests only exist in community and restricted rooms but only moderators decide on them
+	ActionDecideJoinRequests: func(_ roomdb.PrivacyMode, role roomdb.Role) bool {
+		return role == roomdb.RoleAdmin || role == roomdb.RoleModerator
+	},
+
 	ActionChangeNotice: func(pm roomdb.PrivacyMode, role roomdb.Role) bool {
 		switch pm {
 		case roomdb.ModeCommunity:
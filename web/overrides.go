@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package web
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/network"
+	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+)
+
+// OverridesDir is the directory in the repo with templates and assets that replace the embedded ones.
+// It mirrors the layout of this package: templates go into overrides/templates and assets into overrides/assets,
+// under the same path as the file they replace (like overrides/templates/landing/index.tmpl).
+const OverridesDir = "overrides"
+
+// Overrides are the templates and assets to use, with the files from the repo layered over the embedded ones.
+type Overrides struct {
+	Templates http.FileSystem
+	Assets    http.FileSystem
+
+	// Files lists the overridden files, relative to OverridesDir
+	Files []string
+}
+
+// LoadOverrides looks for templates and assets in the OverridesDir of the repo.
+// Every custom template needs to parse and define the same templates (with define or block) as the one it replaces,
+// since the handlers and the base template rely on them.
+func LoadOverrides(r repo.Interface) (Overrides, error) {
+	o := Overrides{
+		Templates: Templates,
+		Assets:    Assets,
+	}
+
+	templatesDir := r.GetPath(OverridesDir, "templates")
+	customTemplates, err := listFiles(templatesDir)
+	if err != nil {
+		return o, err
+	}
+	for _, name := range customTemplates {
+		if err := checkTemplateOverride(templatesDir, name); err != nil {
+			return o, err
+		}
+		o.Files = append(o.Files, "templates/"+name)
+	}
+	if len(customTemplates) > 0 {
+		o.Templates = overlayFS{upper: http.Dir(templatesDir), lower: Templates}
+	}
+
+	assetsDir := r.GetPath(OverridesDir, "assets")
+	customAssets, err := listFiles(assetsDir)
+	if err != nil {
+		return o, err
+	}
+	for _, name := range customAssets {
+		o.Files = append(o.Files, "assets/"+name)
+	}
+	if len(customAssets) > 0 {
+		o.Assets = overlayFS{upper: http.Dir(assetsDir), lower: Assets}
+	}
+
+	return o, nil
+}
+
+// listFiles returns the paths of all the files in dir, relative to it and with forward slashes.
+// A missing dir has no files.
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == dir && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("web: failed to list overrides in %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// checkTemplateOverride makes sure the custom template name in dir parses and defines everything the embedded one does
+func checkTemplateOverride(dir, name string) error {
+	custom, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return fmt.Errorf("web: failed to read custom template: %w", err)
+	}
+	customDefs, err := definedTemplates(name, custom)
+	if err != nil {
+		return fmt.Errorf("web: custom template %s: %w", name, err)
+	}
+
+	f, err := Templates.Open("/" + name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("web: custom template %s doesn't replace one of the built-in templates", name)
+		}
+		return err
+	}
+	defer f.Close()
+	builtin, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	builtinDefs, err := definedTemplates(name, builtin)
+	if err != nil {
+		return fmt.Errorf("web: built-in template %s: %w", name, err)
+	}
+
+	var missing []string
+	for def := range builtinDefs {
+		if !customDefs[def] {
+			missing = append(missing, fmt.Sprintf("%q", def))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("web: custom template %s is missing %s, which the built-in one defines. Use {{define \"name\"}} or {{block \"name\" .}} for each of them",
+			name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// definedTemplates parses text and returns the names of the templates it defines.
+// The renderer doesn't exist yet, so the functions it provides are replaced by stubFuncs.
+func definedTemplates(name string, text []byte) (map[string]bool, error) {
+	t, err := template.New(name).Funcs(stubFuncs()).Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make(map[string]bool)
+	for _, def := range t.Templates() {
+		if def.Name() != name {
+			defs[def.Name()] = true
+		}
+	}
+	return defs, nil
+}
+
+// requestFuncs are the template functions the renderer adds for each request,
+// see handlers.New, i18n.Helper.GetRenderFuncs and members.TemplateHelpers
+var requestFuncs = []string{
+	"current_page_is",
+	"i18n",
+	"i18nWithData",
+	"i18npl",
+	"is_logged_in",
+	"language_count",
+	"list_languages",
+	"member_can",
+	"member_has_role",
+	"member_is_admin",
+	"member_is_elevated",
+	"privacy_mode_is",
+	"room_branding",
+	"room_pages",
+	"urlToNotice",
+	"urlToPage",
+}
+
+// stubFuncs has the names of all the template functions, for parsing templates without executing them
+func stubFuncs() template.FuncMap {
+	funcs := TemplateFuncs(mux.NewRouter(), network.ServerEndpointDetails{})
+	for _, name := range requestFuncs {
+		funcs[name] = func() string { return "" }
+	}
+	return funcs
+}
+
+// overlayFS opens files from upper and falls back to lower for the ones it doesn't have
+type overlayFS struct {
+	upper, lower http.FileSystem
+}
+
+func (o overlayFS) Open(name string) (http.File, error) {
+	f, err := o.upper.Open(name)
+	if err == nil {
+		return f, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return o.lower.Open(name)
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2021 The NGI Pointer Secure-Scuttlebutt Team of 2020/2021
+//
+// SPDX-License-Identifier: MIT
+
+package web
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssbc/go-ssb-room/v2/internal/repo"
+)
+
+func TestOverrides(t *testing.T) {
+	r := require.New(t)
+
+	testRepo := repo.New(t.TempDir())
+
+	writeFile := func(name, content string) {
+		path := testRepo.GetPath(OverridesDir, filepath.FromSlash(name))
+		r.NoError(os.MkdirAll(filepath.Dir(path), 0700))
+		r.NoError(ioutil.WriteFile(path, []byte(content), 0600))
+	}
+	// nothing to override
+	o, err := LoadOverrides(testRepo)
+	r.NoError(err)
+	r.Empty(o.Files)
+	r.Equal(Templates, o.Templates)
+	r.Equal(Assets, o.Assets)
+
+	writeFile("templates/landing/index.tmpl", `{{define "title"}}Hermies{{end}}{{define "content"}}<h1>{{ i18n "Welcome" }}</h1>{{end}}`)
+	writeFile("assets/custom.css", `body { color: red; }`)
+
+	o, err = LoadOverrides(testRepo)
+	r.NoError(err)
+	r.Equal([]string{"templates/landing/index.tmpl", "assets/custom.css"}, o.Files)
+
+	f, err := o.Templates.Open("/landing/index.tmpl")
+	r.NoError(err)
+	content, err := ioutil.ReadAll(f)
+	r.NoError(err)
+	f.Close()
+	r.Contains(string(content), "Hermies")
+
+	// the others still come from the embedded ones
+	f, err = o.Templates.Open("/base.tmpl")
+	r.NoError(err)
+	f.Close()
+	f, err = o.Assets.Open("/custom.css")
+	r.NoError(err)
+	f.Close()
+	f, err = o.Assets.Open("/style.css")
+	r.NoError(err)
+	f.Close()
+
+	// blocks the handlers need
+	writeFile("templates/landing/index.tmpl", `{{define "title"}}Hermies{{end}}`)
+	_, err = LoadOverrides(testRepo)
+	r.Error(err)
+	r.Contains(err.Error(), `landing/index.tmpl is missing "content"`)
+
+	// syntax errors
+	writeFile("templates/landing/index.tmpl", `{{define "title"}}Hermies{{end}}{{define "content"}}{{if}}{{end}}`)
+	_, err = LoadOverrides(testRepo)
+	r.Error(err)
+	r.Contains(err.Error(), "landing/index.tmpl")
+
+	// unknown functions
+	writeFile("templates/landing/index.tmpl", `{{define "title"}}Hermies{{end}}{{define "content"}}{{ i18m "Welcome" }}{{end}}`)
+	_, err = LoadOverrides(testRepo)
+	r.Error(err)
+	r.Contains(err.Error(), `function "i18m" not defined`)
+
+	// typos in the file names
+	r.NoError(os.Remove(testRepo.GetPath(OverridesDir, "templates", "landing", "index.tmpl")))
+	writeFile("templates/landing/idnex.tmpl", `{{define "title"}}Hermies{{end}}`)
+	_, err = LoadOverrides(testRepo)
+	r.Error(err)
+	r.Contains(err.Error(), "doesn't replace one of the built-in templates")
+}
+
+// the built-in templates need to parse with the stubs, otherwise requestFuncs is missing a function
+func TestStubFuncs(t *testing.T) {
+	r := require.New(t)
+
+	err := filepath.Walk("templates", func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		text, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = definedTemplates(path, text)
+		return err
+	})
+	r.NoError(err)
+}
@@ -11,9 +11,15 @@ const (
 	AdminDashboard = "admin:dashboard"
 	AdminMenu      = "admin:menu"
 
-	AdminSettings            = "admin:settings:overview"
-	AdminSettingsSetPrivacy  = "admin:settings:set-privacy"
-	AdminSettingsSetLanguage = "admin:settings:set-language"
+	AdminSettings               = "admin:settings:overview"
+	AdminSettingsSetPrivacy     = "admin:settings:set-privacy"
+	AdminSettingsSetLanguage    = "admin:settings:set-language"
+	AdminSettingsPeersAdd       = "admin:settings:peers:add"
+	AdminSettingsPeersRemove    = "admin:settings:peers:remove"
+	AdminSettingsSetBranding    = "admin:settings:set-branding"
+	AdminSettingsSetLogo        = "admin:settings:set-logo"
+	AdminSettingsRemoveLogo     = "admin:settings:remove-logo"
+	AdminSettingsSetOpenInvites = "admin:settings:set-open-invites"
 
 	AdminAliasesRevokeConfirm = "admin:aliases:revoke:confirm"
 	AdminAliasesRevoke        = "admin:aliases:revoke"
@@ -28,6 +34,7 @@ const (
 	AdminMembersOverview            = "admin:members:overview"
 	AdminMembersAdd                 = "admin:members:add"
 	AdminMembersChangeRole          = "admin:members:change-role"
+	AdminMembersSetHidden           = "admin:members:set-hidden"
 	AdminMembersCreateFallbackReset = "admin:members:create-password-reset-link"
 	AdminMembersRemoveConfirm       = "admin:members:remove:confirm"
 	AdminMembersRemove              = "admin:members:remove"
@@ -37,10 +44,27 @@ const (
 	AdminInvitesRevoke        = "admin:invites:revoke"
 	AdminInvitesCreate        = "admin:invites:create"
 
+	AdminJoinRequestsOverview = "admin:join-requests:overview"
+	AdminJoinRequestsApprove  = "admin:join-requests:approve"
+	AdminJoinRequestsDecline  = "admin:join-requests:decline"
+
 	AdminNoticeEdit             = "admin:notice:edit"
 	AdminNoticeSave             = "admin:notice:save"
 	AdminNoticeDraftTranslation = "admin:notice:translation:draft"
 	AdminNoticeAddTranslation   = "admin:notice:translation:add"
+	AdminNoticeRevision         = "admin:notice:revision"
+	AdminNoticeRevert           = "admin:notice:revert"
+
+	AdminPagesOverview      = "admin:pages:overview"
+	AdminPagesCreate        = "admin:pages:create"
+	AdminPagesUpdate        = "admin:pages:update"
+	AdminPagesRemoveConfirm = "admin:pages:remove:confirm"
+	AdminPagesRemove        = "admin:pages:remove"
+
+	AdminNewsOverview      = "admin:news:overview"
+	AdminNewsCreate        = "admin:news:create"
+	AdminNewsRemoveConfirm = "admin:news:remove:confirm"
+	AdminNewsRemove        = "admin:news:remove"
 )
 
 // Admin constructs a mux.Router containing the routes for the admin dashboard and settings pages
@@ -54,6 +78,12 @@ func Admin(m *mux.Router) *mux.Router {
 	m.Path("/settings").Methods("GET").Name(AdminSettings)
 	m.Path("/settings/set-privacy").Methods("POST").Name(AdminSettingsSetPrivacy)
 	m.Path("/settings/set-language").Methods("POST").Name(AdminSettingsSetLanguage)
+	m.Path("/settings/peers/add").Methods("POST").Name(AdminSettingsPeersAdd)
+	m.Path("/settings/peers/remove").Methods("POST").Name(AdminSettingsPeersRemove)
+	m.Path("/settings/branding").Methods("POST").Name(AdminSettingsSetBranding)
+	m.Path("/settings/logo").Methods("POST").Name(AdminSettingsSetLogo)
+	m.Path("/settings/logo/remove").Methods("POST").Name(AdminSettingsRemoveLogo)
+	m.Path("/settings/open-invites").Methods("POST").Name(AdminSettingsSetOpenInvites)
 
 	m.Path("/menu").Methods("GET").Name(AdminMenu)
 
@@ -70,6 +100,7 @@ func Admin(m *mux.Router) *mux.Router {
 	m.Path("/members").Methods("GET").Name(AdminMembersOverview)
 	m.Path("/members/add").Methods("POST").Name(AdminMembersAdd)
 	m.Path("/members/change-role").Methods("POST").Name(AdminMembersChangeRole)
+	m.Path("/members/set-hidden").Methods("POST").Name(AdminMembersSetHidden)
 	m.Path("/members/create-fallback-reset-link").Methods("POST").Name(AdminMembersCreateFallbackReset)
 	m.Path("/members/remove/confirm").Methods("GET").Name(AdminMembersRemoveConfirm)
 	m.Path("/members/remove").Methods("POST").Name(AdminMembersRemove)
@@ -78,11 +109,28 @@ func Admin(m *mux.Router) *mux.Router {
 	m.Path("/notice/translation/draft").Methods("GET").Name(AdminNoticeDraftTranslation)
 	m.Path("/notice/translation/add").Methods("POST").Name(AdminNoticeAddTranslation)
 	m.Path("/notice/save").Methods("POST").Name(AdminNoticeSave)
+	m.Path("/notice/revision").Methods("GET").Name(AdminNoticeRevision)
+	m.Path("/notice/revert").Methods("POST").Name(AdminNoticeRevert)
+
+	m.Path("/pages").Methods("GET").Name(AdminPagesOverview)
+	m.Path("/pages/create").Methods("POST").Name(AdminPagesCreate)
+	m.Path("/pages/update").Methods("POST").Name(AdminPagesUpdate)
+	m.Path("/pages/remove/confirm").Methods("GET").Name(AdminPagesRemoveConfirm)
+	m.Path("/pages/remove").Methods("POST").Name(AdminPagesRemove)
+
+	m.Path("/news").Methods("GET").Name(AdminNewsOverview)
+	m.Path("/news/create").Methods("POST").Name(AdminNewsCreate)
+	m.Path("/news/remove/confirm").Methods("GET").Name(AdminNewsRemoveConfirm)
+	m.Path("/news/remove").Methods("POST").Name(AdminNewsRemove)
 
 	m.Path("/invites").Methods("GET").Name(AdminInvitesOverview)
 	m.Path("/invites/revoke/confirm").Methods("GET").Name(AdminInvitesRevokeConfirm)
 	m.Path("/invites/revoke").Methods("POST").Name(AdminInvitesRevoke)
 	m.Path("/invites/create").Methods("POST").Name(AdminInvitesCreate)
 
+	m.Path("/join-requests").Methods("GET").Name(AdminJoinRequestsOverview)
+	m.Path("/join-requests/approve").Methods("POST").Name(AdminJoinRequestsApprove)
+	m.Path("/join-requests/decline").Methods("POST").Name(AdminJoinRequestsDecline)
+
 	return m
 }
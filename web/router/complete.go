@@ -15,8 +15,19 @@ const (
 	CompleteNoticeShow = "complete:notice:show"
 	CompleteNoticeList = "complete:notice:list"
 
+	CompletePageShow = "complete:page:show"
+
+	CompleteNewsList     = "complete:news:list"
+	CompleteNewsFeedAtom = "complete:news:feed:atom"
+	CompleteNewsFeedRSS  = "complete:news:feed:rss"
+	CompleteNewsFeedJSON = "complete:news:feed:json"
+
 	CompleteSetLanguage = "complete:set-language"
 
+	CompleteBrandingLogo  = "complete:branding:logo"
+	CompleteBrandingStyle = "complete:branding:style"
+	CompleteRoomInfo      = "complete:room-info"
+
 	CompleteAliasResolve = "complete:alias:resolve"
 
 	CompleteInviteFacade         = "complete:invite:accept"
@@ -24,9 +35,18 @@ const (
 	CompleteInviteInsertID       = "complete:invite:insert-id"
 	CompleteInviteConsume        = "complete:invite:consume"
 
+	CompleteJoinRequestForm   = "complete:join-request:form"
+	CompleteJoinRequestCreate = "complete:join-request:create"
+	CompleteJoinRequestStatus = "complete:join-request:status"
+
 	MembersChangePasswordForm = "members:change-password:form"
 	MembersChangePassword     = "members:change-password"
 
+	MembersCodeOfConductForm   = "members:code-of-conduct:form"
+	MembersCodeOfConductAccept = "members:code-of-conduct:accept"
+
+	MembersSignedIn = "members:signed-in"
+
 	OpenModeCreateInvite = "open:invites:create"
 )
 
@@ -44,16 +64,36 @@ func CompleteApp() *mux.Router {
 	m.Path("/members/change-password").Methods("GET").Name(MembersChangePasswordForm)
 	m.Path("/members/change-password").Methods("POST").Name(MembersChangePassword)
 
+	m.Path("/members/code-of-conduct").Methods("GET").Name(MembersCodeOfConductForm)
+	m.Path("/members/code-of-conduct").Methods("POST").Name(MembersCodeOfConductAccept)
+
+	m.Path("/members/signed-in").Methods("GET").Name(MembersSignedIn)
+
 	m.Path("/create-invite").Methods("GET", "POST").Name(OpenModeCreateInvite)
 	m.Path("/join").Methods("GET").Name(CompleteInviteFacade)
 	m.Path("/join-fallback").Methods("GET").Name(CompleteInviteFacadeFallback)
 	m.Path("/join-manually").Methods("GET").Name(CompleteInviteInsertID)
 	m.Path("/invite/consume").Methods("POST").Name(CompleteInviteConsume)
 
+	m.Path("/request-to-join").Methods("GET").Name(CompleteJoinRequestForm)
+	m.Path("/request-to-join").Methods("POST").Name(CompleteJoinRequestCreate)
+	m.Path("/request-to-join/status").Methods("GET").Name(CompleteJoinRequestStatus)
+
 	m.Path("/notice/show").Methods("GET").Name(CompleteNoticeShow)
 	m.Path("/notice/list").Methods("GET").Name(CompleteNoticeList)
 
+	m.Path("/page/{slug}").Methods("GET").Name(CompletePageShow)
+
+	m.Path("/news").Methods("GET").Name(CompleteNewsList)
+	m.Path("/news/feed.atom").Methods("GET").Name(CompleteNewsFeedAtom)
+	m.Path("/news/feed.rss").Methods("GET").Name(CompleteNewsFeedRSS)
+	m.Path("/news/feed.json").Methods("GET").Name(CompleteNewsFeedJSON)
+
 	m.Path("/set-language").Methods("POST").Name(CompleteSetLanguage)
 
+	m.Path("/branding/logo").Methods("GET").Name(CompleteBrandingLogo)
+	m.Path("/branding/style.css").Methods("GET").Name(CompleteBrandingStyle)
+	m.Path("/.well-known/ssb-room.json").Methods("GET").Name(CompleteRoomInfo)
+
 	return m
 }